@@ -1,13 +1,38 @@
 package main
 
 import (
+	"context"
+	"database/sql"
+	"flag"
 	_ "igaming/docs" // This is important!
+	"igaming/internal/clock"
 	"igaming/internal/config"
+	"igaming/internal/dialect"
+	"igaming/internal/events"
+	"igaming/internal/fixtures"
+	"igaming/internal/health"
+	"igaming/internal/jobs"
+	"igaming/internal/logging"
+	"igaming/internal/metrics"
+	"igaming/internal/migrations"
+	"igaming/internal/ranking"
+	"igaming/internal/repository"
+	"igaming/internal/repository/memory"
+	"igaming/internal/repository/sqlstore"
 	"igaming/internal/server"
+	"igaming/internal/tracing"
 	"log"
-	"net/http"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
+// ratingCatchUpInterval is how often tournaments that could not be rated
+// when they were settled are retried.
+const ratingCatchUpInterval = 5 * time.Minute
+
 // Package main iGaming API
 // @title iGaming API
 // @version 1.0
@@ -15,13 +40,221 @@ import (
 // @host localhost:8080
 // @BasePath /
 func main() {
-    cfg := config.LoadConfig()
+    configPath := flag.String("config", os.Getenv("CONFIG_FILE"), "YAML or JSON config file, overridden by environment variables")
+    printConfig := flag.Bool("print-config", false, "print the effective configuration with secrets redacted and exit")
+    flag.Parse()
+
+    // Everything is logged as JSON lines, including what goes through the
+    // standard log package.
+    logger := logging.New(os.Stderr, slog.LevelInfo)
+    slog.SetDefault(logger)
+
+    if *printConfig {
+        cfg, err := config.Load(*configPath)
+        if err != nil {
+            log.Fatal(err)
+        }
+        if err := cfg.Print(os.Stdout); err != nil {
+            log.Fatal(err)
+        }
+        if err := cfg.Validate(); err != nil {
+            log.Fatal(err)
+        }
+        return
+    }
+
+    cfg, err := config.LoadConfig(*configPath)
+    if err != nil {
+        log.Fatal(err)
+    }
+
+    shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing.Exporter, cfg.Tracing.Endpoint, cfg.Tracing.SampleRatio)
+    if err != nil {
+        log.Fatal(err)
+    }
+
+    clk := clock.System()
+    m := metrics.New()
+
+    var db *sql.DB
+    var sqlDialect dialect.Dialect
+    var store *repository.Store
+    if cfg.Storage.Backend == config.StorageMemory {
+        if flag.NArg() > 0 {
+            log.Fatalf("The %s command needs a database storage backend (%s, %s or %s)", flag.Arg(0), config.StorageSQLite, config.StorageMySQL, config.StoragePostgres)
+        }
+        store, err = newMemoryStore(cfg.Storage.Dataset, clk)
+        if err != nil {
+            log.Fatal(err)
+        }
+        log.Printf("Using in-memory storage; all data is lost on exit")
+    } else {
+        db = config.InitDB(cfg)
+        sqlDialect = dialect.Dialect(cfg.Storage.Backend)
+
+        migrator, err := migrations.NewMigrator(db, sqlDialect, cfg.Migrations.LockTimeout)
+        if err != nil {
+            log.Fatal(err)
+        }
+        if flag.Arg(0) == "migrate" {
+            os.Exit(runMigrate(migrator, flag.Args()[1:]))
+        }
+        if flag.Arg(0) == "seed" {
+            if _, err := migrator.Check(context.Background()); err != nil {
+                log.Fatal(err)
+            }
+            os.Exit(runSeed(db, sqlDialect, flag.Args()[1:]))
+        }
+        if flag.NArg() > 0 {
+            log.Fatalf("Unknown command %q", flag.Arg(0))
+        }
+
+        // Nothing else migrates a SQLite file, so the service always does.
+        if cfg.Migrations.AutoMigrate || sqlDialect == dialect.SQLite {
+            if _, err := migrator.Up(context.Background()); err != nil {
+                log.Fatalf("Failed to migrate the database: %v", err)
+            }
+        }
+        // A newer schema means a newer release has migrated the database; this
+        // build may not know how to use it, so refuse to start.
+        if version, err := migrator.Check(context.Background()); err != nil {
+            log.Fatal(err)
+        } else if version < migrator.Latest() {
+            log.Printf("Database is at version %d, expected %d; run migrations before serving", version, migrator.Latest())
+        }
+
+        store = sqlstore.NewStore(db, sqlDialect, clk, repository.NewRetrier(m))
+    }
+
+    // Cancelled by SIGINT/SIGTERM to start the graceful shutdown.
+    ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+    defer stop()
+
+    hub := events.NewHub(events.DefaultHistorySize, events.DefaultBufferSize, events.DefaultIdleTTL)
+
+    // The rankings are served from memory; load them before accepting
+    // requests and keep them in sync with the database in the background.
+    rankings := ranking.NewService(store.Players, store.Tournaments, store.Snapshots, clk)
+    if err := rankings.Rebuild(ctx); err != nil {
+        log.Fatalf("Failed to load rankings: %v", err)
+    }
+
+    scheduler := jobs.NewScheduler()
+    scheduler.Add(jobs.Job{
+        Name:     "ranking-rebuild",
+        Interval: ranking.DefaultRefreshInterval,
+        Run:      rankings.Rebuild,
+    })
+    if cfg.Features.RankHistory {
+        scheduler.Add(jobs.Job{
+            Name:       "ranking-snapshot",
+            Interval:   repository.DefaultSnapshotInterval,
+            RunAtStart: true,
+            Run: func(ctx context.Context) error {
+                if err := store.Snapshots.Take(ctx); err != nil {
+                    return err
+                }
+                if err := pruneSnapshots(ctx, store.Snapshots, cfg.Snapshots, clk.Now()); err != nil {
+                    return err
+                }
+                return rankings.RefreshHistory(ctx)
+            },
+        })
+    }
+    if cfg.Features.RatingCatchUp {
+        scheduler.Add(jobs.Job{
+            Name:       "rating-catchup",
+            Interval:   ratingCatchUpInterval,
+            RunAtStart: true,
+            Run: func(ctx context.Context) error {
+                _, err := store.Ratings.ApplyPending(ctx)
+                return err
+            },
+        })
+    }
+
+    // Jobs get their own context so they keep running while in-flight
+    // requests drain.
+    jobsCtx, stopJobs := context.WithCancel(context.Background())
+    scheduler.Start(jobsCtx)
+
+    if db != nil {
+        m.RegisterDB(db, string(sqlDialect))
+    }
+    m.RegisterLiability(store.Players.TotalBalance, metrics.DefaultScrapeTimeout)
+
+    checker := health.NewChecker(db, sqlDialect, scheduler, health.DefaultTimeout)
+    router := server.NewRouter(store, checker, cfg.Features, hub, rankings, clk, logger, m)
+    srv := server.NewHTTPServer(cfg.Server, router)
+
+    serveErr := make(chan error, 1)
+    go func() {
+        log.Printf("Server starting on %s (TLS: %t)", srv.Addr, cfg.Server.TLS())
+        serveErr <- server.ListenAndServe(srv, cfg.Server)
+    }()
+
+    select {
+    case err := <-serveErr:
+        if err != nil {
+            log.Fatalf("Server failed: %v", err)
+        }
+        return
+    case <-ctx.Done():
+    }
+    stop()
+
+    log.Printf("Shutting down (timeout %s)", cfg.Server.ShutdownTimeout)
+    shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+    defer cancel()
+
+    // Streams never finish on their own, so end them before waiting for
+    // the other requests to drain.
+    hub.Close()
+    if err := srv.Shutdown(shutdownCtx); err != nil {
+        log.Printf("HTTP shutdown incomplete: %v", err)
+    }
+
+    stopJobs()
+    jobsDone := make(chan struct{})
+    go func() {
+        scheduler.Wait()
+        close(jobsDone)
+    }()
+    select {
+    case <-jobsDone:
+    case <-shutdownCtx.Done():
+        log.Printf("Background jobs still running at shutdown deadline")
+    }
 
-    db := config.InitDB(cfg)
-    defer db.Close()
+    if db != nil {
+        if err := db.Close(); err != nil {
+            log.Printf("Failed to close database: %v", err)
+        }
+    }
+    if err := shutdownTracing(shutdownCtx); err != nil {
+        log.Printf("Failed to send the remaining traces: %v", err)
+    }
+    log.Println("Server stopped")
+}
 
-    router := server.NewRouter(db)
+// newMemoryStore returns an in-memory store holding the named fixtures
+// dataset, or nothing when dataset is empty.
+func newMemoryStore(dataset string, clk clock.Clock) (*repository.Store, error) {
+    if dataset == "" {
+        return memory.NewStore(clk), nil
+    }
+    data, err := fixtures.Dataset(dataset)
+    if err != nil {
+        return nil, err
+    }
+    return memory.NewSeededStore(clk, data), nil
+}
 
-    log.Println("Server starting on :8080")
-    log.Fatal(http.ListenAndServe(":8080", router))
-}
\ No newline at end of file
+// pruneSnapshots applies the snapshot retention of cfg at now.
+func pruneSnapshots(ctx context.Context, snapshots repository.RankingSnapshotRepository, cfg config.SnapshotConfig, now time.Time) error {
+    var before time.Time
+    if cfg.Retention > 0 {
+        before = now.Add(-cfg.Retention)
+    }
+    return snapshots.Prune(ctx, now.Add(-cfg.HourlyRetention), before)
+}
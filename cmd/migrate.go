@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"igaming/internal/migrations"
+	"log"
+	"os"
+	"text/tabwriter"
+)
+
+// runMigrate runs the migrate subcommand and returns the exit code.
+func runMigrate(migrator *migrations.Migrator, args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "usage: main [flags] migrate up|down|status")
+		return 2
+	}
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			log.Print(err)
+			return 1
+		}
+		if len(applied) == 0 {
+			log.Printf("Database is up to date at version %d", migrator.Latest())
+		}
+	case "down":
+		version, err := migrator.Down(ctx)
+		if err != nil {
+			log.Print(err)
+			return 1
+		}
+		if version == 0 {
+			log.Print("No migrations to roll back")
+		}
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Print(err)
+			return 1
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tAPPLIED AT\tMIGRATION")
+		for _, s := range statuses {
+			applied := "pending"
+			if s.AppliedAt != nil {
+				applied = s.AppliedAt.UTC().Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", s.Version, applied, s.Name)
+		}
+		w.Flush()
+	default:
+		fmt.Fprintf(os.Stderr, "unknown migrate command %q\n", args[0])
+		return 2
+	}
+	return 0
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"igaming/internal/dialect"
+	"igaming/internal/fixtures"
+	"log"
+	"strings"
+	"time"
+)
+
+// runSeed runs the seed subcommand and returns the exit code.
+func runSeed(db *sql.DB, d dialect.Dialect, args []string) int {
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	reset := fs.Bool("reset", false, "delete all existing data first")
+	players := fs.Int("players", 1000, "players to generate")
+	tournaments := fs.Int("tournaments", 50, "tournaments to generate")
+	bets := fs.Int("bets", 20000, "bets to generate")
+	seed := fs.Uint64("seed", 1, "random seed for generate")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: main [flags] seed [-reset] %s|generate [generate flags]\n", strings.Join(fixtures.Names(), "|"))
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	var data fixtures.Data
+	if name := fs.Arg(0); name == "generate" {
+		if *players < 0 || *tournaments < 0 || *bets < 0 || (*bets > 0 && (*players == 0 || *tournaments == 0)) {
+			log.Print("Bets need at least one player and one tournament")
+			return 2
+		}
+		now := time.Now().UTC()
+		data = fixtures.Generate(fixtures.GenerateOptions{
+			Players:     *players,
+			Tournaments: *tournaments,
+			Bets:        *bets,
+			Seed:        *seed,
+			Start:       now.Truncate(24*time.Hour).AddDate(-1, 0, 0),
+			Now:         now,
+		})
+	} else {
+		var err error
+		if data, err = fixtures.Dataset(name); err != nil {
+			log.Print(err)
+			return 2
+		}
+	}
+
+	ctx := context.Background()
+	if *reset {
+		if err := fixtures.Reset(ctx, db); err != nil {
+			log.Print(err)
+			return 1
+		}
+		log.Print("Deleted all existing data")
+	}
+
+	started := time.Now()
+	counts, err := fixtures.Insert(ctx, db, d, data)
+	if err != nil {
+		log.Print(err)
+		return 1
+	}
+	log.Printf("Inserted %d players, %d tournaments and %d bets in %s",
+		counts.Players, counts.Tournaments, counts.Bets, time.Since(started).Round(time.Millisecond))
+	return 0
+}
@@ -15,6 +15,96 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/exclusions": {
+            "get": {
+                "description": "Admin view of every self-exclusion currently in force",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get active exclusions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.PlayerExclusionResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/rankings/consistency": {
+            "get": {
+                "description": "Compare the in-memory rankings and tournament leaderboards with the player_rankings view and the bets in the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Check ranking consistency",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ranking.Report"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/ratings/recompute": {
+            "post": {
+                "description": "Discards all ratings and rating history and rates every settled tournament again, in settlement order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Recompute skill ratings",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RecomputeRatingsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/bets": {
             "get": {
                 "description": "Retrieve list of all placed bets",
@@ -79,13 +169,25 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "$ref": "#/definitions/handlers.DetailedErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DetailedErrorResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "$ref": "#/definitions/handlers.DetailedErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DetailedErrorResponse"
                         }
                     },
                     "500": {
@@ -97,6 +199,55 @@ const docTemplate = `{
                 }
             }
         },
+        "/health/live": {
+            "get": {
+                "description": "Reports that the process is up and serving HTTP. It checks no dependencies, so a database outage does not get the service restarted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/health/ready": {
+            "get": {
+                "description": "Pings the database, checks the schema is at the migration version this build expects and reports the background jobs. Returns 503 when a check fails; a degraded check (e.g. a failing background job) is reported but keeps the service ready. /health is an alias.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
+                    }
+                }
+            }
+        },
         "/players": {
             "get": {
                 "description": "Retrieve list of all registered players",
@@ -164,6 +315,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -173,9 +330,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/rankings": {
+        "/players/{id}/exclusions": {
             "get": {
-                "description": "Get ranked list of players by account balance",
+                "description": "Get every self-exclusion of a player, newest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -183,19 +340,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "rankings"
+                    "players"
+                ],
+                "summary": "Get player exclusions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get player rankings",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.PlayerRanking"
+                                "$ref": "#/definitions/dtos.PlayerExclusionResponse"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -203,11 +375,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/tournaments": {
-            "get": {
-                "description": "Get list of all tournaments",
+            },
+            "post": {
+                "description": "Exclude a player from betting for 24 hours, 7 days, 6 months or permanently. Exclusions cannot be lifted early.",
                 "consumes": [
                     "application/json"
                 ],
@@ -215,17 +385,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tournaments"
+                    "players"
+                ],
+                "summary": "Self-exclude a player",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Exclusion duration",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreatePlayerExclusionRequest"
+                        }
+                    }
                 ],
-                "summary": "Get all tournaments",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Tournament"
-                            }
+                            "$ref": "#/definitions/dtos.PlayerExclusionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
                     "500": {
@@ -235,9 +432,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Creates a new tournament with the provided details",
+            }
+        },
+        "/players/{id}/limits": {
+            "get": {
+                "description": "Get a player's responsible gambling limits with their usage in the current period",
                 "consumes": [
                     "application/json"
                 ],
@@ -245,25 +444,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tournaments"
+                    "players"
                 ],
-                "summary": "Create a new tournament",
+                "summary": "Get player limits",
                 "parameters": [
                     {
-                        "description": "Tournament Creation Data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dtos.CreateTournamentRequest"
-                        }
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.TournamentResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.PlayerLimitResponse"
+                            }
                         }
                     },
                     "400": {
@@ -272,6 +472,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -279,11 +485,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/tournaments/prizes/{id}": {
-            "post": {
-                "description": "Calculate and distribute prizes for a completed tournament",
+            },
+            "put": {
+                "description": "Set or remove responsible gambling limits. Lower limits apply immediately, higher or removed limits only after a cooling-off period.",
                 "consumes": [
                     "application/json"
                 ],
@@ -291,24 +495,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tournaments"
+                    "players"
                 ],
-                "summary": "Distribute tournament prizes",
+                "summary": "Set player limits",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Tournament ID",
+                        "description": "Player ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Limit changes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SetPlayerLimitsRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "202": {
-                        "description": "message: Prizes distributed successfully",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.PlayerLimitResponse"
+                            }
                         }
                     },
                     "400": {
@@ -331,16 +546,899 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dtos.CreatePlayerRequest": {
-            "type": "object",
-            "required": [
-                "email",
-                "name",
-                "password"
-            ],
+        },
+        "/players/{id}/rank": {
+            "get": {
+                "description": "Get the player's position in the global balance ranking",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rankings"
+                ],
+                "summary": "Get a player's rank",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PlayerRanking"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/players/{id}/rankings/history": {
+            "get": {
+                "description": "The player's rank and balance in every ranking snapshot between from and to (default the last 30 days, at most 366 days). Dates are RFC 3339 timestamps or YYYY-MM-DD; a date-only to includes the whole day.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rankings"
+                ],
+                "summary": "Get a player's rank history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the range",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the range",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.RankingSnapshot"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/players/{id}/rating": {
+            "get": {
+                "description": "The player's Elo rating from their tournament placements, with their 20 most recent rating changes. Players who have not played a settled tournament have the initial rating of 1500.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ratings"
+                ],
+                "summary": "Get a player's skill rating",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PlayerRatingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/rankings": {
+            "get": {
+                "description": "Get ranked list of players by account balance, served from the in-memory ranking. Without limit every ranked player is returned; the X-Total-Count header has the number of ranked players. rank_change_day and rank_change_week are the places moved up since the snapshot a day and a week ago.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rankings"
+                ],
+                "summary": "Get player rankings",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of players to return (top-N)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset of the first player",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.PlayerRanking"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/rankings/climbers": {
+            "get": {
+                "description": "Ranked players who moved up the most places since yesterday or last week, biggest climb first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rankings"
+                ],
+                "summary": "Get biggest climbers",
+                "parameters": [
+                    {
+                        "enum": [
+                            "day",
+                            "week"
+                        ],
+                        "type": "string",
+                        "description": "Period to compare against (default day)",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of players (default 10, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.PlayerRanking"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ratings": {
+            "get": {
+                "description": "Rated players ordered by skill rating. The X-Total-Count header has the number of ranked players.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ratings"
+                ],
+                "summary": "Get the rating leaderboard",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 50, max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset of the first player",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.PlayerRating"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seasons": {
+            "get": {
+                "description": "Get all seasons, latest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seasons"
+                ],
+                "summary": "List seasons",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.SeasonResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a season with its prize pool and points table. Tournaments join a season through their season_id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seasons"
+                ],
+                "summary": "Create a season",
+                "parameters": [
+                    {
+                        "description": "Season",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateSeasonRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SeasonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seasons/{id}": {
+            "get": {
+                "description": "Get a season with its points table",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seasons"
+                ],
+                "summary": "Get a season",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Season ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SeasonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seasons/{id}/points": {
+            "put": {
+                "description": "Replaces the points awarded per tournament placement. Standings are recomputed from the table, including tournaments already played. Not allowed once the season prizes have been paid.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seasons"
+                ],
+                "summary": "Set a season's points table",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Season ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Points table",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SetSeasonPointsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SeasonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seasons/{id}/prizes": {
+            "post": {
+                "description": "Pays out the season prize pool to the top three placements with the same 50/30/20 split and tie sharing as tournament prizes. Only possible once the season has ended.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seasons"
+                ],
+                "summary": "Distribute season prizes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Season ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "message: Prizes distributed successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seasons/{id}/standings": {
+            "get": {
+                "description": "Players ranked by season points from their tournament placements. Ties are broken by most wins, then by who reached their total first. Each entry shows the season prize for its placement.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seasons"
+                ],
+                "summary": "Get season standings",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Season ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SeasonStandingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournaments": {
+            "get": {
+                "description": "Get list of all tournaments",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tournaments"
+                ],
+                "summary": "Get all tournaments",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Tournament"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a new tournament with the provided details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tournaments"
+                ],
+                "summary": "Create a new tournament",
+                "parameters": [
+                    {
+                        "description": "Tournament Creation Data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateTournamentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.TournamentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournaments/prizes/{id}": {
+            "post": {
+                "description": "Calculate and distribute prizes for a completed tournament",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tournaments"
+                ],
+                "summary": "Distribute tournament prizes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tournament ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "message: Prizes distributed successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournaments/{id}/leaderboard": {
+            "get": {
+                "description": "Live standings of a tournament ranked by total bet, with tie groups, projected prizes and the gap to the next placement. With player_id the page is centred on that player.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tournaments"
+                ],
+                "summary": "Get tournament leaderboard",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tournament ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 50, max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset of the first entry",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Return the page around this player",
+                        "name": "player_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.LeaderboardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournaments/{id}/stream": {
+            "get": {
+                "description": "Server-sent events stream of bets, leaderboard deltas and settlement of a tournament. Send a WebSocket upgrade request to receive the same events as JSON messages. Resume with the Last-Event-ID header or the last_event_id parameter.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "tournaments"
+                ],
+                "summary": "Stream tournament updates",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tournament ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the last event received",
+                        "name": "last_event_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/events.Event"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dtos.CreatePlayerExclusionRequest": {
+            "type": "object",
+            "required": [
+                "duration"
+            ],
+            "properties": {
+                "duration": {
+                    "description": "How long the player is excluded for\nrequired: true\nexample: 7d",
+                    "type": "string",
+                    "enum": [
+                        "24h",
+                        "7d",
+                        "6m",
+                        "permanent"
+                    ]
+                }
+            }
+        },
+        "dtos.CreatePlayerRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "password"
+            ],
             "properties": {
                 "account_balance": {
                     "description": "Initial account balance\nminimum: 0\nexample: 100.00",
@@ -364,6 +1462,36 @@ const docTemplate = `{
                 }
             }
         },
+        "dtos.CreateSeasonRequest": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "description": "format: date-time\nexample: 2024-06-30T23:59:59Z",
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "name": {
+                    "description": "Season name\nrequired: true\nexample: Season 2024",
+                    "type": "string"
+                },
+                "points_table": {
+                    "description": "Points per placement; 10/6/4 for placements 1-3 when omitted",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.SeasonPointsRequest"
+                    }
+                },
+                "prize_pool": {
+                    "description": "Prize pool paid out when the season ends\nexample: 10000",
+                    "type": "number"
+                },
+                "start_date": {
+                    "description": "format: date-time\nexample: 2024-01-01T00:00:00Z",
+                    "type": "string",
+                    "format": "date-time"
+                }
+            }
+        },
         "dtos.CreateTournamentBetRequest": {
             "type": "object",
             "required": [
@@ -389,59 +1517,453 @@ const docTemplate = `{
         "dtos.CreateTournamentRequest": {
             "type": "object",
             "required": [
-                "name",
-                "prize_pool"
+                "name"
             ],
             "properties": {
+                "betting_closes_at": {
+                    "description": "When betting closes; defaults to end_date\nexample: 2023-09-05T18:00:00Z",
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "betting_opens_at": {
+                    "description": "When betting opens; bets are accepted any time before closing when omitted\nexample: 2023-08-31T15:00:00Z",
+                    "type": "string",
+                    "format": "date-time"
+                },
                 "end_date": {
                     "description": "format: date-time\nexample: 2023-09-05T18:00:00Z",
                     "type": "string",
                     "format": "date-time"
                 },
+                "entry_fee": {
+                    "description": "Fixed entry fee; players enter once by betting exactly this amount (optional)\nexample: 50",
+                    "type": "number"
+                },
+                "guaranteed_prize_pool": {
+                    "description": "Guaranteed minimum prize pool in accumulating mode\nexample: 1000",
+                    "type": "number"
+                },
+                "late_registration_minutes": {
+                    "description": "Minutes after start_date during which new players may still join (optional)\nexample: 30",
+                    "type": "integer"
+                },
+                "max_bet": {
+                    "description": "Maximum amount of a single bet (optional)\nexample: 500",
+                    "type": "number"
+                },
+                "max_participants": {
+                    "description": "Maximum number of distinct participants (optional)\nexample: 100",
+                    "type": "integer"
+                },
+                "max_stake_per_player": {
+                    "description": "Maximum total stake per player (optional)\nexample: 2000",
+                    "type": "number"
+                },
+                "min_bet": {
+                    "description": "Minimum amount of a single bet (optional)\nexample: 10",
+                    "type": "number"
+                },
                 "name": {
                     "description": "Tournament name (3-100 characters)\nexample: tournament123\ndefault: tournament123",
                     "type": "string",
                     "maxLength": 100,
                     "minLength": 3
                 },
+                "pool_mode": {
+                    "description": "Prize pool mode: \"fixed\" (default) or \"accumulating\"\nexample: fixed",
+                    "type": "string",
+                    "enum": [
+                        "fixed",
+                        "accumulating"
+                    ]
+                },
                 "prize_pool": {
-                    "description": "Prize pool amount (must be positive)\nexample: 3333\ndefault: 3333",
+                    "description": "Prize pool amount (must be positive in fixed mode)\nexample: 3333\ndefault: 3333",
                     "type": "number",
-                    "default": 3333
+                    "default": 3333,
+                    "minimum": 0
+                },
+                "rake_percentage": {
+                    "description": "Percentage of every bet kept as house rake in accumulating mode (0-100)\nexample: 10",
+                    "type": "number"
+                },
+                "season_id": {
+                    "description": "Season the tournament counts towards (optional)\nexample: 1",
+                    "type": "integer"
+                },
+                "start_date": {
+                    "description": "format: date-time\nexample: 2023-09-01T15:00:00Z",
+                    "type": "string",
+                    "format": "date-time"
+                }
+            }
+        },
+        "dtos.LeaderboardEntryResponse": {
+            "type": "object",
+            "properties": {
+                "gap_to_next": {
+                    "description": "Additional bet needed to draw level with the next placement up\nexample: 250.00",
+                    "type": "number"
+                },
+                "placement": {
+                    "description": "Placement shared by all players in the tie group\nexample: 3",
+                    "type": "integer"
+                },
+                "player_id": {
+                    "description": "Player ID\nexample: 123",
+                    "type": "integer"
+                },
+                "player_name": {
+                    "description": "Player name\nexample: JohnDoe123",
+                    "type": "string"
+                },
+                "position": {
+                    "description": "1-based row in the leaderboard\nexample: 4",
+                    "type": "integer"
+                },
+                "projected_prize": {
+                    "description": "Prize the player would win if the tournament were settled now\nexample: 2500.00",
+                    "type": "number"
+                },
+                "tie_group_size": {
+                    "description": "Number of players sharing the placement\nexample: 2",
+                    "type": "integer"
+                },
+                "total_bet": {
+                    "description": "Total amount the player has bet in the tournament\nexample: 750.00",
+                    "type": "number"
+                }
+            }
+        },
+        "dtos.LeaderboardResponse": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "description": "Leaderboard entries",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.LeaderboardEntryResponse"
+                    }
+                },
+                "limit": {
+                    "description": "Maximum number of entries in the page\nexample: 50",
+                    "type": "integer"
+                },
+                "offset": {
+                    "description": "Offset of the first entry\nexample: 0",
+                    "type": "integer"
+                },
+                "prize_pool": {
+                    "description": "Prize pool the projections are based on\nexample: 25000.00",
+                    "type": "number"
+                },
+                "total_participants": {
+                    "description": "Number of players with at least one bet\nexample: 42",
+                    "type": "integer"
+                },
+                "tournament_id": {
+                    "description": "Tournament ID\nexample: 456",
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.PlayerExclusionResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Whether the exclusion is currently in force\nexample: true",
+                    "type": "boolean"
+                },
+                "duration": {
+                    "description": "Exclusion duration\nexample: 7d",
+                    "type": "string"
+                },
+                "ends_at": {
+                    "description": "When the exclusion ends (never when null)\nexample: 2023-09-08T10:15:00Z",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Exclusion ID\nexample: 1",
+                    "type": "integer"
+                },
+                "player_id": {
+                    "description": "Excluded player ID\nexample: 123",
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "description": "When the exclusion started\nexample: 2023-09-01T10:15:00Z",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.PlayerLimitChange": {
+            "type": "object",
+            "required": [
+                "period",
+                "type"
+            ],
+            "properties": {
+                "amount": {
+                    "description": "New limit amount; null removes the limit\nminimum: 0.01\nexample: 100.00",
+                    "type": "number"
+                },
+                "period": {
+                    "description": "Limit period\nrequired: true\nexample: daily",
+                    "type": "string",
+                    "enum": [
+                        "daily",
+                        "weekly",
+                        "monthly"
+                    ]
+                },
+                "type": {
+                    "description": "Limit type: wager (total bets) or loss (bets minus prizes won)\nrequired: true\nexample: wager",
+                    "type": "string",
+                    "enum": [
+                        "wager",
+                        "loss"
+                    ]
+                }
+            }
+        },
+        "dtos.PlayerLimitResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Limit currently in force (no limit when null)\nexample: 100.00",
+                    "type": "number"
+                },
+                "pending_amount": {
+                    "description": "Limit that takes effect after the cooling-off period\nexample: 250.00",
+                    "type": "number"
+                },
+                "pending_effective_at": {
+                    "description": "When the pending change takes effect\nexample: 2023-09-02T10:15:00Z",
+                    "type": "string"
+                },
+                "period": {
+                    "description": "Limit period\nexample: daily",
+                    "type": "string"
+                },
+                "remaining": {
+                    "description": "Amount left in the current period (null when there is no limit)\nexample: 60.00",
+                    "type": "number"
+                },
+                "type": {
+                    "description": "Limit type\nexample: wager",
+                    "type": "string"
+                },
+                "used": {
+                    "description": "Amount used in the current period\nexample: 40.00",
+                    "type": "number"
+                }
+            }
+        },
+        "dtos.PlayerRatingResponse": {
+            "type": "object",
+            "properties": {
+                "history": {
+                    "description": "Most recent rating changes, newest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RatingChange"
+                    }
+                },
+                "player_id": {
+                    "type": "integer"
+                },
+                "player_name": {
+                    "type": "string"
+                },
+                "rank": {
+                    "description": "Dense rank among rated players, nil when not ranked (unrated,\nself-excluded or deleted)",
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "tournaments_played": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.PlayerResponse": {
+            "type": "object",
+            "properties": {
+                "account_balance": {
+                    "description": "Current account balance\nexample: 150.50",
+                    "type": "number"
+                },
+                "created_at": {
+                    "description": "Account creation timestamp\nexample: 2023-08-15T14:30:45Z",
+                    "type": "string"
+                },
+                "email": {
+                    "description": "The player's email\nexample: john.doe@example.com",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "The player ID\nexample: 1",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "The player's display name\nexample: JohnDoe123",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "Last update timestamp\nexample: 2023-08-16T09:15:22Z",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.RecomputeRatingsResponse": {
+            "type": "object",
+            "properties": {
+                "players": {
+                    "description": "Players with a rating\nexample: 310",
+                    "type": "integer"
+                },
+                "tournaments": {
+                    "description": "Settled tournaments rated\nexample: 42",
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.SeasonPointsRequest": {
+            "type": "object",
+            "properties": {
+                "placement": {
+                    "description": "Tournament placement (1-based), up to the number of prize tiers\nexample: 1",
+                    "type": "integer"
+                },
+                "points": {
+                    "description": "Points awarded for the placement\nexample: 10",
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.SeasonResponse": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "description": "format: date-time",
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "id": {
+                    "description": "example: 1",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "example: Season 2024",
+                    "type": "string"
+                },
+                "points_table": {
+                    "description": "Points per placement (omitted in lists)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.SeasonPointsRequest"
+                    }
+                },
+                "prize_pool": {
+                    "description": "example: 10000",
+                    "type": "number"
+                },
+                "prizes_distributed": {
+                    "description": "Whether the season prizes have been paid out",
+                    "type": "boolean"
                 },
                 "start_date": {
-                    "description": "format: date-time\nexample: 2023-09-01T15:00:00Z",
+                    "description": "format: date-time",
                     "type": "string",
                     "format": "date-time"
                 }
             }
         },
-        "dtos.PlayerResponse": {
+        "dtos.SeasonStandingResponse": {
             "type": "object",
             "properties": {
-                "account_balance": {
-                    "description": "Current account balance\nexample: 150.50",
-                    "type": "number"
+                "achieved_at": {
+                    "description": "When the player reached their points total (second tie-breaker)\nformat: date-time",
+                    "type": "string",
+                    "format": "date-time"
                 },
-                "created_at": {
-                    "description": "Account creation timestamp\nexample: 2023-08-15T14:30:45Z",
-                    "type": "string"
+                "placement": {
+                    "description": "Placement after tie-breakers; equal only for a full tie\nexample: 1",
+                    "type": "integer"
                 },
-                "email": {
-                    "description": "The player's email\nexample: john.doe@example.com",
+                "player_id": {
+                    "description": "example: 123",
+                    "type": "integer"
+                },
+                "player_name": {
+                    "description": "example: JohnDoe123",
                     "type": "string"
                 },
-                "id": {
-                    "description": "The player ID\nexample: 1",
+                "points": {
+                    "description": "example: 26",
                     "type": "integer"
                 },
-                "name": {
-                    "description": "The player's display name\nexample: JohnDoe123",
-                    "type": "string"
+                "prize": {
+                    "description": "Season prize for the placement: paid once distributed, projected before\nexample: 5000",
+                    "type": "number"
                 },
-                "updated_at": {
-                    "description": "Last update timestamp\nexample: 2023-08-16T09:15:22Z",
-                    "type": "string"
+                "tournaments_placed": {
+                    "description": "Tournaments finished in a ranked placement\nexample: 3",
+                    "type": "integer"
+                },
+                "wins": {
+                    "description": "Tournaments won (first tie-breaker)\nexample: 2",
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.SeasonStandingsResponse": {
+            "type": "object",
+            "properties": {
+                "prize_pool": {
+                    "type": "number"
+                },
+                "prizes_distributed": {
+                    "type": "boolean"
+                },
+                "season_id": {
+                    "type": "integer"
+                },
+                "standings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.SeasonStandingResponse"
+                    }
+                }
+            }
+        },
+        "dtos.SetPlayerLimitsRequest": {
+            "type": "object",
+            "required": [
+                "limits"
+            ],
+            "properties": {
+                "limits": {
+                    "description": "Limits to change; limits not listed are left untouched\nrequired: true",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.PlayerLimitChange"
+                    }
+                }
+            }
+        },
+        "dtos.SetSeasonPointsRequest": {
+            "type": "object",
+            "properties": {
+                "points_table": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.SeasonPointsRequest"
+                    }
                 }
             }
         },
@@ -464,6 +1986,10 @@ const docTemplate = `{
                     "description": "Player ID\nexample: 123",
                     "type": "integer"
                 },
+                "rake_amount": {
+                    "description": "Part of the bet kept by the house as rake\nexample: 5.00",
+                    "type": "number"
+                },
                 "tournament_id": {
                     "description": "Tournament ID\nexample: 456",
                     "type": "integer"
@@ -473,6 +1999,16 @@ const docTemplate = `{
         "dtos.TournamentResponse": {
             "type": "object",
             "properties": {
+                "betting_closes_at": {
+                    "description": "When betting closes",
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "betting_opens_at": {
+                    "description": "When betting opens",
+                    "type": "string",
+                    "format": "date-time"
+                },
                 "created_at": {
                     "description": "format: date-time\nexample: 2023-08-25T09:30:00Z",
                     "type": "string",
@@ -483,18 +2019,62 @@ const docTemplate = `{
                     "type": "string",
                     "format": "date-time"
                 },
+                "entry_fee": {
+                    "description": "Fixed entry fee",
+                    "type": "number"
+                },
+                "guaranteed_prize_pool": {
+                    "description": "Guaranteed minimum prize pool",
+                    "type": "number"
+                },
                 "id": {
                     "description": "Tournament ID",
                     "type": "integer"
                 },
+                "late_registration_minutes": {
+                    "description": "Late registration period in minutes after start_date",
+                    "type": "integer"
+                },
+                "max_bet": {
+                    "description": "Maximum amount of a single bet",
+                    "type": "number"
+                },
+                "max_participants": {
+                    "description": "Maximum number of distinct participants",
+                    "type": "integer"
+                },
+                "max_stake_per_player": {
+                    "description": "Maximum total stake per player",
+                    "type": "number"
+                },
+                "min_bet": {
+                    "description": "Minimum amount of a single bet",
+                    "type": "number"
+                },
                 "name": {
                     "description": "Tournament name",
                     "type": "string"
                 },
+                "pool_mode": {
+                    "description": "Prize pool mode",
+                    "type": "string"
+                },
                 "prize_pool": {
-                    "description": "Prize pool amount",
+                    "description": "Current prize pool amount",
+                    "type": "number"
+                },
+                "rake_collected": {
+                    "description": "Rake collected by the house so far",
+                    "type": "number"
+                },
+                "rake_percentage": {
+                    "description": "Percentage of every bet kept as house rake",
                     "type": "number"
                 },
+                "season_id": {
+                    "description": "Season the tournament counts towards",
+                    "type": "integer"
+                },
                 "start_date": {
                     "description": "format: date-time\nexample: 2023-09-01T15:00:00Z",
                     "type": "string",
@@ -502,6 +2082,35 @@ const docTemplate = `{
                 }
             }
         },
+        "events.Event": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "id": {
+                    "type": "integer"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.DetailedErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
         "handlers.ErrorResponse": {
             "type": "object",
             "properties": {
@@ -511,6 +2120,41 @@ const docTemplate = `{
                 }
             }
         },
+        "health.Check": {
+            "type": "object",
+            "properties": {
+                "details": {},
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "health.Report": {
+            "type": "object",
+            "properties": {
+                "checked_at": {
+                    "type": "string"
+                },
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/health.Check"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
         "models.PlayerRanking": {
             "type": "object",
             "properties": {
@@ -525,12 +2169,96 @@ const docTemplate = `{
                 },
                 "rank": {
                     "type": "integer"
+                },
+                "rank_change_day": {
+                    "description": "Places moved up since yesterday / last week (negative when dropped),\nnull when the player was not ranked then",
+                    "type": "integer"
+                },
+                "rank_change_week": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PlayerRating": {
+            "type": "object",
+            "properties": {
+                "player_id": {
+                    "type": "integer"
+                },
+                "player_name": {
+                    "type": "string"
+                },
+                "rank": {
+                    "description": "Dense rank among rated players, nil when not ranked (unrated,\nself-excluded or deleted)",
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "tournaments_played": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RankingSnapshot": {
+            "type": "object",
+            "properties": {
+                "account_balance": {
+                    "type": "number"
+                },
+                "player_id": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "taken_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RatingChange": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "field_size": {
+                    "type": "integer"
+                },
+                "placement": {
+                    "type": "integer"
+                },
+                "player_id": {
+                    "type": "integer"
+                },
+                "rating_after": {
+                    "type": "number"
+                },
+                "rating_before": {
+                    "type": "number"
+                },
+                "tournament_id": {
+                    "type": "integer"
                 }
             }
         },
         "models.Tournament": {
             "type": "object",
             "properties": {
+                "betting_closes_at": {
+                    "description": "Time after which bets are rejected (end_date when null)\nformat: date-time\nexample: 2023-09-05T18:00:00Z",
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "betting_opens_at": {
+                    "description": "Time from which bets are accepted (no lower bound when null)\nformat: date-time\nexample: 2023-08-31T15:00:00Z",
+                    "type": "string",
+                    "format": "date-time"
+                },
                 "created_at": {
                     "description": "Creation timestamp\nreadOnly: true\nformat: date-time\nexample: 2023-08-25T09:30:00Z",
                     "type": "string",
@@ -541,18 +2269,66 @@ const docTemplate = `{
                     "type": "string",
                     "format": "date-time"
                 },
+                "entry_fee": {
+                    "description": "Fixed entry fee; when set every player enters once by betting exactly this amount\nexample: 50.00",
+                    "type": "number"
+                },
+                "guaranteed_prize_pool": {
+                    "description": "Prize pool guaranteed by the house regardless of bets\nminimum: 0\nexample: 50000.00",
+                    "type": "number"
+                },
                 "id": {
                     "description": "The unique identifier for the tournament\nexample: 1",
                     "type": "integer"
                 },
+                "late_registration_minutes": {
+                    "description": "Minutes after start_date during which new players may still join\n(new players may join until betting closes when null)\nexample: 30",
+                    "type": "integer"
+                },
+                "max_bet": {
+                    "description": "Largest amount accepted for a single bet (no limit when null)\nexample: 500.00",
+                    "type": "number"
+                },
+                "max_participants": {
+                    "description": "Maximum number of distinct players that may place bets\nexample: 100",
+                    "type": "integer"
+                },
+                "max_stake_per_player": {
+                    "description": "Maximum total a single player may stake in the tournament\nexample: 2000.00",
+                    "type": "number"
+                },
+                "min_bet": {
+                    "description": "Smallest amount accepted for a single bet (no limit when null)\nexample: 10.00",
+                    "type": "number"
+                },
                 "name": {
                     "description": "Name of the tournament\nrequired: true\nexample: World Championship",
                     "type": "string"
                 },
+                "pool_mode": {
+                    "description": "How the prize pool is funded: \"fixed\" or \"accumulating\"\nexample: fixed",
+                    "type": "string"
+                },
                 "prize_pool": {
-                    "description": "Total prize pool in USD\nrequired: true\nminimum: 0\nexample: 100000.00",
+                    "description": "Current prize pool in USD (grows with every bet in accumulating mode)\nrequired: true\nminimum: 0\nexample: 100000.00",
+                    "type": "number"
+                },
+                "prizes_distributed": {
+                    "description": "Whether the prizes have been paid out\nreadOnly: true\nexample: false",
+                    "type": "boolean"
+                },
+                "rake_collected": {
+                    "description": "Total rake booked by the house so far\nexample: 1250.00",
                     "type": "number"
                 },
+                "rake_percentage": {
+                    "description": "Percentage of every bet kept by the house in accumulating mode\nminimum: 0\nmaximum: 100\nexample: 10",
+                    "type": "number"
+                },
+                "season_id": {
+                    "description": "Season the tournament counts towards (none when null)\nexample: 1",
+                    "type": "integer"
+                },
                 "start_date": {
                     "description": "Start date/time of the tournament\nrequired: true\nformat: date-time\nexample: 2023-09-01T15:00:00Z",
                     "type": "string",
@@ -563,6 +2339,63 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "ranking.Mismatch": {
+            "type": "object",
+            "properties": {
+                "actual_rank": {
+                    "type": "integer"
+                },
+                "actual_score": {
+                    "type": "number"
+                },
+                "board": {
+                    "description": "\"global\" or \"tournament:\u003cid\u003e\"",
+                    "type": "string"
+                },
+                "expected_rank": {
+                    "description": "Dense ranks, 0 when the player is missing on that side",
+                    "type": "integer"
+                },
+                "expected_score": {
+                    "type": "number"
+                },
+                "player_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "ranking.Report": {
+            "type": "object",
+            "properties": {
+                "boards_checked": {
+                    "type": "integer"
+                },
+                "checked_at": {
+                    "type": "string"
+                },
+                "consistent": {
+                    "type": "boolean"
+                },
+                "last_rebuild_at": {
+                    "type": "string"
+                },
+                "mismatch_count": {
+                    "type": "integer"
+                },
+                "mismatches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ranking.Mismatch"
+                    }
+                },
+                "players_checked": {
+                    "type": "integer"
+                }
+            }
         }
     }
 }`
@@ -1,30 +1,519 @@
+// Package config loads the service configuration in layers: built-in
+// defaults, then an optional YAML or JSON file, then environment variables.
+// Any environment variable can instead be read from a file by setting
+// <NAME>_FILE, which is how Docker and Kubernetes mount secrets.
 package config
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"igaming/internal/tracing"
+	"io"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
-	DBHost     string
-	DBPort     string
-	DBUser     string
-	DBPassword string
-	DBName     string
+	Storage    StorageConfig   `yaml:"storage"`
+	Database   DatabaseConfig  `yaml:"database"`
+	Server     ServerConfig    `yaml:"server"`
+	Migrations MigrationConfig `yaml:"migrations"`
+	Features   FeatureConfig   `yaml:"features"`
+	Snapshots  SnapshotConfig  `yaml:"snapshots"`
+	Tracing    TracingConfig   `yaml:"tracing"`
+}
+
+// Storage backends
+const (
+	StorageMySQL    = "mysql"
+	StoragePostgres = "postgres"
+	StorageSQLite   = "sqlite"
+	StorageMemory   = "memory"
+)
+
+// defaultPorts are the database ports used when none is configured.
+var defaultPorts = map[string]string{
+	StorageMySQL:    "3306",
+	StoragePostgres: "5432",
+}
+
+// StorageConfig selects where the data lives.
+type StorageConfig struct {
+	// "sqlite", "mysql", "postgres", or "memory" to run without a
+	// database; nothing is kept across restarts
+	Backend string `yaml:"backend"`
+	// Fixtures dataset to load into the memory backend at start
+	Dataset string `yaml:"dataset"`
+}
+
+// DatabaseConfig configures the database connection pool. SQLite only uses
+// Path and the pool settings.
+type DatabaseConfig struct {
+	// SQLite database file, created when missing
+	Path string `yaml:"path"`
+
+	Host string `yaml:"host"`
+	// Defaults to the backend's standard port
+	Port     string `yaml:"port"`
+	User     string `yaml:"user"`
+	Password Secret `yaml:"password"`
+	// Read the password from this file instead, e.g. a mounted secret
+	PasswordFile string `yaml:"password_file"`
+	Name         string `yaml:"name"`
+
+	// Zero means no limit
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time"`
+}
+
+// ServerConfig configures the HTTP server.
+type ServerConfig struct {
+	Port              string        `yaml:"port"`
+	ReadTimeout       time.Duration `yaml:"read_timeout"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+	// Streaming endpoints clear the write deadline for their connection.
+	WriteTimeout   time.Duration `yaml:"write_timeout"`
+	IdleTimeout    time.Duration `yaml:"idle_timeout"`
+	MaxHeaderBytes int           `yaml:"max_header_bytes"`
+	// TLS is served when both are set
+	TLSCertFile string `yaml:"tls_cert_file"`
+	TLSKeyFile  string `yaml:"tls_key_file"`
+	// How long shutdown waits for in-flight requests and background jobs
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+}
+
+// MigrationConfig configures the schema migrations.
+type MigrationConfig struct {
+	// Apply pending migrations before serving
+	AutoMigrate bool `yaml:"auto_migrate"`
+	// How long to wait for another instance that is migrating
+	LockTimeout time.Duration `yaml:"lock_timeout"`
+}
+
+// FeatureConfig switches optional parts of the service on or off.
+type FeatureConfig struct {
+	// Serve the Swagger UI under /swagger/
+	Swagger bool `yaml:"swagger"`
+	// Serve live tournament updates under /tournaments/{id}/stream
+	Streams bool `yaml:"streams"`
+	// Take hourly ranking snapshots for rank history and rank changes
+	RankHistory bool `yaml:"rank_history"`
+	// Periodically rate settled tournaments that were missed at settlement
+	RatingCatchUp bool `yaml:"rating_catch_up"`
+}
+
+// SnapshotConfig configures how long the ranking snapshots taken for rank
+// history are kept.
+type SnapshotConfig struct {
+	// Older hourly snapshots are thinned out to the first of each day. Rank
+	// changes look a week back, so it must cover a week.
+	HourlyRetention time.Duration `yaml:"hourly_retention"`
+	// Older snapshots are deleted; zero keeps the daily ones forever
+	Retention time.Duration `yaml:"retention"`
+}
+
+// TracingConfig configures OpenTelemetry tracing.
+type TracingConfig struct {
+	// "none", "stdout" to print spans as JSON lines, or "otlp" to send them
+	// to an OTLP/HTTP collector
+	Exporter string `yaml:"exporter"`
+	// Collector URL, e.g. http://localhost:4318; defaults to the
+	// OTEL_EXPORTER_OTLP_ENDPOINT variable
+	Endpoint string `yaml:"endpoint"`
+	// Share of new traces to record, from 0 to 1. Requests in a trace their
+	// caller records are always recorded.
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+// UsesDatabase reports whether the storage backend is a SQL database.
+func (c *Config) UsesDatabase() bool {
+	return c.usesServer() || c.Storage.Backend == StorageSQLite
+}
+
+// usesServer reports whether the storage backend is a database server to
+// connect to.
+func (c *Config) usesServer() bool {
+	return c.Storage.Backend == StorageMySQL || c.Storage.Backend == StoragePostgres
+}
+
+// TLS reports whether the server should serve HTTPS.
+func (c ServerConfig) TLS() bool {
+	return c.TLSCertFile != ""
+}
+
+// Secret is a string that is never printed: it is redacted when formatted or
+// marshalled.
+type Secret string
+
+const redacted = "[REDACTED]"
+
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return redacted
+}
+
+func (s Secret) MarshalYAML() (any, error) {
+	return s.String(), nil
+}
+
+// Default returns the configuration used when nothing overrides it. It leaves
+// the storage backend unset for Load to choose and has no database
+// credentials.
+func Default() Config {
+	return Config{
+		Database: DatabaseConfig{
+			Path:            "igaming.db",
+			MaxOpenConns:    25,
+			MaxIdleConns:    25,
+			ConnMaxLifetime: 5 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+		},
+		Server: ServerConfig{
+			Port:              "8080",
+			ReadTimeout:       15 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       60 * time.Second,
+			MaxHeaderBytes:    1 << 20,
+			ShutdownTimeout:   30 * time.Second,
+		},
+		Migrations: MigrationConfig{
+			LockTimeout: time.Minute,
+		},
+		Features: FeatureConfig{
+			Swagger:       true,
+			Streams:       true,
+			RankHistory:   true,
+			RatingCatchUp: true,
+		},
+		Snapshots: SnapshotConfig{
+			HourlyRetention: 8 * 24 * time.Hour,
+			Retention:       365 * 24 * time.Hour,
+		},
+		Tracing: TracingConfig{
+			Exporter:    tracing.ExporterNone,
+			SampleRatio: 1,
+		},
+	}
+}
+
+// Load layers the file at path (skipped when empty) and the environment over
+// the defaults. Without a configured storage backend it runs on MySQL when a
+// database host, user or name is set, so deployments configured only with
+// the DB_* variables keep their server, and on SQLite in Path otherwise. It
+// does not validate the result; call Validate for that.
+func Load(path string) (*Config, error) {
+	cfg := Default()
+
+	if path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	if err := cfg.loadEnv(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	// Left unset by loadEnv when DB_PASSWORD or DB_PASSWORD_FILE is given.
+	if cfg.Database.PasswordFile != "" {
+		password, err := readSecret(cfg.Database.PasswordFile)
+		if err != nil {
+			return nil, fmt.Errorf("database password: %w", err)
+		}
+		cfg.Database.Password = Secret(password)
+	}
+	if cfg.Storage.Backend == "" {
+		cfg.Storage.Backend = StorageSQLite
+		if cfg.Database.Host != "" || cfg.Database.User != "" || cfg.Database.Name != "" {
+			cfg.Storage.Backend = StorageMySQL
+		}
+	}
+	if cfg.Database.Port == "" {
+		cfg.Database.Port = defaultPorts[cfg.Storage.Backend]
+	}
+	return &cfg, nil
+}
+
+// LoadConfig loads the configuration like Load and validates it.
+func LoadConfig(path string) (*Config, error) {
+	cfg, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
 }
 
-func LoadConfig() *Config {
-	return &Config{
-		DBHost:     getEnv("DB_HOST", "localhost"),
-		DBPort:     getEnv("DB_PORT", "3306"),
-		DBUser:     getEnv("DB_USER", "root"),
-		DBPassword: getEnv("DB_PASSWORD", "password"),
-		DBName:     getEnv("DB_NAME", "igaming"),
+// loadFile reads a YAML file. JSON is valid YAML, so JSON files work too.
+// Unknown keys are rejected so a typo does not silently fall back to the
+// default.
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
 	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+	return nil
 }
 
-func getEnv(key, defaultValue string) string {
-	if value, exists := os.LookupEnv(key); exists {
-		return value
+func (c *Config) loadEnv() error {
+	var errs []error
+	str := func(dst *string, key string) {
+		value, ok, err := lookupEnv(key)
+		if err != nil {
+			errs = append(errs, err)
+		} else if ok {
+			*dst = value
+		}
+	}
+	duration := func(dst *time.Duration, key string) {
+		value, ok, err := lookupEnv(key)
+		if err != nil {
+			errs = append(errs, err)
+		} else if ok {
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %q is not a valid duration", key, value))
+				return
+			}
+			*dst = d
+		}
+	}
+	integer := func(dst *int, key string) {
+		value, ok, err := lookupEnv(key)
+		if err != nil {
+			errs = append(errs, err)
+		} else if ok {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %q is not an integer", key, value))
+				return
+			}
+			*dst = n
+		}
+	}
+	number := func(dst *float64, key string) {
+		value, ok, err := lookupEnv(key)
+		if err != nil {
+			errs = append(errs, err)
+		} else if ok {
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %q is not a number", key, value))
+				return
+			}
+			*dst = n
+		}
+	}
+	boolean := func(dst *bool, key string) {
+		value, ok, err := lookupEnv(key)
+		if err != nil {
+			errs = append(errs, err)
+		} else if ok {
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %q is not a boolean", key, value))
+				return
+			}
+			*dst = b
+		}
+	}
+
+	str(&c.Storage.Backend, "STORAGE_BACKEND")
+	str(&c.Storage.Dataset, "STORAGE_DATASET")
+
+	db := &c.Database
+	str(&db.Path, "DB_PATH")
+	str(&db.Host, "DB_HOST")
+	str(&db.Port, "DB_PORT")
+	str(&db.User, "DB_USER")
+	var password string
+	str(&password, "DB_PASSWORD")
+	if password != "" {
+		// Overrides the password file of the config file as well.
+		db.Password = Secret(password)
+		db.PasswordFile = ""
+	}
+	str(&db.Name, "DB_NAME")
+	integer(&db.MaxOpenConns, "DB_MAX_OPEN_CONNS")
+	integer(&db.MaxIdleConns, "DB_MAX_IDLE_CONNS")
+	duration(&db.ConnMaxLifetime, "DB_CONN_MAX_LIFETIME")
+	duration(&db.ConnMaxIdleTime, "DB_CONN_MAX_IDLE_TIME")
+
+	srv := &c.Server
+	str(&srv.Port, "PORT")
+	duration(&srv.ReadTimeout, "HTTP_READ_TIMEOUT")
+	duration(&srv.ReadHeaderTimeout, "HTTP_READ_HEADER_TIMEOUT")
+	duration(&srv.WriteTimeout, "HTTP_WRITE_TIMEOUT")
+	duration(&srv.IdleTimeout, "HTTP_IDLE_TIMEOUT")
+	integer(&srv.MaxHeaderBytes, "HTTP_MAX_HEADER_BYTES")
+	str(&srv.TLSCertFile, "TLS_CERT_FILE")
+	str(&srv.TLSKeyFile, "TLS_KEY_FILE")
+	duration(&srv.ShutdownTimeout, "SHUTDOWN_TIMEOUT")
+
+	boolean(&c.Migrations.AutoMigrate, "MIGRATE_ON_START")
+	duration(&c.Migrations.LockTimeout, "MIGRATION_LOCK_TIMEOUT")
+
+	f := &c.Features
+	boolean(&f.Swagger, "FEATURE_SWAGGER")
+	boolean(&f.Streams, "FEATURE_STREAMS")
+	boolean(&f.RankHistory, "FEATURE_RANK_HISTORY")
+	boolean(&f.RatingCatchUp, "FEATURE_RATING_CATCH_UP")
+
+	duration(&c.Snapshots.HourlyRetention, "SNAPSHOT_HOURLY_RETENTION")
+	duration(&c.Snapshots.Retention, "SNAPSHOT_RETENTION")
+
+	tr := &c.Tracing
+	str(&tr.Exporter, "TRACING_EXPORTER")
+	str(&tr.Endpoint, "TRACING_ENDPOINT")
+	number(&tr.SampleRatio, "TRACING_SAMPLE_RATIO")
+
+	return errors.Join(errs...)
+}
+
+// Validate reports every missing or invalid setting at once.
+func (c *Config) Validate() error {
+	var errs []error
+	required := func(value, name string) {
+		if value == "" {
+			errs = append(errs, fmt.Errorf("%s is required", name))
+		}
+	}
+	nonNegative := func(value time.Duration, name string) {
+		if value < 0 {
+			errs = append(errs, fmt.Errorf("%s must not be negative", name))
+		}
+	}
+
+	switch c.Storage.Backend {
+	case StorageSQLite, StorageMySQL, StoragePostgres:
+		if c.Storage.Dataset != "" {
+			errs = append(errs, errors.New("storage.dataset only applies to the memory backend; use the seed command for a database"))
+		}
+	case StorageMemory:
+	default:
+		errs = append(errs, fmt.Errorf("storage.backend: %q is not one of %s, %s, %s, %s", c.Storage.Backend, StorageSQLite, StorageMySQL, StoragePostgres, StorageMemory))
+	}
+
+	db := c.Database
+	if c.Storage.Backend == StorageSQLite {
+		required(db.Path, "database.path (DB_PATH)")
+		// Settings meant for a server would otherwise be ignored, and the
+		// service would start on an empty file instead of the real data.
+		if db.Host != "" || db.User != "" || db.Name != "" {
+			errs = append(errs, errors.New("database.host, database.user and database.name (DB_HOST, DB_USER, DB_NAME) do not apply to sqlite; set storage.backend (STORAGE_BACKEND) to mysql or postgres"))
+		}
+	}
+	if c.usesServer() {
+		required(db.Host, "database.host (DB_HOST)")
+		required(db.User, "database.user (DB_USER)")
+		required(string(db.Password), "database.password (DB_PASSWORD, DB_PASSWORD_FILE or database.password_file)")
+		required(db.Name, "database.name (DB_NAME)")
+		if _, err := strconv.ParseUint(db.Port, 10, 16); err != nil {
+			errs = append(errs, fmt.Errorf("database.port: %q is not a valid port", db.Port))
+		}
+	}
+	if db.MaxOpenConns < 0 || db.MaxIdleConns < 0 {
+		errs = append(errs, errors.New("database connection limits must not be negative"))
 	}
-	return defaultValue
-}
\ No newline at end of file
+	if db.MaxOpenConns > 0 && db.MaxIdleConns > db.MaxOpenConns {
+		errs = append(errs, fmt.Errorf("database.max_idle_conns (%d) exceeds database.max_open_conns (%d)", db.MaxIdleConns, db.MaxOpenConns))
+	}
+	nonNegative(db.ConnMaxLifetime, "database.conn_max_lifetime")
+	nonNegative(db.ConnMaxIdleTime, "database.conn_max_idle_time")
+
+	srv := c.Server
+	if _, err := strconv.ParseUint(srv.Port, 10, 16); err != nil {
+		errs = append(errs, fmt.Errorf("server.port: %q is not a valid port", srv.Port))
+	}
+	nonNegative(srv.ReadTimeout, "server.read_timeout")
+	nonNegative(srv.ReadHeaderTimeout, "server.read_header_timeout")
+	nonNegative(srv.WriteTimeout, "server.write_timeout")
+	nonNegative(srv.IdleTimeout, "server.idle_timeout")
+	if srv.ShutdownTimeout <= 0 {
+		errs = append(errs, errors.New("server.shutdown_timeout must be positive"))
+	}
+	if srv.MaxHeaderBytes <= 0 {
+		errs = append(errs, errors.New("server.max_header_bytes must be positive"))
+	}
+	if (srv.TLSCertFile == "") != (srv.TLSKeyFile == "") {
+		errs = append(errs, errors.New("server.tls_cert_file and server.tls_key_file must be set together"))
+	}
+	nonNegative(c.Migrations.LockTimeout, "migrations.lock_timeout")
+
+	snap := c.Snapshots
+	if snap.HourlyRetention < 7*24*time.Hour {
+		errs = append(errs, fmt.Errorf("snapshots.hourly_retention: %s is less than the week rank changes look back", snap.HourlyRetention))
+	}
+	if snap.Retention != 0 && snap.Retention < snap.HourlyRetention {
+		errs = append(errs, fmt.Errorf("snapshots.retention (%s) is less than snapshots.hourly_retention (%s)", snap.Retention, snap.HourlyRetention))
+	}
+
+	tr := c.Tracing
+	switch tr.Exporter {
+	case tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP:
+	default:
+		errs = append(errs, fmt.Errorf("tracing.exporter: %q is not one of %s, %s, %s", tr.Exporter, tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP))
+	}
+	if tr.SampleRatio < 0 || tr.SampleRatio > 1 {
+		errs = append(errs, fmt.Errorf("tracing.sample_ratio: %v is not between 0 and 1", tr.SampleRatio))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	return nil
+}
+
+// Print writes the configuration as YAML with the secrets redacted.
+func (c *Config) Print(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(c); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// lookupEnv returns the variable key, or the contents of the file named by
+// key_FILE. Setting both is an error.
+func lookupEnv(key string) (string, bool, error) {
+	value, ok := os.LookupEnv(key)
+	path, fromFile := os.LookupEnv(key + "_FILE")
+	switch {
+	case ok && fromFile:
+		return "", false, fmt.Errorf("%s and %s_FILE are both set", key, key)
+	case fromFile:
+		value, err := readSecret(path)
+		if err != nil {
+			return "", false, fmt.Errorf("%s_FILE: %w", key, err)
+		}
+		return value, true, nil
+	}
+	return value, ok, nil
+}
+
+// readSecret reads a secret file, dropping the trailing newline most editors
+// and `echo` add.
+func readSecret(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
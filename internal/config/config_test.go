@@ -0,0 +1,394 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// envPrefixes are the prefixes of the variables the configuration reads.
+var envPrefixes = []string{"STORAGE_", "DB_", "PORT", "HTTP_", "TLS_", "SHUTDOWN_", "MIGRATE_", "MIGRATION_", "FEATURE_", "SNAPSHOT_", "TRACING_"}
+
+// clearEnv unsets the configuration variables of the environment the tests
+// run in for the duration of the test.
+func clearEnv(t *testing.T) {
+	t.Helper()
+
+	for _, kv := range os.Environ() {
+		key, _, _ := strings.Cut(kv, "=")
+		for _, prefix := range envPrefixes {
+			if strings.HasPrefix(key, prefix) {
+				t.Setenv(key, "")
+				os.Unsetenv(key)
+				break
+			}
+		}
+	}
+}
+
+// writeFile writes content to a new file named name and returns its path.
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	tests := []struct {
+		name  string
+		file  string
+		env   map[string]string
+		check func(t *testing.T, c *Config)
+	}{
+		{
+			name: "defaults",
+			check: func(t *testing.T, c *Config) {
+				if c.Storage.Backend != StorageSQLite || c.Server.Port != "8080" || c.Database.Port != "" || c.Database.Path != "igaming.db" {
+					t.Errorf("backend %q, server port %q, database port %q, path %q; want sqlite, 8080, none, igaming.db", c.Storage.Backend, c.Server.Port, c.Database.Port, c.Database.Path)
+				}
+			},
+		},
+		{
+			name: "server settings default to mysql",
+			env:  map[string]string{"DB_HOST": "db", "DB_USER": "igaming", "DB_NAME": "igaming"},
+			check: func(t *testing.T, c *Config) {
+				if c.Storage.Backend != StorageMySQL || c.Database.Port != "3306" {
+					t.Errorf("backend %q, database port %q; want mysql, 3306", c.Storage.Backend, c.Database.Port)
+				}
+			},
+		},
+		{
+			name: "configured backend kept with server settings",
+			env:  map[string]string{"STORAGE_BACKEND": "memory", "DB_NAME": "igaming"},
+			check: func(t *testing.T, c *Config) {
+				if c.Storage.Backend != StorageMemory {
+					t.Errorf("backend %q, want memory", c.Storage.Backend)
+				}
+			},
+		},
+		{
+			name: "file over defaults",
+			file: "storage:\n  backend: postgres\nserver:\n  port: \"9090\"\n  read_timeout: 3s\n",
+			check: func(t *testing.T, c *Config) {
+				if c.Storage.Backend != StoragePostgres || c.Server.Port != "9090" || c.Server.ReadTimeout != 3*time.Second {
+					t.Errorf("backend %q, port %q, read timeout %v; want postgres, 9090, 3s", c.Storage.Backend, c.Server.Port, c.Server.ReadTimeout)
+				}
+				if c.Database.Port != "5432" {
+					t.Errorf("database port %q, want the postgres default 5432", c.Database.Port)
+				}
+				if c.Server.WriteTimeout != 30*time.Second {
+					t.Errorf("write timeout %v, want the default 30s", c.Server.WriteTimeout)
+				}
+			},
+		},
+		{
+			name: "JSON file",
+			file: `{"server": {"port": "9090"}}`,
+			check: func(t *testing.T, c *Config) {
+				if c.Server.Port != "9090" {
+					t.Errorf("port %q, want 9090", c.Server.Port)
+				}
+			},
+		},
+		{
+			name: "env over file",
+			file: "server:\n  port: \"9090\"\nfeatures:\n  swagger: true\n",
+			env:  map[string]string{"PORT": "7070", "FEATURE_SWAGGER": "false", "DB_CONN_MAX_LIFETIME": "1m"},
+			check: func(t *testing.T, c *Config) {
+				if c.Server.Port != "7070" || c.Features.Swagger || c.Database.ConnMaxLifetime != time.Minute {
+					t.Errorf("port %q, swagger %t, conn max lifetime %v; want 7070, false, 1m", c.Server.Port, c.Features.Swagger, c.Database.ConnMaxLifetime)
+				}
+			},
+		},
+		{
+			name: "configured port kept",
+			file: "storage:\n  backend: postgres\ndatabase:\n  port: \"6432\"\n",
+			check: func(t *testing.T, c *Config) {
+				if c.Database.Port != "6432" {
+					t.Errorf("database port %q, want 6432", c.Database.Port)
+				}
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			clearEnv(t)
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			path := ""
+			if tc.file != "" {
+				path = writeFile(t, "config.yaml", tc.file)
+			}
+
+			c, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			tc.check(t, c)
+		})
+	}
+}
+
+func TestLoadSecretFiles(t *testing.T) {
+	secret := func(t *testing.T) string { return writeFile(t, "secret", "from-file\n") }
+
+	tests := []struct {
+		name     string
+		file     func(t *testing.T) string
+		env      func(t *testing.T) map[string]string
+		want     Secret
+		wantUser string
+		wantErr  string
+	}{
+		{
+			name: "variable from file",
+			env:  func(t *testing.T) map[string]string { return map[string]string{"DB_PASSWORD_FILE": secret(t)} },
+			want: "from-file",
+		},
+		{
+			name: "any variable from file",
+			env: func(t *testing.T) map[string]string {
+				return map[string]string{"DB_USER_FILE": writeFile(t, "user", "igaming\n"), "DB_PASSWORD": "plain"}
+			},
+			want:     "plain",
+			wantUser: "igaming",
+		},
+		{
+			name: "both set",
+			env: func(t *testing.T) map[string]string {
+				return map[string]string{"DB_PASSWORD": "plain", "DB_PASSWORD_FILE": secret(t)}
+			},
+			wantErr: "DB_PASSWORD and DB_PASSWORD_FILE are both set",
+		},
+		{
+			name: "missing file",
+			env: func(t *testing.T) map[string]string {
+				return map[string]string{"DB_PASSWORD_FILE": "/nonexistent/secret"}
+			},
+			wantErr: "DB_PASSWORD_FILE",
+		},
+		{
+			name: "password file of the config file",
+			file: func(t *testing.T) string { return "database:\n  password_file: " + secret(t) + "\n" },
+			want: "from-file",
+		},
+		{
+			name: "env over the password file of the config file",
+			file: func(t *testing.T) string { return "database:\n  password_file: /nonexistent/secret\n" },
+			env:  func(t *testing.T) map[string]string { return map[string]string{"DB_PASSWORD": "plain"} },
+			want: "plain",
+		},
+		{
+			name: "env file over the password file of the config file",
+			file: func(t *testing.T) string { return "database:\n  password_file: /nonexistent/secret\n" },
+			env:  func(t *testing.T) map[string]string { return map[string]string{"DB_PASSWORD_FILE": secret(t)} },
+			want: "from-file",
+		},
+		{
+			name:    "missing password file of the config file",
+			file:    func(t *testing.T) string { return "database:\n  password_file: /nonexistent/secret\n" },
+			wantErr: "database password",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			clearEnv(t)
+			if tc.env != nil {
+				for k, v := range tc.env(t) {
+					t.Setenv(k, v)
+				}
+			}
+			path := ""
+			if tc.file != nil {
+				path = writeFile(t, "config.yaml", tc.file(t))
+			}
+
+			c, err := Load(path)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("Load error %v, want one mentioning %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if c.Database.Password != tc.want {
+				t.Errorf("password %q, want %q", string(c.Database.Password), string(tc.want))
+			}
+			if c.Database.User != tc.wantUser {
+				t.Errorf("user %q, want %q", c.Database.User, tc.wantUser)
+			}
+		})
+	}
+}
+
+func TestLoadRejectsUnknownKeys(t *testing.T) {
+	clearEnv(t)
+
+	tests := map[string]string{
+		"top level": "storage:\n  backend: mysql\nstorgae:\n  backend: sqlite\n",
+		"nested":    "server:\n  prot: \"9090\"\n",
+	}
+	for name, file := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Load(writeFile(t, "config.yaml", file))
+			if err == nil || !strings.Contains(err.Error(), "not found") {
+				t.Fatalf("Load error %v, want an unknown field error", err)
+			}
+		})
+	}
+}
+
+func TestLoadReportsEveryInvalidVariable(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("HTTP_READ_TIMEOUT", "soon")
+	t.Setenv("DB_MAX_OPEN_CONNS", "many")
+	t.Setenv("FEATURE_STREAMS", "maybe")
+
+	_, err := Load("")
+	if err == nil {
+		t.Fatal("Load succeeded, want an error")
+	}
+	for _, key := range []string{"HTTP_READ_TIMEOUT", "DB_MAX_OPEN_CONNS", "FEATURE_STREAMS"} {
+		if !strings.Contains(err.Error(), key) {
+			t.Errorf("error %q does not mention %s", err, key)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() Config {
+		c := Default()
+		c.Storage.Backend = StorageMySQL
+		c.Database.Host = "localhost"
+		c.Database.Port = "3306"
+		c.Database.User = "igaming"
+		c.Database.Password = "secret"
+		c.Database.Name = "igaming"
+		return c
+	}
+
+	tests := []struct {
+		name   string
+		modify func(c *Config)
+		want   []string
+	}{
+		{
+			name:   "valid",
+			modify: func(c *Config) {},
+		},
+		{
+			name: "every error at once",
+			modify: func(c *Config) {
+				c.Database.Host = ""
+				c.Database.Password = ""
+				c.Database.MaxIdleConns = 50
+				c.Server.Port = "http"
+				c.Server.ShutdownTimeout = 0
+				c.Server.TLSCertFile = "cert.pem"
+				c.Tracing.SampleRatio = 2
+				c.Snapshots.HourlyRetention = 24 * time.Hour
+				c.Snapshots.Retention = time.Hour
+			},
+			want: []string{
+				"database.host (DB_HOST) is required",
+				"database.password",
+				"database.max_idle_conns (50) exceeds database.max_open_conns (25)",
+				`server.port: "http" is not a valid port`,
+				"server.shutdown_timeout must be positive",
+				"server.tls_cert_file and server.tls_key_file must be set together",
+				"tracing.sample_ratio: 2 is not between 0 and 1",
+				"snapshots.hourly_retention: 24h0m0s is less than the week",
+				"snapshots.retention (1h0m0s) is less than snapshots.hourly_retention (24h0m0s)",
+			},
+		},
+		{
+			name:   "unknown backend",
+			modify: func(c *Config) { c.Storage.Backend = "oracle" },
+			want:   []string{`storage.backend: "oracle"`},
+		},
+		{
+			name: "sqlite with server settings",
+			modify: func(c *Config) {
+				c.Storage.Backend = StorageSQLite
+			},
+			want: []string{"do not apply to sqlite"},
+		},
+		{
+			name: "sqlite",
+			modify: func(c *Config) {
+				*c = Default()
+				c.Storage.Backend = StorageSQLite
+			},
+		},
+		{
+			name: "memory needs no database",
+			modify: func(c *Config) {
+				*c = Default()
+				c.Storage.Backend = StorageMemory
+				c.Storage.Dataset = "demo"
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := valid()
+			tc.modify(&c)
+
+			err := c.Validate()
+			if len(tc.want) == 0 {
+				if err != nil {
+					t.Fatalf("Validate: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Validate succeeded, want an error")
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not contain %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPrintRedactsSecrets(t *testing.T) {
+	c := Default()
+	c.Database.User = "igaming"
+	c.Database.Password = "hunter2"
+
+	var b strings.Builder
+	if err := c.Print(&b); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	if strings.Contains(out, "hunter2") {
+		t.Errorf("printed configuration contains the password:\n%s", out)
+	}
+	if !strings.Contains(out, "password: '"+redacted+"'") {
+		t.Errorf("printed configuration does not redact the password:\n%s", out)
+	}
+	if !strings.Contains(out, "user: igaming") {
+		t.Errorf("printed configuration lacks the user:\n%s", out)
+	}
+
+	if s := Secret("hunter2").String(); s != redacted {
+		t.Errorf("Secret.String() = %q, want %q", s, redacted)
+	}
+	if s := Secret("").String(); s != "" {
+		t.Errorf("empty Secret.String() = %q, want empty", s)
+	}
+}
@@ -1,18 +1,26 @@
 package config
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"igaming/internal/dialect"
+	"igaming/internal/tracing"
 	"log"
+	"net"
+	"net/url"
+	"time"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/stdlib"
+	_ "modernc.org/sqlite"
 )
 
+// InitDB opens the configured database and exits when it cannot be reached.
 func InitDB(cfg *Config) *sql.DB {
-    dataSource := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true",
-        cfg.DBUser, cfg.DBPassword, cfg.DBHost, cfg.DBPort, cfg.DBName)
-
-    db, err := sql.Open("mysql", dataSource)
+    db, err := OpenDB(cfg)
     if err != nil {
         log.Fatalf("Failed to connect to database: %v", err)
     }
@@ -23,4 +31,74 @@ func InitDB(cfg *Config) *sql.DB {
     }
 
     return db
-}
\ No newline at end of file
+}
+
+// OpenDB returns the connection pool of the configured database backend
+// without connecting to it yet. Statements run within a trace are traced.
+func OpenDB(cfg *Config) (*sql.DB, error) {
+    var db *sql.DB
+    switch cfg.Storage.Backend {
+    case StorageMySQL:
+        dsn := mysql.NewConfig()
+        dsn.User = cfg.Database.User
+        dsn.Passwd = string(cfg.Database.Password)
+        dsn.Net = "tcp"
+        dsn.Addr = net.JoinHostPort(cfg.Database.Host, cfg.Database.Port)
+        dsn.DBName = cfg.Database.Name
+        dsn.ParseTime = true
+
+        var err error
+        if db, err = tracing.OpenDB("mysql", dsn.FormatDSN(), dialect.MySQL); err != nil {
+            return nil, err
+        }
+    case StoragePostgres:
+        dsn := url.URL{
+            Scheme: "postgres",
+            User:   url.UserPassword(cfg.Database.User, string(cfg.Database.Password)),
+            Host:   net.JoinHostPort(cfg.Database.Host, cfg.Database.Port),
+            Path:   cfg.Database.Name,
+        }
+        connConfig, err := pgx.ParseConfig(dsn.String())
+        if err != nil {
+            return nil, fmt.Errorf("invalid database settings: %w", err)
+        }
+        connector := stdlib.GetConnector(*connConfig, stdlib.OptionAfterConnect(scanTimestampsInUTC))
+        db = tracing.OpenConnector(connector, dialect.Postgres)
+    case StorageSQLite:
+        // Transactions take the write lock when they begin, and writers wait
+        // for each other instead of failing at once. Timestamps are stored
+        // as Unix microseconds and read back in UTC.
+        params := url.Values{}
+        params.Add("_pragma", "foreign_keys(1)")
+        params.Add("_pragma", "journal_mode(WAL)")
+        params.Add("_pragma", "busy_timeout(5000)")
+        params.Set("_txlock", "immediate")
+        params.Set("_time_integer_format", "unix_micro")
+        params.Set("_inttotime", "1")
+
+        var err error
+        if db, err = tracing.OpenDB("sqlite", cfg.Database.Path+"?"+params.Encode(), dialect.SQLite); err != nil {
+            return nil, err
+        }
+    default:
+        return nil, fmt.Errorf("storage backend %q has no database", cfg.Storage.Backend)
+    }
+
+    db.SetMaxOpenConns(cfg.Database.MaxOpenConns)
+    db.SetMaxIdleConns(cfg.Database.MaxIdleConns)
+    db.SetConnMaxLifetime(cfg.Database.ConnMaxLifetime)
+    db.SetConnMaxIdleTime(cfg.Database.ConnMaxIdleTime)
+
+    return db, nil
+}
+
+// scanTimestampsInUTC makes the connection return TIMESTAMPTZ values in UTC,
+// like the MySQL driver does, instead of the server's time zone.
+func scanTimestampsInUTC(ctx context.Context, conn *pgx.Conn) error {
+    conn.TypeMap().RegisterType(&pgtype.Type{
+        Name:  "timestamptz",
+        OID:   pgtype.TimestamptzOID,
+        Codec: &pgtype.TimestamptzCodec{ScanLocation: time.UTC},
+    })
+    return nil
+}
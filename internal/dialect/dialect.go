@@ -0,0 +1,40 @@
+// Package dialect names the SQL databases the service runs on and papers
+// over the differences that code shared between them has to care about.
+package dialect
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Dialect is a SQL database flavour. Its value is the storage backend name
+// in the configuration.
+type Dialect string
+
+const (
+	MySQL    Dialect = "mysql"
+	Postgres Dialect = "postgres"
+	SQLite   Dialect = "sqlite"
+)
+
+// Rebind rewrites the ? placeholders of query to the dialect's own: $1, $2,
+// ... for Postgres and SQLite. Queries must not contain a literal question
+// mark.
+func (d Dialect) Rebind(query string) string {
+	if d == MySQL {
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r != '?' {
+			b.WriteRune(r)
+			continue
+		}
+		n++
+		b.WriteByte('$')
+		b.WriteString(strconv.Itoa(n))
+	}
+	return b.String()
+}
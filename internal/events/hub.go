@@ -0,0 +1,287 @@
+// Package events is an in-process publish/subscribe hub used to push
+// tournament updates (bets, leaderboard changes, settlements) to streaming
+// clients.
+package events
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultHistorySize is how many recent events each topic keeps so
+	// reconnecting clients can resume from their last event ID.
+	DefaultHistorySize = 256
+	// DefaultBufferSize is how many undelivered events a subscriber may
+	// have queued before it is dropped as a slow consumer.
+	DefaultBufferSize = 64
+	// DefaultIdleTTL is how long a topic keeps its history, and keeps
+	// recording events, after its last subscriber left, so a dropped or
+	// disconnected client can still resume it.
+	DefaultIdleTTL = 5 * time.Minute
+)
+
+var (
+	// ErrSlowConsumer closes a subscription whose buffer filled up.
+	ErrSlowConsumer = errors.New("subscriber is too slow")
+	// ErrHubClosed closes every subscription when the hub shuts down.
+	ErrHubClosed = errors.New("event hub closed")
+)
+
+// Event is a single message published on a topic. IDs increase across all
+// topics, so a client can resume a topic from the last ID it has seen.
+type Event struct {
+	ID   uint64    `json:"id"`
+	Type string    `json:"type"`
+	Time time.Time `json:"time"`
+	Data any       `json:"data"`
+}
+
+type topic struct {
+	history []Event
+	subs    map[*Subscription]struct{}
+	// expiry removes the topic once it has been idle for the idle TTL.
+	// idlePeriods counts the times the topic went idle, so a timer that
+	// fires after the topic was subscribed to again does nothing.
+	expiry      *time.Timer
+	idlePeriods uint64
+}
+
+// Hub fans published events out to the subscribers of each topic. Publishing
+// never blocks: a subscriber that cannot keep up is dropped and has to
+// reconnect, resuming from the topic history. A topic exists from its first
+// subscriber until it has had none for the idle TTL, and records events in
+// between; events published on any other topic are discarded.
+type Hub struct {
+	mu          sync.RWMutex
+	lastID      uint64
+	topics      map[string]*topic
+	historySize int
+	bufferSize  int
+	idleTTL     time.Duration
+	closed      bool
+	onIdle      []func(name string)
+}
+
+// NewHub returns a hub keeping historySize events per topic and idle topics
+// for idleTTL. A zero idleTTL removes a topic as soon as its last subscriber
+// leaves.
+func NewHub(historySize, bufferSize int, idleTTL time.Duration) *Hub {
+	return &Hub{
+		topics:      make(map[string]*topic),
+		historySize: historySize,
+		bufferSize:  bufferSize,
+		idleTTL:     idleTTL,
+	}
+}
+
+// TournamentTopic is the topic carrying the updates of one tournament.
+func TournamentTopic(tournamentID uint) string {
+	return fmt.Sprintf("tournament:%d", tournamentID)
+}
+
+// OnIdle registers fn to be called with the topic name when a topic has had
+// no subscribers for the idle TTL and its history is discarded. fn runs with
+// the hub locked, so it must not call the hub.
+func (h *Hub) OnIdle(fn func(name string)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.onIdle = append(h.onIdle, fn)
+}
+
+// Publish sends an event to every subscriber of the topic and records it in
+// the topic history. ok is false when the topic does not exist, as it has
+// had no subscribers for the idle TTL, and the event was discarded.
+func (h *Hub) Publish(name, eventType string, data any) (event Event, ok bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.lastID++
+	event = Event{ID: h.lastID, Type: eventType, Time: time.Now().UTC(), Data: data}
+	t, ok := h.topics[name]
+	if h.closed || !ok {
+		return event, false
+	}
+
+	t.history = append(t.history, event)
+	if len(t.history) > h.historySize {
+		t.history = t.history[len(t.history)-h.historySize:]
+	}
+
+	for sub := range t.subs {
+		select {
+		case sub.ch <- event:
+		default:
+			h.drop(name, sub, ErrSlowConsumer)
+		}
+	}
+
+	return event, true
+}
+
+// HasSubscribers reports whether anyone is listening on the topic.
+func (h *Hub) HasSubscribers(name string) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	t, ok := h.topics[name]
+	return ok && len(t.subs) > 0
+}
+
+// Active reports whether events published on the topic are recorded: it has
+// subscribers, or had some less than the idle TTL ago.
+func (h *Hub) Active(name string) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	_, ok := h.topics[name]
+	return ok
+}
+
+// Subscribe starts listening on the topic. With a non-zero lastEventID the
+// events published after it that are still in the history are returned as
+// replay; complete is false when some of them have already been discarded
+// and the client should reload its state. That includes every resume of a
+// topic nobody listened to for the idle TTL.
+func (h *Hub) Subscribe(name string, lastEventID uint64) (sub *Subscription, replay []Event, complete bool, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, nil, false, ErrHubClosed
+	}
+
+	t, ok := h.topics[name]
+	if !ok {
+		t = &topic{subs: make(map[*Subscription]struct{})}
+		h.topics[name] = t
+	}
+	if t.expiry != nil {
+		t.expiry.Stop()
+		t.expiry = nil
+	}
+	complete = ok || lastEventID == 0
+	if ok && lastEventID > 0 {
+		for _, e := range t.history {
+			if e.ID > lastEventID {
+				replay = append(replay, e)
+			}
+		}
+		// The event right after lastEventID may have been on another topic,
+		// so only a full history can hide a gap.
+		if len(t.history) == h.historySize && len(replay) == len(t.history) {
+			complete = false
+		}
+	}
+
+	sub = &Subscription{
+		hub:   h,
+		topic: name,
+		ch:    make(chan Event, h.bufferSize),
+	}
+	t.subs[sub] = struct{}{}
+
+	return sub, replay, complete, nil
+}
+
+// Close ends every subscription. Events published afterwards are discarded.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+	h.closed = true
+
+	for name, t := range h.topics {
+		for sub := range t.subs {
+			h.drop(name, sub, ErrHubClosed)
+		}
+		if t.expiry != nil {
+			t.expiry.Stop()
+		}
+	}
+}
+
+// drop removes the subscriber and closes its channel. Callers hold h.mu.
+func (h *Hub) drop(name string, sub *Subscription, reason error) {
+	if h.leave(name, sub) {
+		sub.err = reason
+	}
+}
+
+// leave removes the subscriber and closes its channel. When it was the last
+// one, the topic is discarded after the idle TTL. It reports whether the
+// subscriber was still there. Callers hold h.mu.
+func (h *Hub) leave(name string, sub *Subscription) bool {
+	t, ok := h.topics[name]
+	if !ok {
+		return false
+	}
+	if _, ok := t.subs[sub]; !ok {
+		return false
+	}
+	delete(t.subs, sub)
+	close(sub.ch)
+
+	if len(t.subs) > 0 {
+		return true
+	}
+	t.idlePeriods++
+	if h.idleTTL <= 0 || h.closed {
+		h.expire(name)
+		return true
+	}
+	period := t.idlePeriods
+	t.expiry = time.AfterFunc(h.idleTTL, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		if h.topics[name] == t && len(t.subs) == 0 && t.idlePeriods == period {
+			h.expire(name)
+		}
+	})
+	return true
+}
+
+// expire discards the idle topic and its history. Callers hold h.mu.
+func (h *Hub) expire(name string) {
+	delete(h.topics, name)
+	for _, fn := range h.onIdle {
+		fn(name)
+	}
+}
+
+// Subscription receives the events of one topic.
+type Subscription struct {
+	hub   *Hub
+	topic string
+	ch    chan Event
+	err   error
+}
+
+// Events returns the channel events are delivered on. It is closed when the
+// subscription ends; Err then tells why.
+func (s *Subscription) Events() <-chan Event {
+	return s.ch
+}
+
+// Err returns why the subscription was closed by the hub, nil while it is
+// open or after Unsubscribe.
+func (s *Subscription) Err() error {
+	s.hub.mu.RLock()
+	defer s.hub.mu.RUnlock()
+	return s.err
+}
+
+// Unsubscribe stops delivery. It is safe to call more than once.
+func (s *Subscription) Unsubscribe() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	s.hub.leave(s.topic, s)
+}
@@ -0,0 +1,231 @@
+package events
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// idleTTL is short enough for the tests to wait out.
+const idleTTL = 20 * time.Millisecond
+
+// idleTopics records the topics the hub discards.
+type idleTopics struct {
+	mu    sync.Mutex
+	names []string
+}
+
+func (i *idleTopics) add(name string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.names = append(i.names, name)
+}
+
+func (i *idleTopics) get() []string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return slices.Clone(i.names)
+}
+
+// wait waits until the hub has discarded exactly the topics in want.
+func (i *idleTopics) wait(t *testing.T, want ...string) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for !slices.Equal(i.get(), want) {
+		if time.Now().After(deadline) {
+			t.Fatalf("idle topics %v, want %v", i.get(), want)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestHubPrunesIdleTopics(t *testing.T) {
+	h := NewHub(DefaultHistorySize, DefaultBufferSize, idleTTL)
+	defer h.Close()
+	idle := &idleTopics{}
+	h.OnIdle(idle.add)
+
+	if _, ok := h.Publish("a", "test", 1); ok {
+		t.Fatal("event published to a topic without subscribers")
+	}
+	if len(h.topics) != 0 {
+		t.Fatalf("publishing without subscribers created %d topics", len(h.topics))
+	}
+
+	first, _, _, err := h.Subscribe("a", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _, _, err := h.Subscribe("a", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !h.HasSubscribers("a") || h.HasSubscribers("b") {
+		t.Fatal("HasSubscribers does not match the subscriptions")
+	}
+	if _, ok := h.Publish("a", "test", 2); !ok {
+		t.Fatal("event not published to a topic with subscribers")
+	}
+
+	first.Unsubscribe()
+	if !h.HasSubscribers("a") {
+		t.Fatal("no subscribers with one left")
+	}
+	second.Unsubscribe()
+	second.Unsubscribe()
+	if _, ok := <-first.Events(); !ok {
+		t.Fatal("event published before unsubscribing was not delivered")
+	}
+
+	// The topic keeps recording for the idle TTL after the last subscriber
+	// left, then it is discarded.
+	if h.HasSubscribers("a") || !h.Active("a") {
+		t.Fatal("topic not kept idle after the last subscriber left")
+	}
+	if _, ok := h.Publish("a", "test", 3); !ok {
+		t.Fatal("event not recorded on an idle topic")
+	}
+	idle.wait(t, "a")
+
+	h.mu.RLock()
+	topics := len(h.topics)
+	h.mu.RUnlock()
+	if h.Active("a") || topics != 0 {
+		t.Fatalf("%d topics after the idle TTL", topics)
+	}
+	if _, ok := h.Publish("a", "test", 4); ok {
+		t.Fatal("event published to a discarded topic")
+	}
+}
+
+func TestHubKeepsTopicsSubscribedAgain(t *testing.T) {
+	h := NewHub(DefaultHistorySize, DefaultBufferSize, idleTTL)
+	defer h.Close()
+	idle := &idleTopics{}
+	h.OnIdle(idle.add)
+
+	sub, _, _, err := h.Subscribe("a", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub.Unsubscribe()
+	sub, _, _, err = h.Subscribe("a", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(3 * idleTTL)
+	if got := idle.get(); len(got) != 0 || !h.HasSubscribers("a") {
+		t.Fatalf("topic with a subscriber discarded: idle %v", got)
+	}
+	sub.Unsubscribe()
+	idle.wait(t, "a")
+}
+
+// TestHubResumeAfterDrop drops the only subscriber of a topic as a slow
+// consumer and has it resume from the last event it received, replaying
+// everything published in between.
+func TestHubResumeAfterDrop(t *testing.T) {
+	h := NewHub(DefaultHistorySize, 1, DefaultIdleTTL)
+	defer h.Close()
+
+	sub, _, _, err := h.Subscribe("a", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []uint64
+	for i := range 4 {
+		e, _ := h.Publish("a", "test", i)
+		ids = append(ids, e.ID)
+	}
+
+	if !errors.Is(sub.Err(), ErrSlowConsumer) {
+		t.Fatalf("Err = %v, want ErrSlowConsumer", sub.Err())
+	}
+	if h.HasSubscribers("a") {
+		t.Fatal("slow consumer still subscribed")
+	}
+	var received []uint64
+	for e := range sub.Events() {
+		received = append(received, e.ID)
+	}
+	if !slices.Equal(received, ids[:1]) {
+		t.Fatalf("slow consumer received %v, want %v", received, ids[:1])
+	}
+
+	resumed, replay, complete, err := h.Subscribe("a", received[len(received)-1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resumed.Unsubscribe()
+	var replayed []uint64
+	for _, e := range replay {
+		replayed = append(replayed, e.ID)
+	}
+	if !complete || !slices.Equal(replayed, ids[1:]) {
+		t.Fatalf("resume replayed %v, complete %t; want %v complete", replayed, complete, ids[1:])
+	}
+}
+
+func TestHubResume(t *testing.T) {
+	h := NewHub(3, DefaultBufferSize, 0)
+	defer h.Close()
+
+	listener, _, _, err := h.Subscribe("a", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []uint64
+	for i := range 4 {
+		e, _ := h.Publish("a", "test", i)
+		ids = append(ids, e.ID)
+	}
+
+	resume := func(lastEventID uint64) ([]Event, bool) {
+		t.Helper()
+		sub, replay, complete, err := h.Subscribe("a", lastEventID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sub.Unsubscribe()
+		return replay, complete
+	}
+
+	if replay, complete := resume(ids[1]); !complete || len(replay) != 2 || replay[0].ID != ids[2] {
+		t.Errorf("resume after the second event: %v, complete %t", replay, complete)
+	}
+	// The history holds the last three events, so the second is lost.
+	if replay, complete := resume(ids[0]); complete || len(replay) != 3 {
+		t.Errorf("resume after the first event: %v, complete %t", replay, complete)
+	}
+
+	// Without an idle TTL nothing is kept while nobody listens, so a resume
+	// cannot tell what it missed.
+	listener.Unsubscribe()
+	if replay, complete := resume(ids[3]); complete || len(replay) != 0 {
+		t.Errorf("resume of an idle topic: %v, complete %t", replay, complete)
+	}
+	if _, complete := resume(0); !complete {
+		t.Error("a new subscription is incomplete")
+	}
+}
+
+func TestHubClose(t *testing.T) {
+	h := NewHub(DefaultHistorySize, DefaultBufferSize, DefaultIdleTTL)
+
+	sub, _, _, err := h.Subscribe("a", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Close()
+
+	if _, ok := <-sub.Events(); ok || !errors.Is(sub.Err(), ErrHubClosed) {
+		t.Fatalf("subscription after Close: err %v", sub.Err())
+	}
+	if _, _, _, err := h.Subscribe("a", 0); !errors.Is(err, ErrHubClosed) {
+		t.Fatalf("Subscribe after Close = %v, want ErrHubClosed", err)
+	}
+}
@@ -0,0 +1,199 @@
+package events
+
+import (
+	"context"
+	"igaming/internal/leaderboard"
+	"igaming/internal/logging"
+	"igaming/internal/models"
+	"math"
+	"sync"
+)
+
+// Tournament event types
+const (
+	TypeBetPlaced         = "bet_placed"
+	TypeLeaderboardDelta  = "leaderboard_delta"
+	TypePrizesDistributed = "prizes_distributed"
+	// TypeResync tells a resuming client that events were lost and it
+	// should reload the leaderboard.
+	TypeResync = "resync"
+)
+
+// TournamentSource loads the settled tournament state the publisher derives
+// its settlement events from.
+type TournamentSource interface {
+	GetTournamentByID(ctx context.Context, id uint) (*models.Tournament, error)
+	GetResults(ctx context.Context, tournamentID uint) ([]models.TournamentResult, error)
+}
+
+// LeaderboardSource serves the current tournament leaderboards, normally the
+// in-memory ranking.Service, so bets are published without a query.
+type LeaderboardSource interface {
+	Leaderboard(tournamentID uint, prizePool float64, offset, limit int) ([]models.LeaderboardEntry, int)
+}
+
+// BetPlacedData is the payload of a bet_placed event.
+type BetPlacedData struct {
+	BetID        uint    `json:"bet_id"`
+	TournamentID uint    `json:"tournament_id"`
+	PlayerID     uint    `json:"player_id"`
+	BetAmount    float64 `json:"bet_amount"`
+	PrizePool    float64 `json:"prize_pool"`
+}
+
+// LeaderboardDeltaData is the payload of a leaderboard_delta event. Entries
+// holds only the rows that changed, unless Full is set.
+type LeaderboardDeltaData struct {
+	TournamentID      uint                      `json:"tournament_id"`
+	PrizePool         float64                   `json:"prize_pool"`
+	TotalParticipants int                       `json:"total_participants"`
+	Full              bool                      `json:"full"`
+	Entries           []models.LeaderboardEntry `json:"entries"`
+}
+
+// PrizesDistributedData is the payload of a prizes_distributed event.
+type PrizesDistributedData struct {
+	TournamentID uint               `json:"tournament_id"`
+	PrizePool    float64            `json:"prize_pool"`
+	Results      []SettlementResult `json:"results"`
+}
+
+type SettlementResult struct {
+	PlayerID    uint    `json:"player_id"`
+	Placement   int     `json:"placement"`
+	PrizeAmount float64 `json:"prize_amount"`
+}
+
+// TournamentPublisher turns committed bets and settlements into tournament
+// events. It remembers the last leaderboard sent per tournament so it can
+// publish only the rows that changed, and forgets it when the tournament's
+// topic is discarded for having no listeners.
+type TournamentPublisher struct {
+	hub          *Hub
+	source       TournamentSource
+	leaderboards LeaderboardSource
+
+	mu     sync.Mutex
+	boards map[string]*board
+}
+
+// board is the last leaderboard published for a tournament. Its lock keeps
+// the events of one tournament in order without serializing other tournaments.
+type board struct {
+	mu      sync.Mutex
+	entries []models.LeaderboardEntry
+	known   bool
+}
+
+func NewTournamentPublisher(hub *Hub, source TournamentSource, leaderboards LeaderboardSource) *TournamentPublisher {
+	p := &TournamentPublisher{
+		hub:          hub,
+		source:       source,
+		leaderboards: leaderboards,
+		boards:       make(map[string]*board),
+	}
+	hub.OnIdle(p.forget)
+	return p
+}
+
+func (p *TournamentPublisher) board(name string) *board {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	b, ok := p.boards[name]
+	if !ok {
+		b = &board{}
+		p.boards[name] = b
+	}
+	return b
+}
+
+// forget drops the leaderboard last sent on a topic the hub discarded, so
+// the next listener gets a full leaderboard.
+func (p *TournamentPublisher) forget(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.boards, name)
+}
+
+// BetPlaced publishes a committed bet and the leaderboard rows it changed,
+// taking the leaderboard from the in-memory rankings. prizePool is the
+// tournament's prize pool including the bet.
+func (p *TournamentPublisher) BetPlaced(bet *models.TournamentBet, prizePool float64) {
+	name := TournamentTopic(bet.TournamentID)
+	if !p.hub.Active(name) {
+		return
+	}
+
+	b := p.board(name)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := p.hub.Publish(name, TypeBetPlaced, BetPlacedData{
+		BetID:        bet.ID,
+		TournamentID: bet.TournamentID,
+		PlayerID:     bet.PlayerID,
+		BetAmount:    bet.BetAmount,
+		PrizePool:    prizePool,
+	}); !ok {
+		// The topic was discarded after the check above, maybe before this
+		// board was created, so it is not kept as the next baseline.
+		p.forget(name)
+		return
+	}
+
+	entries, total := p.leaderboards.Leaderboard(bet.TournamentID, prizePool, 0, math.MaxInt)
+
+	delta := LeaderboardDeltaData{
+		TournamentID:      bet.TournamentID,
+		PrizePool:         prizePool,
+		TotalParticipants: total,
+		Full:              !b.known,
+		Entries:           entries,
+	}
+	if b.known {
+		delta.Entries = leaderboard.Diff(b.entries, entries)
+	}
+	b.entries, b.known = entries, true
+
+	p.hub.Publish(name, TypeLeaderboardDelta, delta)
+}
+
+// PrizesDistributed publishes the results of a settled tournament. A settled
+// tournament takes no more bets, so its last leaderboard is forgotten.
+func (p *TournamentPublisher) PrizesDistributed(ctx context.Context, tournamentID uint) {
+	name := TournamentTopic(tournamentID)
+
+	p.forget(name)
+	if !p.hub.Active(name) {
+		return
+	}
+
+	tournament, err := p.source.GetTournamentByID(ctx, tournamentID)
+	if err != nil {
+		logging.FromContext(ctx).Error("failed to publish settlement", "tournament_id", tournamentID, "error", err)
+		return
+	}
+
+	results, err := p.source.GetResults(ctx, tournamentID)
+	if err != nil {
+		logging.FromContext(ctx).Error("failed to publish settlement", "tournament_id", tournamentID, "error", err)
+		return
+	}
+
+	data := PrizesDistributedData{
+		TournamentID: tournamentID,
+		PrizePool:    tournament.PrizePool,
+		Results:      make([]SettlementResult, 0, len(results)),
+	}
+	for _, r := range results {
+		data.Results = append(data.Results, SettlementResult{
+			PlayerID:    r.PlayerID,
+			Placement:   r.Placement,
+			PrizeAmount: r.PrizeAmount,
+		})
+	}
+
+	p.hub.Publish(name, TypePrizesDistributed, data)
+}
@@ -0,0 +1,166 @@
+package events
+
+import (
+	"context"
+	"igaming/internal/models"
+	"testing"
+	"time"
+)
+
+// source serves a settled tournament. Bets are published from leaderboards
+// alone, so it counts its calls.
+type source struct {
+	calls int
+}
+
+func (s *source) GetTournamentByID(ctx context.Context, id uint) (*models.Tournament, error) {
+	s.calls++
+	return &models.Tournament{ID: id, PrizePool: 100}, nil
+}
+
+func (s *source) GetResults(ctx context.Context, tournamentID uint) ([]models.TournamentResult, error) {
+	s.calls++
+	return []models.TournamentResult{{TournamentID: tournamentID, PlayerID: 1, Placement: 1, PrizeAmount: 100}}, nil
+}
+
+// leaderboards serves a fixed leaderboard for every tournament.
+type leaderboards struct {
+	entries []models.LeaderboardEntry
+}
+
+func (l *leaderboards) Leaderboard(tournamentID uint, prizePool float64, offset, limit int) ([]models.LeaderboardEntry, int) {
+	return l.entries, len(l.entries)
+}
+
+// kept returns the number of leaderboards the publisher remembers.
+func (p *TournamentPublisher) kept() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.boards)
+}
+
+func receive(t *testing.T, sub *Subscription, eventType string) Event {
+	t.Helper()
+
+	select {
+	case e := <-sub.Events():
+		if e.Type != eventType {
+			t.Fatalf("event %s, want %s", e.Type, eventType)
+		}
+		return e
+	default:
+		t.Fatalf("no %s event", eventType)
+		return Event{}
+	}
+}
+
+func TestPublishBets(t *testing.T) {
+	hub := NewHub(DefaultHistorySize, DefaultBufferSize, idleTTL)
+	defer hub.Close()
+	src := &source{}
+	boards := &leaderboards{entries: []models.LeaderboardEntry{
+		{Position: 1, Placement: 1, PlayerID: 1, TotalBet: 30},
+		{Position: 2, Placement: 2, PlayerID: 2, TotalBet: 20},
+	}}
+	p := NewTournamentPublisher(hub, src, boards)
+	bet := &models.TournamentBet{ID: 1, TournamentID: 7, PlayerID: 1, BetAmount: 10}
+
+	// Nobody listens, so nothing is kept.
+	p.BetPlaced(bet, 100)
+	if p.kept() != 0 {
+		t.Fatalf("%d leaderboards kept without listeners", p.kept())
+	}
+
+	sub, _, _, err := hub.Subscribe(TournamentTopic(7), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p.BetPlaced(bet, 110)
+	if data := receive(t, sub, TypeBetPlaced).Data.(BetPlacedData); data.BetID != 1 || data.PrizePool != 110 {
+		t.Errorf("bet_placed = %+v", data)
+	}
+	delta := receive(t, sub, TypeLeaderboardDelta).Data.(LeaderboardDeltaData)
+	if !delta.Full || len(delta.Entries) != 2 || delta.TotalParticipants != 2 || delta.PrizePool != 110 {
+		t.Errorf("first delta = %+v, want the full leaderboard", delta)
+	}
+
+	boards.entries = []models.LeaderboardEntry{
+		{Position: 1, Placement: 1, PlayerID: 1, TotalBet: 30},
+		{Position: 2, Placement: 2, PlayerID: 2, TotalBet: 25},
+	}
+	p.BetPlaced(&models.TournamentBet{ID: 2, TournamentID: 7, PlayerID: 2, BetAmount: 5}, 115)
+	receive(t, sub, TypeBetPlaced)
+	last := receive(t, sub, TypeLeaderboardDelta)
+	delta = last.Data.(LeaderboardDeltaData)
+	if delta.Full || len(delta.Entries) != 1 || delta.Entries[0].PlayerID != 2 {
+		t.Errorf("second delta = %+v, want player 2's row", delta)
+	}
+	if src.calls != 0 {
+		t.Errorf("publishing bets made %d database calls", src.calls)
+	}
+
+	// Bets are still published for the idle TTL after the last listener
+	// left, so it can resume. Then the leaderboard is forgotten, and the
+	// next listener starts from a full leaderboard.
+	sub.Unsubscribe()
+	p.BetPlaced(bet, 115)
+	resumed, replay, complete, err := hub.Subscribe(TournamentTopic(7), last.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resumed.Unsubscribe()
+	if !complete || len(replay) != 2 || replay[1].Type != TypeLeaderboardDelta || replay[1].Data.(LeaderboardDeltaData).Full {
+		t.Fatalf("resume replayed %+v, complete %t; want the bet and its delta", replay, complete)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for hub.Active(TournamentTopic(7)) || p.kept() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d leaderboards kept after the topic went idle", p.kept())
+		}
+		time.Sleep(time.Millisecond)
+	}
+	sub, _, _, err = hub.Subscribe(TournamentTopic(7), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+	p.BetPlaced(bet, 120)
+	receive(t, sub, TypeBetPlaced)
+	if delta := receive(t, sub, TypeLeaderboardDelta).Data.(LeaderboardDeltaData); !delta.Full {
+		t.Errorf("delta for a new listener = %+v, want the full leaderboard", delta)
+	}
+}
+
+func TestPublishPrizesDistributed(t *testing.T) {
+	hub := NewHub(DefaultHistorySize, DefaultBufferSize, DefaultIdleTTL)
+	defer hub.Close()
+	src := &source{}
+	p := NewTournamentPublisher(hub, src, &leaderboards{})
+	ctx := context.Background()
+
+	p.PrizesDistributed(ctx, 7)
+	if src.calls != 0 {
+		t.Errorf("settlement without listeners made %d database calls", src.calls)
+	}
+
+	sub, _, _, err := hub.Subscribe(TournamentTopic(7), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	p.BetPlaced(&models.TournamentBet{ID: 1, TournamentID: 7, PlayerID: 1, BetAmount: 10}, 100)
+	receive(t, sub, TypeBetPlaced)
+	receive(t, sub, TypeLeaderboardDelta)
+
+	p.PrizesDistributed(ctx, 7)
+	data := receive(t, sub, TypePrizesDistributed).Data.(PrizesDistributedData)
+	if data.PrizePool != 100 || len(data.Results) != 1 || data.Results[0].PrizeAmount != 100 {
+		t.Errorf("prizes_distributed = %+v", data)
+	}
+	if p.kept() != 0 {
+		t.Errorf("%d leaderboards kept after settlement", p.kept())
+	}
+}
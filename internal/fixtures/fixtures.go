@@ -0,0 +1,86 @@
+// Package fixtures loads sample data into a database or the in-memory store:
+// fixed datasets for demos and a deterministic generator for benchmarks.
+// Nothing here runs as part of the migrations, so production databases start
+// empty.
+package fixtures
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Dataset names.
+const (
+	Demo     = "demo"
+	LoadTest = "load-test"
+	Empty    = "empty"
+)
+
+// ErrUnknownDataset is returned for a dataset name that does not exist.
+var ErrUnknownDataset = errors.New("unknown dataset")
+
+type Player struct {
+	Name         string
+	Email        string
+	PasswordHash string
+	Balance      float64
+}
+
+type Tournament struct {
+	Name      string
+	PrizePool float64
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+// Bet refers to its player and tournament by their index in Data.
+type Bet struct {
+	Player     int
+	Tournament int
+	Amount     float64
+	PlacedAt   time.Time
+}
+
+// Data is a set of records to insert together. Balances are the balances
+// after the bets; inserting bets does not charge them again.
+type Data struct {
+	Players     []Player
+	Tournaments []Tournament
+	Bets        []Bet
+}
+
+var datasets = map[string]func() Data{
+	Demo:  demo,
+	Empty: func() Data { return Data{} },
+	LoadTest: func() Data {
+		return Generate(GenerateOptions{
+			Players:     10000,
+			Tournaments: 200,
+			Bets:        200000,
+			Seed:        1,
+			Start:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			Now:         time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		})
+	},
+}
+
+// Names lists the datasets.
+func Names() []string {
+	names := make([]string, 0, len(datasets))
+	for name := range datasets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Dataset returns the named dataset.
+func Dataset(name string) (Data, error) {
+	build, ok := datasets[name]
+	if !ok {
+		return Data{}, fmt.Errorf("%w %q, expected one of %v", ErrUnknownDataset, name, Names())
+	}
+	return build(), nil
+}
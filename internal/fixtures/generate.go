@@ -0,0 +1,176 @@
+package fixtures
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"time"
+)
+
+// GenerateOptions sizes a synthetic dataset.
+type GenerateOptions struct {
+	Players     int
+	Tournaments int
+	Bets        int
+	// The same seed always produces the same data
+	Seed uint64
+	// Tournaments are spread over the year from Start
+	Start time.Time
+	// Now is when the data is generated. No bet is placed after it, so
+	// tournaments that have not started yet get none.
+	Now time.Time
+}
+
+// maxPlayerDraws bounds how often a bet looks for a player who can still
+// afford it before the generator gives up on the remaining bets.
+const maxPlayerDraws = 1000
+
+// minBet is the smallest generated bet.
+const minBet = 1.0
+
+var (
+	firstNames = []string{
+		"Alice", "Bob", "Charlie", "Diana", "Evan", "Fiona", "George", "Hannah",
+		"Ian", "Jenny", "Karl", "Laura", "Marco", "Nina", "Oscar", "Paula",
+		"Quinn", "Rosa", "Sam", "Tara", "Umar", "Vera", "Will", "Yara", "Zoe",
+	}
+	lastNames = []string{
+		"Smith", "Johnson", "Brown", "Miller", "Davis", "Clark", "Wilson",
+		"White", "Moore", "Taylor", "Garcia", "Novak", "Rossi", "Kowalski",
+		"Jensen", "Silva", "Murphy", "Schmidt", "Dubois", "Tanaka",
+	}
+	tournamentWords = []string{
+		"Classic", "Championship", "Showdown", "Royale", "Invitational",
+		"Warmup", "High Roller", "Frenzy", "Knockout", "Special", "Series",
+		"Open", "Masters", "Sprint", "Marathon",
+	}
+	// Prize pools with their relative frequency: most tournaments are small.
+	prizeTiers = []struct {
+		pool   float64
+		weight int
+	}{
+		{5000, 30}, {10000, 25}, {25000, 20}, {50000, 12}, {100000, 8}, {250000, 5},
+	}
+)
+
+// Generate builds a synthetic dataset. Balances and bet amounts are
+// log-normal, so most players hold and bet little while a few hold and bet
+// a lot. Activity follows a Zipf distribution: a small share of players
+// place most bets, and a few tournaments draw most of the field. Players
+// only stake what they have: a bet is cut to the player's remaining funds,
+// and a player left with less than the smallest bet places no more, so
+// fewer than opts.Bets may be generated when the field runs dry.
+func Generate(opts GenerateOptions) Data {
+	rng := rand.New(rand.NewPCG(opts.Seed, opts.Seed^0x9e3779b97f4a7c15))
+	data := Data{
+		Players:     make([]Player, opts.Players),
+		Tournaments: make([]Tournament, opts.Tournaments),
+	}
+
+	for i := range data.Players {
+		first := firstNames[rng.IntN(len(firstNames))]
+		last := lastNames[rng.IntN(len(lastNames))]
+		data.Players[i] = Player{
+			Name:         first + " " + last,
+			Email:        fmt.Sprintf("%s.%s.%d@example.com", strings.ToLower(first), strings.ToLower(last), i+1),
+			PasswordHash: "$2a$10$" + randomString(rng, 15),
+			Balance:      roundCents(logNormal(rng, 2000, 1.0, 0, 1_000_000)),
+		}
+	}
+
+	totalWeight := 0
+	for _, t := range prizeTiers {
+		totalWeight += t.weight
+	}
+	for i := range data.Tournaments {
+		pool := prizeTiers[0].pool
+		for n, t := rng.IntN(totalWeight), 0; t < len(prizeTiers); t++ {
+			if n < prizeTiers[t].weight {
+				pool = prizeTiers[t].pool
+				break
+			}
+			n -= prizeTiers[t].weight
+		}
+
+		start := opts.Start.Add(time.Duration(rng.IntN(365*24)) * time.Hour)
+		data.Tournaments[i] = Tournament{
+			Name:      fmt.Sprintf("%s #%d", tournamentWords[rng.IntN(len(tournamentWords))], i+1),
+			PrizePool: pool,
+			StartDate: start,
+			EndDate:   start.Add(time.Duration(4+rng.IntN(5*24-4)) * time.Hour),
+		}
+	}
+
+	// Only tournaments that have started by Now take bets.
+	var open []int
+	for i, t := range data.Tournaments {
+		if t.StartDate.Before(opts.Now) {
+			open = append(open, i)
+		}
+	}
+	if opts.Players == 0 || len(open) == 0 {
+		return data
+	}
+
+	// Zipf picks low indices most often; shuffle which players and
+	// tournaments those are so activity does not follow insertion order.
+	playerOrder := rng.Perm(opts.Players)
+	rng.Shuffle(len(open), func(i, j int) { open[i], open[j] = open[j], open[i] })
+	pickPlayer := rand.NewZipf(rng, 1.1, 1, uint64(opts.Players-1))
+	pickTournament := rand.NewZipf(rng, 1.05, 1, uint64(len(open)-1))
+
+	data.Bets = make([]Bet, 0, opts.Bets)
+	for range opts.Bets {
+		ti := open[pickTournament.Uint64()]
+		t := data.Tournaments[ti]
+		end := t.EndDate
+		if opts.Now.Before(end) {
+			end = opts.Now
+		}
+		window := end.Sub(t.StartDate)
+		amount := roundCents(logNormal(rng, 50, 1.2, minBet, 10000))
+
+		player := -1
+		for range maxPlayerDraws {
+			if p := playerOrder[pickPlayer.Uint64()]; data.Players[p].Balance >= minBet {
+				player = p
+				break
+			}
+		}
+		if player < 0 {
+			break
+		}
+		// Balances end up as what is left after the bets.
+		amount = min(amount, data.Players[player].Balance)
+		data.Players[player].Balance = roundCents(data.Players[player].Balance - amount)
+
+		data.Bets = append(data.Bets, Bet{
+			Player:     player,
+			Tournament: ti,
+			Amount:     amount,
+			PlacedAt:   t.StartDate.Add(time.Duration(rng.Int64N(int64(window)))).Truncate(time.Second),
+		})
+	}
+	return data
+}
+
+// logNormal draws from a log-normal distribution with the given median,
+// clamped to [lo, hi].
+func logNormal(rng *rand.Rand, median, sigma, lo, hi float64) float64 {
+	v := median * math.Exp(sigma*rng.NormFloat64())
+	return min(max(v, lo), hi)
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func randomString(rng *rand.Rand, n int) string {
+	const alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = alphabet[rng.IntN(len(alphabet))]
+	}
+	return string(b)
+}
@@ -0,0 +1,84 @@
+package fixtures
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func testOptions(seed uint64) GenerateOptions {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return GenerateOptions{
+		Players:     300,
+		Tournaments: 40,
+		Bets:        5000,
+		Seed:        seed,
+		Start:       start,
+		// Halfway through the year, so some tournaments are still to come
+		// and some are running.
+		Now: start.AddDate(0, 6, 0),
+	}
+}
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	a := Generate(testOptions(7))
+	b := Generate(testOptions(7))
+	if !reflect.DeepEqual(a, b) {
+		t.Fatal("the same seed produced different data")
+	}
+
+	c := Generate(testOptions(8))
+	if reflect.DeepEqual(a.Bets, c.Bets) || reflect.DeepEqual(a.Players, c.Players) {
+		t.Fatal("different seeds produced the same data")
+	}
+}
+
+func TestGenerateBets(t *testing.T) {
+	opts := testOptions(7)
+	initial := Generate(GenerateOptions{Players: opts.Players, Tournaments: opts.Tournaments, Seed: opts.Seed, Start: opts.Start, Now: opts.Now})
+	data := Generate(opts)
+
+	if len(data.Bets) != opts.Bets {
+		t.Fatalf("%d bets, want %d", len(data.Bets), opts.Bets)
+	}
+
+	stakes := make([]float64, len(data.Players))
+	for i, b := range data.Bets {
+		tr := data.Tournaments[b.Tournament]
+		if b.PlacedAt.After(opts.Now) {
+			t.Fatalf("bet %d placed at %v, after the data was generated at %v", i, b.PlacedAt, opts.Now)
+		}
+		if b.PlacedAt.Before(tr.StartDate) || b.PlacedAt.After(tr.EndDate) {
+			t.Fatalf("bet %d placed at %v, outside its tournament %v to %v", i, b.PlacedAt, tr.StartDate, tr.EndDate)
+		}
+		if b.Amount <= 0 {
+			t.Fatalf("bet %d amount %.2f", i, b.Amount)
+		}
+		stakes[b.Player] += b.Amount
+	}
+
+	// Balances are what is left after the bets, out of the balance the
+	// player started with.
+	for i, p := range data.Players {
+		if p.Balance < 0 {
+			t.Errorf("player %d balance %.2f is negative", i, p.Balance)
+		}
+		if start := initial.Players[i].Balance; math.Abs(start-stakes[i]-p.Balance) > 0.01 {
+			t.Errorf("player %d staked %.2f and has %.2f left out of %.2f", i, stakes[i], p.Balance, start)
+		}
+	}
+}
+
+func TestGenerateWithoutOpenTournaments(t *testing.T) {
+	opts := testOptions(7)
+	opts.Now = opts.Start
+
+	data := Generate(opts)
+	if len(data.Bets) != 0 {
+		t.Fatalf("%d bets on tournaments that have not started", len(data.Bets))
+	}
+	if len(data.Players) != opts.Players || len(data.Tournaments) != opts.Tournaments {
+		t.Fatalf("%d players and %d tournaments, want %d and %d", len(data.Players), len(data.Tournaments), opts.Players, opts.Tournaments)
+	}
+}
@@ -0,0 +1,179 @@
+package fixtures
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"igaming/internal/dialect"
+	"strings"
+)
+
+// batchSize is the number of rows per INSERT statement.
+const batchSize = 500
+
+// resetTables lists every table holding data, children before parents.
+var resetTables = []string{
+	"rating_history",
+	"player_ratings",
+	"season_results",
+	"tournament_results",
+	"tournament_bets",
+	"ranking_snapshots",
+	"player_exclusions",
+	"player_limits",
+	"tournaments",
+	"season_points",
+	"seasons",
+	"players",
+}
+
+// Counts reports how many rows Insert wrote.
+type Counts struct {
+	Players     int
+	Tournaments int
+	Bets        int
+}
+
+// Insert writes data in one transaction with multi-row inserts. Existing
+// rows are kept; the emails must not clash with existing players.
+func Insert(ctx context.Context, db *sql.DB, d dialect.Dialect, data Data) (Counts, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return Counts{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	playerIDs, err := insertBatches(ctx, tx, d, len(data.Players),
+		"players", "name, email, password_hash, account_balance", 4,
+		func(i int) []any {
+			p := data.Players[i]
+			return []any{p.Name, p.Email, p.PasswordHash, p.Balance}
+		})
+	if err != nil {
+		return Counts{}, fmt.Errorf("failed to insert players: %w", err)
+	}
+
+	tournamentIDs, err := insertBatches(ctx, tx, d, len(data.Tournaments),
+		"tournaments", "name, prize_pool, guaranteed_prize_pool, start_date, end_date", 5,
+		func(i int) []any {
+			t := data.Tournaments[i]
+			return []any{t.Name, t.PrizePool, t.PrizePool, t.StartDate, t.EndDate}
+		})
+	if err != nil {
+		return Counts{}, fmt.Errorf("failed to insert tournaments: %w", err)
+	}
+
+	for i, b := range data.Bets {
+		if b.Player < 0 || b.Player >= len(playerIDs) || b.Tournament < 0 || b.Tournament >= len(tournamentIDs) {
+			return Counts{}, fmt.Errorf("bet %d refers to a player or tournament outside the dataset", i)
+		}
+	}
+	_, err = insertBatches(ctx, tx, d, len(data.Bets),
+		"tournament_bets", "player_id, tournament_id, bet_amount, created_at", 4,
+		func(i int) []any {
+			b := data.Bets[i]
+			return []any{playerIDs[b.Player], tournamentIDs[b.Tournament], b.Amount, b.PlacedAt}
+		})
+	if err != nil {
+		return Counts{}, fmt.Errorf("failed to insert bets: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return Counts{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return Counts{Players: len(data.Players), Tournaments: len(data.Tournaments), Bets: len(data.Bets)}, nil
+}
+
+// Reset deletes all data, leaving the schema and migration history alone.
+func Reset(ctx context.Context, db *sql.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, table := range resetTables {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM "+table); err != nil {
+			return fmt.Errorf("failed to clear %s: %w", table, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// insertBatches inserts n rows of width columns into table and returns their
+// IDs. On MySQL, a multi-row INSERT with a known row count gets consecutive
+// AUTO_INCREMENT values starting at LAST_INSERT_ID, whatever
+// innodb_autoinc_lock_mode is set to. SQLite writes one statement at a time,
+// so its rows are consecutive too, but it reports the ID of the last one.
+// Postgres makes no such promise, so the IDs are taken from the sequence
+// first and inserted explicitly.
+func insertBatches(ctx context.Context, tx *sql.Tx, d dialect.Dialect, n int, table, columns string, width int, row func(i int) []any) ([]int64, error) {
+	ids := make([]int64, 0, n)
+	for start := 0; start < n; start += batchSize {
+		end := min(start+batchSize, n)
+
+		if d == dialect.Postgres {
+			batch, err := nextIDs(ctx, tx, table, end-start)
+			if err != nil {
+				return nil, err
+			}
+			args := make([]any, 0, (end-start)*(width+1))
+			for i := start; i < end; i++ {
+				args = append(args, batch[i-start])
+				args = append(args, row(i)...)
+			}
+			query := "INSERT INTO " + table + " (id, " + columns + ") VALUES " + rowPlaceholders(end-start, width+1)
+			if _, err := tx.ExecContext(ctx, d.Rebind(query), args...); err != nil {
+				return nil, err
+			}
+			ids = append(ids, batch...)
+			continue
+		}
+
+		args := make([]any, 0, (end-start)*width)
+		for i := start; i < end; i++ {
+			args = append(args, row(i)...)
+		}
+		query := "INSERT INTO " + table + " (" + columns + ") VALUES " + rowPlaceholders(end-start, width)
+		res, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return nil, err
+		}
+		first, err := res.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+		if d == dialect.SQLite {
+			first -= int64(end - start - 1)
+		}
+		for i := range end - start {
+			ids = append(ids, first+int64(i))
+		}
+	}
+	return ids, nil
+}
+
+// nextIDs draws n values from the ID sequence of a Postgres table.
+func nextIDs(ctx context.Context, tx *sql.Tx, table string, n int) ([]int64, error) {
+	rows, err := tx.QueryContext(ctx,
+		"SELECT nextval(pg_get_serial_sequence($1, 'id')) FROM generate_series(1, $2)", table, n)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]int64, 0, n)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func rowPlaceholders(rows, width int) string {
+	row := "(" + strings.TrimSuffix(strings.Repeat("?, ", width), ", ") + ")"
+	return strings.TrimSuffix(strings.Repeat(row+", ", rows), ", ")
+}
@@ -0,0 +1,62 @@
+package dtos
+
+// LeaderboardEntryResponse represents a participant's live standing
+type LeaderboardEntryResponse struct {
+	// 1-based row in the leaderboard
+	// example: 4
+	Position int `json:"position"`
+
+	// Placement shared by all players in the tie group
+	// example: 3
+	Placement int `json:"placement"`
+
+	// Player ID
+	// example: 123
+	PlayerID uint `json:"player_id"`
+
+	// Player name
+	// example: JohnDoe123
+	PlayerName string `json:"player_name"`
+
+	// Total amount the player has bet in the tournament
+	// example: 750.00
+	TotalBet float64 `json:"total_bet"`
+
+	// Number of players sharing the placement
+	// example: 2
+	TieGroupSize int `json:"tie_group_size"`
+
+	// Prize the player would win if the tournament were settled now
+	// example: 2500.00
+	ProjectedPrize float64 `json:"projected_prize"`
+
+	// Additional bet needed to draw level with the next placement up
+	// example: 250.00
+	GapToNext float64 `json:"gap_to_next"`
+}
+
+// LeaderboardResponse represents a page of a tournament leaderboard
+type LeaderboardResponse struct {
+	// Tournament ID
+	// example: 456
+	TournamentID uint `json:"tournament_id"`
+
+	// Prize pool the projections are based on
+	// example: 25000.00
+	PrizePool float64 `json:"prize_pool"`
+
+	// Number of players with at least one bet
+	// example: 42
+	TotalParticipants int `json:"total_participants"`
+
+	// Offset of the first entry
+	// example: 0
+	Offset int `json:"offset"`
+
+	// Maximum number of entries in the page
+	// example: 50
+	Limit int `json:"limit"`
+
+	// Leaderboard entries
+	Entries []LeaderboardEntryResponse `json:"entries"`
+}
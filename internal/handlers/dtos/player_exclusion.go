@@ -0,0 +1,38 @@
+package dtos
+
+import "time"
+
+// CreatePlayerExclusionRequest represents a player excluding themselves
+type CreatePlayerExclusionRequest struct {
+	// How long the player is excluded for
+	// required: true
+	// example: 7d
+	Duration string `json:"duration" validate:"required,oneof=24h 7d 6m permanent" enums:"24h,7d,6m,permanent"`
+}
+
+// PlayerExclusionResponse represents a self-exclusion
+type PlayerExclusionResponse struct {
+	// Exclusion ID
+	// example: 1
+	ID uint `json:"id"`
+
+	// Excluded player ID
+	// example: 123
+	PlayerID uint `json:"player_id"`
+
+	// Exclusion duration
+	// example: 7d
+	Duration string `json:"duration"`
+
+	// When the exclusion started
+	// example: 2023-09-01T10:15:00Z
+	StartsAt time.Time `json:"starts_at"`
+
+	// When the exclusion ends (never when null)
+	// example: 2023-09-08T10:15:00Z
+	EndsAt *time.Time `json:"ends_at"`
+
+	// Whether the exclusion is currently in force
+	// example: true
+	Active bool `json:"active"`
+}
@@ -0,0 +1,59 @@
+package dtos
+
+import "time"
+
+// SetPlayerLimitsRequest represents a change to a player's responsible gambling limits
+type SetPlayerLimitsRequest struct {
+	// Limits to change; limits not listed are left untouched
+	// required: true
+	Limits []PlayerLimitChange `json:"limits" validate:"required,dive"`
+}
+
+// PlayerLimitChange sets or removes a single limit
+type PlayerLimitChange struct {
+	// Limit type: wager (total bets) or loss (bets minus prizes won)
+	// required: true
+	// example: wager
+	Type string `json:"type" validate:"required,oneof=wager loss" enums:"wager,loss"`
+
+	// Limit period
+	// required: true
+	// example: daily
+	Period string `json:"period" validate:"required,oneof=daily weekly monthly" enums:"daily,weekly,monthly"`
+
+	// New limit amount; null removes the limit
+	// minimum: 0.01
+	// example: 100.00
+	Amount *float64 `json:"amount" validate:"omitempty,gt=0"`
+}
+
+// PlayerLimitResponse represents a player's limit and its current usage
+type PlayerLimitResponse struct {
+	// Limit type
+	// example: wager
+	Type string `json:"type"`
+
+	// Limit period
+	// example: daily
+	Period string `json:"period"`
+
+	// Limit currently in force (no limit when null)
+	// example: 100.00
+	Amount *float64 `json:"amount"`
+
+	// Limit that takes effect after the cooling-off period
+	// example: 250.00
+	PendingAmount *float64 `json:"pending_amount,omitempty"`
+
+	// When the pending change takes effect
+	// example: 2023-09-02T10:15:00Z
+	PendingEffectiveAt *time.Time `json:"pending_effective_at,omitempty"`
+
+	// Amount used in the current period
+	// example: 40.00
+	Used float64 `json:"used"`
+
+	// Amount left in the current period (null when there is no limit)
+	// example: 60.00
+	Remaining *float64 `json:"remaining"`
+}
@@ -0,0 +1,20 @@
+package dtos
+
+import "igaming/internal/models"
+
+// PlayerRatingResponse is a player's rating with their recent rating changes
+type PlayerRatingResponse struct {
+	models.PlayerRating
+	// Most recent rating changes, newest first
+	History []models.RatingChange `json:"history"`
+}
+
+// RecomputeRatingsResponse reports a rating recomputation
+type RecomputeRatingsResponse struct {
+	// Settled tournaments rated
+	// example: 42
+	Tournaments int `json:"tournaments"`
+	// Players with a rating
+	// example: 310
+	Players int `json:"players"`
+}
@@ -0,0 +1,88 @@
+package dtos
+
+import "time"
+
+// SeasonPointsRequest is the points awarded for one tournament placement
+type SeasonPointsRequest struct {
+	// Tournament placement (1-based), up to the number of prize tiers
+	// example: 1
+	Placement int `json:"placement"`
+	// Points awarded for the placement
+	// example: 10
+	Points int `json:"points"`
+}
+
+// CreateSeasonRequest represents the season creation payload
+type CreateSeasonRequest struct {
+	// Season name
+	// required: true
+	// example: Season 2024
+	Name string `json:"name"`
+	// format: date-time
+	// example: 2024-01-01T00:00:00Z
+	StartDate time.Time `json:"start_date" swaggertype:"string" format:"date-time"`
+	// format: date-time
+	// example: 2024-06-30T23:59:59Z
+	EndDate time.Time `json:"end_date" swaggertype:"string" format:"date-time"`
+	// Prize pool paid out when the season ends
+	// example: 10000
+	PrizePool float64 `json:"prize_pool"`
+	// Points per placement; 10/6/4 for placements 1-3 when omitted
+	PointsTable []SeasonPointsRequest `json:"points_table,omitempty"`
+}
+
+// SetSeasonPointsRequest replaces a season's points table
+type SetSeasonPointsRequest struct {
+	PointsTable []SeasonPointsRequest `json:"points_table"`
+}
+
+// SeasonResponse represents a season
+type SeasonResponse struct {
+	// example: 1
+	ID uint `json:"id"`
+	// example: Season 2024
+	Name string `json:"name"`
+	// format: date-time
+	StartDate time.Time `json:"start_date" swaggertype:"string" format:"date-time"`
+	// format: date-time
+	EndDate time.Time `json:"end_date" swaggertype:"string" format:"date-time"`
+	// example: 10000
+	PrizePool float64 `json:"prize_pool"`
+	// Whether the season prizes have been paid out
+	PrizesDistributed bool `json:"prizes_distributed"`
+	// Points per placement (omitted in lists)
+	PointsTable []SeasonPointsRequest `json:"points_table,omitempty"`
+}
+
+// SeasonStandingResponse is a player's position in a season
+type SeasonStandingResponse struct {
+	// Placement after tie-breakers; equal only for a full tie
+	// example: 1
+	Placement int `json:"placement"`
+	// example: 123
+	PlayerID uint `json:"player_id"`
+	// example: JohnDoe123
+	PlayerName string `json:"player_name"`
+	// example: 26
+	Points int `json:"points"`
+	// Tournaments won (first tie-breaker)
+	// example: 2
+	Wins int `json:"wins"`
+	// Tournaments finished in a ranked placement
+	// example: 3
+	TournamentsPlaced int `json:"tournaments_placed"`
+	// When the player reached their points total (second tie-breaker)
+	// format: date-time
+	AchievedAt *time.Time `json:"achieved_at" swaggertype:"string" format:"date-time"`
+	// Season prize for the placement: paid once distributed, projected before
+	// example: 5000
+	Prize float64 `json:"prize"`
+}
+
+// SeasonStandingsResponse is the season leaderboard
+type SeasonStandingsResponse struct {
+	SeasonID          uint                     `json:"season_id"`
+	PrizePool         float64                  `json:"prize_pool"`
+	PrizesDistributed bool                     `json:"prizes_distributed"`
+	Standings         []SeasonStandingResponse `json:"standings"`
+}
@@ -7,16 +7,52 @@ type CreateTournamentRequest struct {
 	// example: tournament123
 	// default: tournament123
     Name      string    `json:"name" validate:"required,min=3,max=100"`
-    // Prize pool amount (must be positive)
+    // Prize pool amount (must be positive in fixed mode)
 	// example: 3333
 	// default: 3333
-	PrizePool float64   `json:"prize_pool" validate:"required,gt=0" swaggertype:"number" default:"3333"`
+	PrizePool float64   `json:"prize_pool" validate:"gte=0" swaggertype:"number" default:"3333"`
+    // Prize pool mode: "fixed" (default) or "accumulating"
+    // example: fixed
+    PoolMode string `json:"pool_mode,omitempty" enums:"fixed,accumulating"`
+    // Guaranteed minimum prize pool in accumulating mode
+    // example: 1000
+    GuaranteedPrizePool float64 `json:"guaranteed_prize_pool,omitempty"`
+    // Percentage of every bet kept as house rake in accumulating mode (0-100)
+    // example: 10
+    RakePercentage float64 `json:"rake_percentage,omitempty"`
     // format: date-time
     // example: 2023-09-01T15:00:00Z
     StartDate time.Time `json:"start_date" swaggertype:"string" format:"date-time"`
     // format: date-time
     // example: 2023-09-05T18:00:00Z
     EndDate time.Time `json:"end_date" swaggertype:"string" format:"date-time"`
+    // Minimum amount of a single bet (optional)
+    // example: 10
+    MinBet *float64 `json:"min_bet,omitempty"`
+    // Maximum amount of a single bet (optional)
+    // example: 500
+    MaxBet *float64 `json:"max_bet,omitempty"`
+    // Maximum total stake per player (optional)
+    // example: 2000
+    MaxStakePerPlayer *float64 `json:"max_stake_per_player,omitempty"`
+    // Maximum number of distinct participants (optional)
+    // example: 100
+    MaxParticipants *int `json:"max_participants,omitempty"`
+    // Fixed entry fee; players enter once by betting exactly this amount (optional)
+    // example: 50
+    EntryFee *float64 `json:"entry_fee,omitempty"`
+    // When betting opens; bets are accepted any time before closing when omitted
+    // example: 2023-08-31T15:00:00Z
+    BettingOpensAt *time.Time `json:"betting_opens_at,omitempty" swaggertype:"string" format:"date-time"`
+    // When betting closes; defaults to end_date
+    // example: 2023-09-05T18:00:00Z
+    BettingClosesAt *time.Time `json:"betting_closes_at,omitempty" swaggertype:"string" format:"date-time"`
+    // Minutes after start_date during which new players may still join (optional)
+    // example: 30
+    LateRegistrationMinutes *int `json:"late_registration_minutes,omitempty"`
+    // Season the tournament counts towards (optional)
+    // example: 1
+    SeasonID *uint `json:"season_id,omitempty"`
 }
 
 type TournamentResponse struct {
@@ -24,14 +60,40 @@ type TournamentResponse struct {
     ID        uint      `json:"id"`
     // Tournament name
     Name      string    `json:"name"`
-    // Prize pool amount
+    // Current prize pool amount
     PrizePool float64   `json:"prize_pool"`
+    // Prize pool mode
+    PoolMode string `json:"pool_mode"`
+    // Guaranteed minimum prize pool
+    GuaranteedPrizePool float64 `json:"guaranteed_prize_pool"`
+    // Percentage of every bet kept as house rake
+    RakePercentage float64 `json:"rake_percentage"`
+    // Rake collected by the house so far
+    RakeCollected float64 `json:"rake_collected"`
     // format: date-time
     // example: 2023-09-01T15:00:00Z
     StartDate time.Time `json:"start_date" swaggertype:"string" format:"date-time"`
     // format: date-time
     // example: 2023-09-05T18:00:00Z
     EndDate time.Time `json:"end_date" swaggertype:"string" format:"date-time"`
+    // Minimum amount of a single bet
+    MinBet *float64 `json:"min_bet,omitempty"`
+    // Maximum amount of a single bet
+    MaxBet *float64 `json:"max_bet,omitempty"`
+    // Maximum total stake per player
+    MaxStakePerPlayer *float64 `json:"max_stake_per_player,omitempty"`
+    // Maximum number of distinct participants
+    MaxParticipants *int `json:"max_participants,omitempty"`
+    // Fixed entry fee
+    EntryFee *float64 `json:"entry_fee,omitempty"`
+    // When betting opens
+    BettingOpensAt *time.Time `json:"betting_opens_at,omitempty" swaggertype:"string" format:"date-time"`
+    // When betting closes
+    BettingClosesAt *time.Time `json:"betting_closes_at,omitempty" swaggertype:"string" format:"date-time"`
+    // Late registration period in minutes after start_date
+    LateRegistrationMinutes *int `json:"late_registration_minutes,omitempty"`
+    // Season the tournament counts towards
+    SeasonID *uint `json:"season_id,omitempty"`
     // format: date-time
     // example: 2023-08-25T09:30:00Z
     CreatedAt time.Time `json:"created_at" swaggertype:"string" format:"date-time"`
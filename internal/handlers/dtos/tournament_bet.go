@@ -39,6 +39,10 @@ type TournamentBetResponse struct {
 	// example: 50.00
 	BetAmount float64 `json:"bet_amount"`
 	
+	// Part of the bet kept by the house as rake
+	// example: 5.00
+	RakeAmount float64 `json:"rake_amount"`
+	
 	// Bet placement timestamp
 	// example: 2023-09-01T10:15:00Z
 	CreatedAt time.Time `json:"created_at"`
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"igaming/internal/health"
+	"net/http"
+)
+
+type HealthHandler struct {
+	checker *health.Checker
+}
+
+func NewHealthHandler(checker *health.Checker) *HealthHandler {
+	return &HealthHandler{checker: checker}
+}
+
+// Live godoc
+// @Summary Liveness probe
+// @Description Reports that the process is up and serving HTTP. It checks no dependencies, so a database outage does not get the service restarted.
+// @Tags health
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Router /health/live [get]
+func (h *HealthHandler) Live(w http.ResponseWriter, r *http.Request) {
+	respondWithJSON(w, http.StatusOK, map[string]string{"status": health.StatusOK})
+}
+
+// Ready godoc
+// @Summary Readiness probe
+// @Description Pings the database, checks the schema is at the migration version this build expects and reports the background jobs. Returns 503 when a check fails; a degraded check (e.g. a failing background job) is reported but keeps the service ready. /health is an alias.
+// @Tags health
+// @Produce json
+// @Success 200 {object} health.Report
+// @Failure 503 {object} health.Report
+// @Router /health/ready [get]
+func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	report := h.checker.Ready(r.Context())
+
+	status := http.StatusOK
+	if !report.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	respondWithJSON(w, status, report)
+}
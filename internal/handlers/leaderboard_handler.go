@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"errors"
+	"igaming/internal/handlers/dtos"
+	"igaming/internal/models"
+	"igaming/internal/ranking"
+	"igaming/internal/repository"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	defaultLeaderboardLimit = 50
+	maxLeaderboardLimit     = 500
+)
+
+type LeaderboardHandler struct {
+	repo     repository.TournamentRepository
+	rankings *ranking.Service
+}
+
+func NewLeaderboardHandler(repo repository.TournamentRepository, rankings *ranking.Service) *LeaderboardHandler {
+	return &LeaderboardHandler{repo: repo, rankings: rankings}
+}
+
+// GetLeaderboard godoc
+// @Summary Get tournament leaderboard
+// @Description Live standings of a tournament ranked by total bet, with tie groups, projected prizes and the gap to the next placement. With player_id the page is centred on that player.
+// @Tags tournaments
+// @Accept json
+// @Produce json
+// @Param id path int true "Tournament ID"
+// @Param limit query int false "Page size (default 50, max 500)"
+// @Param offset query int false "Offset of the first entry"
+// @Param player_id query int false "Return the page around this player"
+// @Success 200 {object} dtos.LeaderboardResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tournaments/{id}/leaderboard [get]
+func (h *LeaderboardHandler) GetLeaderboard(w http.ResponseWriter, r *http.Request) {
+	tournamentID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid tournament ID")
+		return
+	}
+
+	limit, err := queryInt(r, "limit", defaultLeaderboardLimit)
+	if err != nil || limit < 1 || limit > maxLeaderboardLimit {
+		respondWithError(w, http.StatusBadRequest, "Limit must be between 1 and 500")
+		return
+	}
+
+	offset, err := queryInt(r, "offset", 0)
+	if err != nil || offset < 0 {
+		respondWithError(w, http.StatusBadRequest, "Offset cannot be negative")
+		return
+	}
+
+	tournament, err := h.repo.GetTournamentByID(r.Context(), uint(tournamentID))
+	if err != nil {
+		if errors.Is(err, repository.ErrTournamentNotFound) {
+			respondWithError(w, http.StatusNotFound, "Tournament not found")
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, "Failed to get tournament: "+err.Error())
+		return
+	}
+
+	var page []models.LeaderboardEntry
+	var total int
+	if playerParam := r.URL.Query().Get("player_id"); playerParam != "" {
+		playerID, err := strconv.ParseUint(playerParam, 10, 32)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid player ID")
+			return
+		}
+
+		var ok bool
+		page, offset, total, ok = h.rankings.LeaderboardAround(tournament.ID, tournament.PrizePool, uint(playerID), limit)
+		if !ok {
+			respondWithError(w, http.StatusNotFound, "Player has no bets in this tournament")
+			return
+		}
+	} else {
+		page, total = h.rankings.Leaderboard(tournament.ID, tournament.PrizePool, offset, limit)
+	}
+
+	response := dtos.LeaderboardResponse{
+		TournamentID:      tournament.ID,
+		PrizePool:         tournament.PrizePool,
+		TotalParticipants: total,
+		Offset:            offset,
+		Limit:             limit,
+		Entries:           make([]dtos.LeaderboardEntryResponse, 0, len(page)),
+	}
+	for _, e := range page {
+		response.Entries = append(response.Entries, dtos.LeaderboardEntryResponse{
+			Position:       e.Position,
+			Placement:      e.Placement,
+			PlayerID:       e.PlayerID,
+			PlayerName:     e.PlayerName,
+			TotalBet:       e.TotalBet,
+			TieGroupSize:   e.TieGroupSize,
+			ProjectedPrize: e.ProjectedPrize,
+			GapToNext:      e.GapToNext,
+		})
+	}
+
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// queryInt parses the named query parameter, returning def when it is absent.
+func queryInt(r *http.Request, name string, def int) (int, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return def, nil
+	}
+	return strconv.Atoi(v)
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"igaming/internal/handlers/dtos"
+	"igaming/internal/models"
+	"igaming/internal/repository"
+	"igaming/internal/service"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type PlayerExclusionHandler struct {
+	exclusions *service.ExclusionService
+}
+
+func NewPlayerExclusionHandler(exclusions *service.ExclusionService) *PlayerExclusionHandler {
+	return &PlayerExclusionHandler{exclusions: exclusions}
+}
+
+// CreateExclusion godoc
+// @Summary Self-exclude a player
+// @Description Exclude a player from betting for 24 hours, 7 days, 6 months or permanently. Exclusions cannot be lifted early.
+// @Tags players
+// @Accept json
+// @Produce json
+// @Param id path int true "Player ID"
+// @Param request body dtos.CreatePlayerExclusionRequest true "Exclusion duration"
+// @Success 201 {object} dtos.PlayerExclusionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /players/{id}/exclusions [post]
+func (h *PlayerExclusionHandler) CreateExclusion(w http.ResponseWriter, r *http.Request) {
+	playerID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid player ID")
+		return
+	}
+
+	var req dtos.CreatePlayerExclusionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request format: "+err.Error())
+		return
+	}
+
+	exclusion := models.PlayerExclusion{
+		PlayerID: uint(playerID),
+		Duration: req.Duration,
+	}
+
+	if err := h.exclusions.Create(r.Context(), &exclusion); err != nil {
+		var invalid *service.ValidationError
+		if errors.As(err, &invalid) {
+			respondWithError(w, http.StatusBadRequest, invalid.Message)
+			return
+		}
+		if errors.Is(err, repository.ErrPlayerNotFound) {
+			respondWithError(w, http.StatusNotFound, "Player not found")
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, "Failed to create exclusion: "+err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, h.toResponse(exclusion))
+}
+
+// GetPlayerExclusions godoc
+// @Summary Get player exclusions
+// @Description Get every self-exclusion of a player, newest first
+// @Tags players
+// @Accept json
+// @Produce json
+// @Param id path int true "Player ID"
+// @Success 200 {array} dtos.PlayerExclusionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /players/{id}/exclusions [get]
+func (h *PlayerExclusionHandler) GetPlayerExclusions(w http.ResponseWriter, r *http.Request) {
+	playerID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid player ID")
+		return
+	}
+
+	exclusions, err := h.exclusions.ListByPlayer(r.Context(), uint(playerID))
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to retrieve exclusions: "+err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, h.toResponses(exclusions))
+}
+
+// GetActiveExclusions godoc
+// @Summary Get active exclusions
+// @Description Admin view of every self-exclusion currently in force
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {array} dtos.PlayerExclusionResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/exclusions [get]
+func (h *PlayerExclusionHandler) GetActiveExclusions(w http.ResponseWriter, r *http.Request) {
+	exclusions, err := h.exclusions.ListActive(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to retrieve exclusions: "+err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, h.toResponses(exclusions))
+}
+
+func (h *PlayerExclusionHandler) toResponses(exclusions []models.PlayerExclusion) []dtos.PlayerExclusionResponse {
+	response := make([]dtos.PlayerExclusionResponse, 0, len(exclusions))
+	for _, e := range exclusions {
+		response = append(response, h.toResponse(e))
+	}
+	return response
+}
+
+func (h *PlayerExclusionHandler) toResponse(e models.PlayerExclusion) dtos.PlayerExclusionResponse {
+	return dtos.PlayerExclusionResponse{
+		ID:       e.ID,
+		PlayerID: e.PlayerID,
+		Duration: e.Duration,
+		StartsAt: e.StartsAt,
+		EndsAt:   e.EndsAt,
+		Active:   h.exclusions.Active(e),
+	}
+}
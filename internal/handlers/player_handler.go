@@ -2,18 +2,21 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"igaming/internal/handlers/dtos"
+	"igaming/internal/logging"
 	"igaming/internal/models"
 	"igaming/internal/repository"
+	"igaming/internal/service"
 	"net/http"
 )
 
 type PlayerHandler struct {
-	repo *repository.PlayerRepository
+	players *service.PlayerService
 }
 
-func NewPlayerHandler(repo *repository.PlayerRepository) *PlayerHandler {
-	return &PlayerHandler{repo: repo}
+func NewPlayerHandler(players *service.PlayerService) *PlayerHandler {
+	return &PlayerHandler{players: players}
 }
 
 // CreatePlayer godoc
@@ -25,6 +28,7 @@ func NewPlayerHandler(repo *repository.PlayerRepository) *PlayerHandler {
 // @Param request body dtos.CreatePlayerRequest true "Player registration data"
 // @Success 201 {object} dtos.PlayerResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /players [post]
 func (h *PlayerHandler) CreatePlayer(w http.ResponseWriter, r *http.Request) {
@@ -41,10 +45,21 @@ func (h *PlayerHandler) CreatePlayer(w http.ResponseWriter, r *http.Request) {
 		AccountBalance: req.AccountBalance,
 	}
 
-	if err := h.repo.Create(r.Context(), &player); err != nil {
+	if err := h.players.Create(r.Context(), &player); err != nil {
+		var invalid *service.ValidationError
+		if errors.As(err, &invalid) {
+			respondWithError(w, http.StatusBadRequest, invalid.Message)
+			return
+		}
+		if errors.Is(err, repository.ErrEmailTaken) {
+			respondWithError(w, http.StatusConflict, "Email is already registered")
+			return
+		}
+		logging.FromContext(r.Context()).Error("failed to create player", "error", err)
 		respondWithError(w, http.StatusInternalServerError, "Failed to create player: "+err.Error())
 		return
 	}
+	logging.SetPlayerID(r.Context(), player.ID)
 
 	respondWithJSON(w, http.StatusCreated, dtos.PlayerResponse{
 		ID:            player.ID,
@@ -66,7 +81,7 @@ func (h *PlayerHandler) CreatePlayer(w http.ResponseWriter, r *http.Request) {
 // @Failure 500 {object} ErrorResponse
 // @Router /players [get]
 func (h *PlayerHandler) GetPlayers(w http.ResponseWriter, r *http.Request) {
-	players, err := h.repo.GetAllPlayers(r.Context())
+	players, err := h.players.List(r.Context())
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Failed to retrieve players: "+err.Error())
 		return
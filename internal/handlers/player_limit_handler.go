@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"igaming/internal/handlers/dtos"
+	"igaming/internal/models"
+	"igaming/internal/repository"
+	"igaming/internal/service"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type PlayerLimitHandler struct {
+	limits *service.LimitService
+}
+
+func NewPlayerLimitHandler(limits *service.LimitService) *PlayerLimitHandler {
+	return &PlayerLimitHandler{limits: limits}
+}
+
+// GetLimits godoc
+// @Summary Get player limits
+// @Description Get a player's responsible gambling limits with their usage in the current period
+// @Tags players
+// @Accept json
+// @Produce json
+// @Param id path int true "Player ID"
+// @Success 200 {array} dtos.PlayerLimitResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /players/{id}/limits [get]
+func (h *PlayerLimitHandler) GetLimits(w http.ResponseWriter, r *http.Request) {
+	playerID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid player ID")
+		return
+	}
+
+	limits, err := h.limits.Get(r.Context(), uint(playerID))
+	if err != nil {
+		if errors.Is(err, repository.ErrPlayerNotFound) {
+			respondWithError(w, http.StatusNotFound, "Player not found")
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, "Failed to get limits: "+err.Error())
+		return
+	}
+
+	respondWithLimits(w, limits)
+}
+
+// SetLimits godoc
+// @Summary Set player limits
+// @Description Set or remove responsible gambling limits. Lower limits apply immediately, higher or removed limits only after a cooling-off period.
+// @Tags players
+// @Accept json
+// @Produce json
+// @Param id path int true "Player ID"
+// @Param request body dtos.SetPlayerLimitsRequest true "Limit changes"
+// @Success 200 {array} dtos.PlayerLimitResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /players/{id}/limits [put]
+func (h *PlayerLimitHandler) SetLimits(w http.ResponseWriter, r *http.Request) {
+	playerID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid player ID")
+		return
+	}
+
+	var req dtos.SetPlayerLimitsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request format: "+err.Error())
+		return
+	}
+
+	changes := make([]models.PlayerLimit, 0, len(req.Limits))
+	for _, l := range req.Limits {
+		changes = append(changes, models.PlayerLimit{
+			PlayerID: uint(playerID),
+			Type:     l.Type,
+			Period:   l.Period,
+			Amount:   l.Amount,
+		})
+	}
+
+	limits, err := h.limits.Set(r.Context(), uint(playerID), changes)
+	if err != nil {
+		var invalid *service.ValidationError
+		if errors.As(err, &invalid) {
+			respondWithError(w, http.StatusBadRequest, invalid.Message)
+			return
+		}
+		if errors.Is(err, repository.ErrPlayerNotFound) {
+			respondWithError(w, http.StatusNotFound, "Player not found")
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, "Failed to set limits: "+err.Error())
+		return
+	}
+
+	respondWithLimits(w, limits)
+}
+
+func respondWithLimits(w http.ResponseWriter, limits []models.PlayerLimit) {
+	response := make([]dtos.PlayerLimitResponse, 0, len(limits))
+	for _, l := range limits {
+		var remaining *float64
+		if l.Amount != nil {
+			left := max(*l.Amount-l.Used, 0)
+			remaining = &left
+		}
+
+		response = append(response, dtos.PlayerLimitResponse{
+			Type:               l.Type,
+			Period:             l.Period,
+			Amount:             l.Amount,
+			PendingAmount:      l.PendingAmount,
+			PendingEffectiveAt: l.PendingEffectiveAt,
+			Used:               l.Used,
+			Remaining:          remaining,
+		})
+	}
+
+	respondWithJSON(w, http.StatusOK, response)
+}
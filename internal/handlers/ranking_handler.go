@@ -1,33 +1,212 @@
 package handlers
 
 import (
+	"errors"
+	"igaming/internal/clock"
+	"igaming/internal/ranking"
 	"igaming/internal/repository"
 	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	defaultClimbersLimit = 10
+	maxClimbersLimit     = 100
+	defaultHistoryDays   = 30
+	maxHistoryDays       = 366
 )
 
 type RankingHandler struct {
-    repo *repository.PlayerRepository
+	rankings  *ranking.Service
+	snapshots repository.RankingSnapshotRepository
+	clock     clock.Clock
 }
 
-func NewRankingHandler(repo *repository.PlayerRepository) *RankingHandler {
-    return &RankingHandler{repo: repo}
+func NewRankingHandler(rankings *ranking.Service, snapshots repository.RankingSnapshotRepository, clk clock.Clock) *RankingHandler {
+	return &RankingHandler{rankings: rankings, snapshots: snapshots, clock: clk}
 }
 
 // GetPlayerRankings godoc
 // @Summary Get player rankings
-// @Description Get ranked list of players by account balance
+// @Description Get ranked list of players by account balance, served from the in-memory ranking. Without limit every ranked player is returned; the X-Total-Count header has the number of ranked players. rank_change_day and rank_change_week are the places moved up since the snapshot a day and a week ago.
 // @Tags rankings
 // @Accept  json
 // @Produce  json
+// @Param limit query int false "Number of players to return (top-N)"
+// @Param offset query int false "Offset of the first player"
 // @Success 200 {array} models.PlayerRanking
-// @Failure 500 {object} ErrorResponse
+// @Failure 400 {object} ErrorResponse
 // @Router /rankings [get]
 func (h *RankingHandler) GetPlayerRankings(w http.ResponseWriter, r *http.Request) {
-    rankings, err := h.repo.GetRankings(r.Context())
-    if err != nil {
-        respondWithError(w, http.StatusInternalServerError, "Failed to get rankings")
-        return
-    }
-
-    respondWithJSON(w, http.StatusOK, rankings)
-}
\ No newline at end of file
+	limit, err := queryInt(r, "limit", 0)
+	if err != nil || limit < 0 {
+		respondWithError(w, http.StatusBadRequest, "Limit cannot be negative")
+		return
+	}
+
+	offset, err := queryInt(r, "offset", 0)
+	if err != nil || offset < 0 {
+		respondWithError(w, http.StatusBadRequest, "Offset cannot be negative")
+		return
+	}
+
+	rankings, total := h.rankings.Rankings(offset, limit)
+
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	respondWithJSON(w, http.StatusOK, rankings)
+}
+
+// GetPlayerRank godoc
+// @Summary Get a player's rank
+// @Description Get the player's position in the global balance ranking
+// @Tags rankings
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Player ID"
+// @Success 200 {object} models.PlayerRanking
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /players/{id}/rank [get]
+func (h *RankingHandler) GetPlayerRank(w http.ResponseWriter, r *http.Request) {
+	playerID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid player ID")
+		return
+	}
+
+	rank, ok := h.rankings.PlayerRank(uint(playerID))
+	if !ok {
+		respondWithError(w, http.StatusNotFound, "Player is not ranked")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, rank)
+}
+
+// GetClimbers godoc
+// @Summary Get biggest climbers
+// @Description Ranked players who moved up the most places since yesterday or last week, biggest climb first
+// @Tags rankings
+// @Accept  json
+// @Produce  json
+// @Param period query string false "Period to compare against (default day)" Enums(day, week)
+// @Param limit query int false "Number of players (default 10, max 100)"
+// @Success 200 {array} models.PlayerRanking
+// @Failure 400 {object} ErrorResponse
+// @Router /rankings/climbers [get]
+func (h *RankingHandler) GetClimbers(w http.ResponseWriter, r *http.Request) {
+	period := r.URL.Query().Get("period")
+	if period == "" {
+		period = ranking.PeriodDay
+	}
+	if period != ranking.PeriodDay && period != ranking.PeriodWeek {
+		respondWithError(w, http.StatusBadRequest, "Period must be day or week")
+		return
+	}
+
+	limit, err := queryInt(r, "limit", defaultClimbersLimit)
+	if err != nil || limit < 1 || limit > maxClimbersLimit {
+		respondWithError(w, http.StatusBadRequest, "Limit must be between 1 and 100")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, h.rankings.Climbers(period, limit))
+}
+
+// GetRankHistory godoc
+// @Summary Get a player's rank history
+// @Description The player's rank and balance in every ranking snapshot between from and to (default the last 30 days, at most 366 days). Dates are RFC 3339 timestamps or YYYY-MM-DD; a date-only to includes the whole day.
+// @Tags rankings
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Player ID"
+// @Param from query string false "Start of the range"
+// @Param to query string false "End of the range"
+// @Success 200 {array} models.RankingSnapshot
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /players/{id}/rankings/history [get]
+func (h *RankingHandler) GetRankHistory(w http.ResponseWriter, r *http.Request) {
+	playerID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid player ID")
+		return
+	}
+
+	to := h.clock.Now()
+	if v := r.URL.Query().Get("to"); v != "" {
+		if to, err = parseDate(v, true); err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid to date")
+			return
+		}
+	}
+
+	from := to.AddDate(0, 0, -defaultHistoryDays)
+	if v := r.URL.Query().Get("from"); v != "" {
+		if from, err = parseDate(v, false); err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid from date")
+			return
+		}
+	}
+
+	if from.After(to) {
+		respondWithError(w, http.StatusBadRequest, "from must not be after to")
+		return
+	}
+	if to.Sub(from) > maxHistoryDays*24*time.Hour {
+		respondWithError(w, http.StatusBadRequest, "Date range cannot exceed 366 days")
+		return
+	}
+
+	history, err := h.snapshots.GetHistory(r.Context(), uint(playerID), from, to)
+	if err != nil {
+		if errors.Is(err, repository.ErrPlayerNotFound) {
+			respondWithError(w, http.StatusNotFound, "Player not found")
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, "Failed to get rank history: "+err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, history)
+}
+
+// parseDate accepts an RFC 3339 timestamp or a YYYY-MM-DD date (UTC). With
+// endOfDay a plain date means the last moment of that day.
+func parseDate(v string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t.UTC(), nil
+	}
+
+	t, err := time.Parse(time.DateOnly, v)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Second)
+	}
+	return t, nil
+}
+
+// CheckConsistency godoc
+// @Summary Check ranking consistency
+// @Description Compare the in-memory rankings and tournament leaderboards with the player_rankings view and the bets in the database
+// @Tags admin
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} ranking.Report
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/rankings/consistency [get]
+func (h *RankingHandler) CheckConsistency(w http.ResponseWriter, r *http.Request) {
+	report, err := h.rankings.Verify(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to check rankings: "+err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, report)
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"errors"
+	"igaming/internal/handlers/dtos"
+	"igaming/internal/repository"
+	"igaming/internal/service"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	defaultRatingsLimit = 50
+	maxRatingsLimit     = 500
+)
+
+type RatingHandler struct {
+	ratings *service.RatingService
+}
+
+func NewRatingHandler(ratings *service.RatingService) *RatingHandler {
+	return &RatingHandler{ratings: ratings}
+}
+
+// GetPlayerRating godoc
+// @Summary Get a player's skill rating
+// @Description The player's Elo rating from their tournament placements, with their 20 most recent rating changes. Players who have not played a settled tournament have the initial rating of 1500.
+// @Tags ratings
+// @Accept json
+// @Produce json
+// @Param id path int true "Player ID"
+// @Success 200 {object} dtos.PlayerRatingResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /players/{id}/rating [get]
+func (h *RatingHandler) GetPlayerRating(w http.ResponseWriter, r *http.Request) {
+	playerID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid player ID")
+		return
+	}
+
+	rating, history, err := h.ratings.Get(r.Context(), uint(playerID))
+	if err != nil {
+		if errors.Is(err, repository.ErrPlayerNotFound) {
+			respondWithError(w, http.StatusNotFound, "Player not found")
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, "Failed to get rating: "+err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, dtos.PlayerRatingResponse{PlayerRating: *rating, History: history})
+}
+
+// GetRatings godoc
+// @Summary Get the rating leaderboard
+// @Description Rated players ordered by skill rating. The X-Total-Count header has the number of ranked players.
+// @Tags ratings
+// @Accept json
+// @Produce json
+// @Param limit query int false "Page size (default 50, max 500)"
+// @Param offset query int false "Offset of the first player"
+// @Success 200 {array} models.PlayerRating
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /ratings [get]
+func (h *RatingHandler) GetRatings(w http.ResponseWriter, r *http.Request) {
+	limit, err := queryInt(r, "limit", defaultRatingsLimit)
+	if err != nil || limit < 1 || limit > maxRatingsLimit {
+		respondWithError(w, http.StatusBadRequest, "Limit must be between 1 and 500")
+		return
+	}
+
+	offset, err := queryInt(r, "offset", 0)
+	if err != nil || offset < 0 {
+		respondWithError(w, http.StatusBadRequest, "Offset cannot be negative")
+		return
+	}
+
+	ratings, total, err := h.ratings.Leaderboard(r.Context(), offset, limit)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to get ratings: "+err.Error())
+		return
+	}
+
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	respondWithJSON(w, http.StatusOK, ratings)
+}
+
+// RecomputeRatings godoc
+// @Summary Recompute skill ratings
+// @Description Discards all ratings and rating history and rates every settled tournament again, in settlement order
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.RecomputeRatingsResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/ratings/recompute [post]
+func (h *RatingHandler) RecomputeRatings(w http.ResponseWriter, r *http.Request) {
+	tournaments, players, err := h.ratings.Recompute(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to recompute ratings: "+err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, dtos.RecomputeRatingsResponse{Tournaments: tournaments, Players: players})
+}
@@ -0,0 +1,296 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"igaming/internal/handlers/dtos"
+	"igaming/internal/logging"
+	"igaming/internal/models"
+	"igaming/internal/repository"
+	"igaming/internal/service"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type SeasonHandler struct {
+	seasons    *service.SeasonService
+	settlement *service.SettlementService
+}
+
+func NewSeasonHandler(seasons *service.SeasonService, settlement *service.SettlementService) *SeasonHandler {
+	return &SeasonHandler{seasons: seasons, settlement: settlement}
+}
+
+// CreateSeason godoc
+// @Summary Create a season
+// @Description Creates a season with its prize pool and points table. Tournaments join a season through their season_id.
+// @Tags seasons
+// @Accept json
+// @Produce json
+// @Param request body dtos.CreateSeasonRequest true "Season"
+// @Success 201 {object} dtos.SeasonResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /seasons [post]
+func (h *SeasonHandler) CreateSeason(w http.ResponseWriter, r *http.Request) {
+	var req dtos.CreateSeasonRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request format: "+err.Error())
+		return
+	}
+
+	season := models.Season{
+		Name:        req.Name,
+		StartDate:   req.StartDate,
+		EndDate:     req.EndDate,
+		PrizePool:   req.PrizePool,
+		PointsTable: pointsTable(req.PointsTable),
+	}
+
+	if err := h.seasons.Create(r.Context(), &season); err != nil {
+		var invalid *service.ValidationError
+		if errors.As(err, &invalid) {
+			respondWithError(w, http.StatusBadRequest, invalid.Message)
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, "Failed to create season: "+err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, seasonResponse(&season))
+}
+
+// GetSeasons godoc
+// @Summary List seasons
+// @Description Get all seasons, latest first
+// @Tags seasons
+// @Accept json
+// @Produce json
+// @Success 200 {array} dtos.SeasonResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /seasons [get]
+func (h *SeasonHandler) GetSeasons(w http.ResponseWriter, r *http.Request) {
+	seasons, err := h.seasons.List(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to get seasons: "+err.Error())
+		return
+	}
+
+	response := make([]dtos.SeasonResponse, 0, len(seasons))
+	for i := range seasons {
+		response = append(response, seasonResponse(&seasons[i]))
+	}
+
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// GetSeason godoc
+// @Summary Get a season
+// @Description Get a season with its points table
+// @Tags seasons
+// @Accept json
+// @Produce json
+// @Param id path int true "Season ID"
+// @Success 200 {object} dtos.SeasonResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /seasons/{id} [get]
+func (h *SeasonHandler) GetSeason(w http.ResponseWriter, r *http.Request) {
+	seasonID, ok := parseSeasonID(w, r)
+	if !ok {
+		return
+	}
+
+	season, err := h.seasons.Get(r.Context(), seasonID)
+	if err != nil {
+		respondWithSeasonError(w, err, "Failed to get season")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, seasonResponse(season))
+}
+
+// SetPoints godoc
+// @Summary Set a season's points table
+// @Description Replaces the points awarded per tournament placement. Standings are recomputed from the table, including tournaments already played. Not allowed once the season prizes have been paid.
+// @Tags seasons
+// @Accept json
+// @Produce json
+// @Param id path int true "Season ID"
+// @Param request body dtos.SetSeasonPointsRequest true "Points table"
+// @Success 200 {object} dtos.SeasonResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /seasons/{id}/points [put]
+func (h *SeasonHandler) SetPoints(w http.ResponseWriter, r *http.Request) {
+	seasonID, ok := parseSeasonID(w, r)
+	if !ok {
+		return
+	}
+
+	var req dtos.SetSeasonPointsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request format: "+err.Error())
+		return
+	}
+
+	season, err := h.seasons.SetPoints(r.Context(), seasonID, pointsTable(req.PointsTable))
+	if err != nil {
+		respondWithSeasonError(w, err, "Failed to set points table")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, seasonResponse(season))
+}
+
+// GetStandings godoc
+// @Summary Get season standings
+// @Description Players ranked by season points from their tournament placements. Ties are broken by most wins, then by who reached their total first. Each entry shows the season prize for its placement.
+// @Tags seasons
+// @Accept json
+// @Produce json
+// @Param id path int true "Season ID"
+// @Success 200 {object} dtos.SeasonStandingsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /seasons/{id}/standings [get]
+func (h *SeasonHandler) GetStandings(w http.ResponseWriter, r *http.Request) {
+	seasonID, ok := parseSeasonID(w, r)
+	if !ok {
+		return
+	}
+
+	standings, err := h.seasons.Standings(r.Context(), seasonID)
+	if err != nil {
+		respondWithSeasonError(w, err, "Failed to get standings")
+		return
+	}
+
+	season := standings.Season
+	response := dtos.SeasonStandingsResponse{
+		SeasonID:          season.ID,
+		PrizePool:         season.PrizePool,
+		PrizesDistributed: season.PrizesDistributed,
+		Standings:         make([]dtos.SeasonStandingResponse, 0, len(standings.Standings)),
+	}
+	for _, s := range standings.Standings {
+		response.Standings = append(response.Standings, dtos.SeasonStandingResponse{
+			Placement:         s.Placement,
+			PlayerID:          s.PlayerID,
+			PlayerName:        s.PlayerName,
+			Points:            s.Points,
+			Wins:              s.Wins,
+			TournamentsPlaced: s.TournamentsPlaced,
+			AchievedAt:        s.AchievedAt,
+			Prize:             standings.Prizes[s.PlayerID],
+		})
+	}
+
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// DistributePrizes godoc
+// @Summary Distribute season prizes
+// @Description Pays out the season prize pool to the top three placements with the same 50/30/20 split and tie sharing as tournament prizes. Only possible once the season has ended.
+// @Tags seasons
+// @Accept json
+// @Produce json
+// @Param id path int true "Season ID"
+// @Success 202 {object} map[string]interface{} "message: Prizes distributed successfully"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /seasons/{id}/prizes [post]
+func (h *SeasonHandler) DistributePrizes(w http.ResponseWriter, r *http.Request) {
+	seasonID, ok := parseSeasonID(w, r)
+	if !ok {
+		return
+	}
+
+	if _, err := h.settlement.SettleSeason(r.Context(), seasonID); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrSeasonNotFound):
+			respondWithError(w, http.StatusNotFound, "Season not found")
+		case errors.Is(err, repository.ErrPrizesAlreadyDistributed):
+			respondWithError(w, http.StatusConflict, "Season prizes have already been distributed")
+		case errors.Is(err, repository.ErrSeasonNotEnded):
+			respondWithError(w, http.StatusConflict, "Season has not ended yet")
+		case errors.Is(err, repository.ErrNoSeasonPoints):
+			respondWithError(w, http.StatusBadRequest, "No player has scored points in this season")
+		default:
+			logging.FromContext(r.Context()).Error("season prize distribution failed", "season_id", seasonID, "error", err)
+			respondWithError(w, http.StatusInternalServerError, "Prize distribution failed")
+		}
+		return
+	}
+
+	respondWithJSON(w, http.StatusAccepted, map[string]interface{}{
+		"message":   "Prizes distributed successfully",
+		"season_id": seasonID,
+	})
+}
+
+// parseSeasonID parses the id URL parameter, writing the error response
+// when it is not a season ID.
+func parseSeasonID(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid season ID")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// respondWithSeasonError writes the response for an error of the season
+// service, prefixing unexpected errors with message.
+func respondWithSeasonError(w http.ResponseWriter, err error, message string) {
+	var invalid *service.ValidationError
+	switch {
+	case errors.As(err, &invalid):
+		respondWithError(w, http.StatusBadRequest, invalid.Message)
+	case errors.Is(err, repository.ErrSeasonNotFound):
+		respondWithError(w, http.StatusNotFound, "Season not found")
+	case errors.Is(err, repository.ErrPrizesAlreadyDistributed):
+		respondWithError(w, http.StatusConflict, "Season prizes have already been distributed")
+	default:
+		respondWithError(w, http.StatusInternalServerError, message+": "+err.Error())
+	}
+}
+
+// pointsTable converts a requested points table, keeping nil for a request
+// without one.
+func pointsTable(req []dtos.SeasonPointsRequest) []models.SeasonPoints {
+	if req == nil {
+		return nil
+	}
+	points := make([]models.SeasonPoints, 0, len(req))
+	for _, p := range req {
+		points = append(points, models.SeasonPoints{Placement: p.Placement, Points: p.Points})
+	}
+	return points
+}
+
+func seasonResponse(s *models.Season) dtos.SeasonResponse {
+	response := dtos.SeasonResponse{
+		ID:                s.ID,
+		Name:              s.Name,
+		StartDate:         s.StartDate,
+		EndDate:           s.EndDate,
+		PrizePool:         s.PrizePool,
+		PrizesDistributed: s.PrizesDistributed,
+	}
+	for _, p := range s.PointsTable {
+		response.PointsTable = append(response.PointsTable, dtos.SeasonPointsRequest{
+			Placement: p.Placement,
+			Points:    p.Points,
+		})
+	}
+	return response
+}
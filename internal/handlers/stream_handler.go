@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"igaming/internal/events"
+	"igaming/internal/repository"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	streamHeartbeatInterval = 15 * time.Second
+	streamRetryInterval     = 3 * time.Second
+)
+
+type StreamHandler struct {
+	hub  *events.Hub
+	repo repository.TournamentRepository
+}
+
+func NewStreamHandler(hub *events.Hub, repo repository.TournamentRepository) *StreamHandler {
+	return &StreamHandler{hub: hub, repo: repo}
+}
+
+// StreamTournament godoc
+// @Summary Stream tournament updates
+// @Description Server-sent events stream of bets, leaderboard deltas and settlement of a tournament. Send a WebSocket upgrade request to receive the same events as JSON messages. Resume with the Last-Event-ID header or the last_event_id parameter.
+// @Tags tournaments
+// @Produce text/event-stream
+// @Param id path int true "Tournament ID"
+// @Param Last-Event-ID header int false "ID of the last event received"
+// @Param last_event_id query int false "ID of the last event received"
+// @Success 200 {object} events.Event
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /tournaments/{id}/stream [get]
+func (h *StreamHandler) StreamTournament(w http.ResponseWriter, r *http.Request) {
+	tournamentID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid tournament ID")
+		return
+	}
+
+	lastEventID, err := lastEventID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid last event ID")
+		return
+	}
+
+	exists, err := h.repo.Exists(r.Context(), uint(tournamentID))
+	if err != nil || !exists {
+		respondWithError(w, http.StatusNotFound, "Tournament not found")
+		return
+	}
+
+	sub, replay, complete, err := h.hub.Subscribe(events.TournamentTopic(uint(tournamentID)), lastEventID)
+	if err != nil {
+		respondWithError(w, http.StatusServiceUnavailable, "Event stream unavailable")
+		return
+	}
+	defer sub.Unsubscribe()
+
+	if !complete {
+		replay = append([]events.Event{{Type: events.TypeResync, Time: time.Now().UTC()}}, replay...)
+	}
+
+	// Streams outlive the server's read and write timeouts; the heartbeat
+	// detects dead clients instead. This has to happen before a WebSocket
+	// upgrade takes over the connection with the deadlines still set.
+	rc := http.NewResponseController(w)
+	rc.SetReadDeadline(time.Time{})
+	rc.SetWriteDeadline(time.Time{})
+
+	if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		h.serveWebSocket(w, r, sub, replay)
+		return
+	}
+	h.serveSSE(w, r, sub, replay)
+}
+
+func (h *StreamHandler) serveSSE(w http.ResponseWriter, r *http.Request, sub *events.Subscription, replay []events.Event) {
+	rc := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprintf(w, "retry: %d\n\n", streamRetryInterval.Milliseconds())
+	for _, e := range replay {
+		if err := writeSSE(w, e); err != nil {
+			return
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+
+		case e, ok := <-sub.Events():
+			if !ok {
+				// Dropped by the hub: tell the client why; it reconnects
+				// with Last-Event-ID and resumes from the history.
+				writeSSE(w, closeEvent(sub))
+				rc.Flush()
+				return
+			}
+			if err := writeSSE(w, e); err != nil {
+				return
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func (h *StreamHandler) serveWebSocket(w http.ResponseWriter, r *http.Request, sub *events.Subscription, replay []events.Event) {
+	websocket.Server{Handler: func(ws *websocket.Conn) {
+		defer ws.Close()
+
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+
+		// The stream is one-way; reading only tells us when the client goes away.
+		go func() {
+			defer cancel()
+			var discard []byte
+			for websocket.Message.Receive(ws, &discard) == nil {
+			}
+		}()
+
+		for _, e := range replay {
+			if err := websocket.JSON.Send(ws, e); err != nil {
+				return
+			}
+		}
+
+		heartbeat := time.NewTicker(streamHeartbeatInterval)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-heartbeat.C:
+				if err := websocket.JSON.Send(ws, events.Event{Type: "heartbeat", Time: time.Now().UTC()}); err != nil {
+					return
+				}
+
+			case e, ok := <-sub.Events():
+				if !ok {
+					websocket.JSON.Send(ws, closeEvent(sub))
+					return
+				}
+				if err := websocket.JSON.Send(ws, e); err != nil {
+					return
+				}
+			}
+		}
+	}}.ServeHTTP(w, r)
+}
+
+func writeSSE(w http.ResponseWriter, e events.Event) error {
+	data, err := json.Marshal(e.Data)
+	if err != nil {
+		return err
+	}
+
+	if e.ID > 0 {
+		if _, err := fmt.Fprintf(w, "id: %d\n", e.ID); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data)
+	return err
+}
+
+func closeEvent(sub *events.Subscription) events.Event {
+	reason := "stream closed"
+	if err := sub.Err(); err != nil {
+		reason = err.Error()
+	}
+	return events.Event{Type: "close", Time: time.Now().UTC(), Data: map[string]string{"reason": reason}}
+}
+
+// lastEventID reads the resume position from the Last-Event-ID header (set by
+// EventSource on reconnect) or the last_event_id query parameter.
+func lastEventID(r *http.Request) (uint64, error) {
+	v := r.Header.Get("Last-Event-ID")
+	if v == "" {
+		v = r.URL.Query().Get("last_event_id")
+	}
+	if v == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(v, 10, 64)
+}
@@ -2,18 +2,42 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"igaming/internal/handlers/dtos"
+	"igaming/internal/logging"
 	"igaming/internal/models"
 	"igaming/internal/repository"
+	"igaming/internal/service"
 	"net/http"
 )
 
+// betErrors maps the rule violations reported by the betting service to the
+// HTTP status and error code returned to the client.
+var betErrors = []struct {
+	err    error
+	status int
+	code   string
+}{
+	{repository.ErrPlayerNotFound, http.StatusNotFound, "PLAYER_NOT_FOUND"},
+	{repository.ErrTournamentNotFound, http.StatusNotFound, "TOURNAMENT_NOT_FOUND"},
+	{repository.ErrInsufficientFunds, http.StatusBadRequest, "INSUFFICIENT_FUNDS"},
+	{repository.ErrBetBelowMinimum, http.StatusBadRequest, "BET_BELOW_MINIMUM"},
+	{repository.ErrBetAboveMaximum, http.StatusBadRequest, "BET_ABOVE_MAXIMUM"},
+	{repository.ErrStakeLimitExceeded, http.StatusBadRequest, "STAKE_LIMIT_EXCEEDED"},
+	{repository.ErrEntryFeeMismatch, http.StatusBadRequest, "ENTRY_FEE_MISMATCH"},
+	{repository.ErrAlreadyEntered, http.StatusConflict, "ALREADY_ENTERED"},
+	{repository.ErrParticipantLimitReached, http.StatusConflict, "PARTICIPANT_LIMIT_REACHED"},
+	{repository.ErrBettingWindowClosed, http.StatusConflict, "BETTING_WINDOW_CLOSED"},
+	{repository.ErrLimitExceeded, http.StatusForbidden, "RESPONSIBLE_GAMBLING_LIMIT"},
+	{repository.ErrPlayerExcluded, http.StatusForbidden, "PLAYER_SELF_EXCLUDED"},
+}
+
 type TournamentBetHandler struct {
-	repo *repository.TournamentBetRepository
+	betting *service.BettingService
 }
 
-func NewTournamentBetHandler(repo *repository.TournamentBetRepository) *TournamentBetHandler {
-	return &TournamentBetHandler{repo: repo}
+func NewTournamentBetHandler(betting *service.BettingService) *TournamentBetHandler {
+	return &TournamentBetHandler{betting: betting}
 }
 
 // CreateBet godoc
@@ -24,8 +48,10 @@ func NewTournamentBetHandler(repo *repository.TournamentBetRepository) *Tourname
 // @Produce json
 // @Param request body dtos.CreateTournamentBetRequest true "Bet details"
 // @Success 201 {object} dtos.TournamentBetResponse
-// @Failure 400 {object} ErrorResponse
-// @Failure 404 {object} ErrorResponse
+// @Failure 400 {object} DetailedErrorResponse
+// @Failure 403 {object} DetailedErrorResponse
+// @Failure 404 {object} DetailedErrorResponse
+// @Failure 409 {object} DetailedErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /bets [post]
 func (h *TournamentBetHandler) CreateBet(w http.ResponseWriter, r *http.Request) {
@@ -35,18 +61,23 @@ func (h *TournamentBetHandler) CreateBet(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	logging.SetPlayerID(r.Context(), req.PlayerID)
+
 	bet := models.TournamentBet{
 		PlayerID:     req.PlayerID,
 		TournamentID: req.TournamentID,
 		BetAmount:    req.BetAmount,
 	}
 
-	if err := h.repo.Create(r.Context(), &bet); err != nil {
-		status := http.StatusInternalServerError
-		if err.Error() == "player does not exist" || err.Error() == "tournament does not exist" {
-			status = http.StatusNotFound
+	if err := h.betting.PlaceBet(r.Context(), &bet); err != nil {
+		for _, e := range betErrors {
+			if errors.Is(err, e.err) {
+				respondWithDetailedError(w, e.status, e.code, err.Error())
+				return
+			}
 		}
-		respondWithError(w, status, err.Error())
+		logging.FromContext(r.Context()).Error("failed to place bet", "error", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to place bet: "+err.Error())
 		return
 	}
 
@@ -55,6 +86,7 @@ func (h *TournamentBetHandler) CreateBet(w http.ResponseWriter, r *http.Request)
 		PlayerID:     bet.PlayerID,
 		TournamentID: bet.TournamentID,
 		BetAmount:    bet.BetAmount,
+		RakeAmount:   bet.RakeAmount,
 		CreatedAt:    bet.CreatedAt,
 	})
 }
@@ -69,7 +101,7 @@ func (h *TournamentBetHandler) CreateBet(w http.ResponseWriter, r *http.Request)
 // @Failure 500 {object} ErrorResponse
 // @Router /bets [get]
 func (h *TournamentBetHandler) GetBets(w http.ResponseWriter, r *http.Request) {
-	bets, err := h.repo.GetAll(r.Context())
+	bets, err := h.betting.List(r.Context())
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Failed to retrieve bets: "+err.Error())
 		return
@@ -82,6 +114,7 @@ func (h *TournamentBetHandler) GetBets(w http.ResponseWriter, r *http.Request) {
 			PlayerID:     bet.PlayerID,
 			TournamentID: bet.TournamentID,
 			BetAmount:    bet.BetAmount,
+			RakeAmount:   bet.RakeAmount,
 			CreatedAt:    bet.CreatedAt,
 		})
 	}
@@ -5,22 +5,22 @@ import (
 	"errors"
 	"fmt"
 	"igaming/internal/handlers/dtos"
+	"igaming/internal/logging"
 	"igaming/internal/models"
 	"igaming/internal/repository"
-	"log"
+	"igaming/internal/service"
 	"net/http"
 	"strconv"
 	"strings"
-
-	"github.com/go-sql-driver/mysql"
 )
 
 type TournamentHandler struct {
-    repo *repository.TournamentRepository
+    tournaments *service.TournamentService
+    settlement  *service.SettlementService
 }
 
-func NewTournamentHandler(repo *repository.TournamentRepository) *TournamentHandler {
-    return &TournamentHandler{repo: repo}
+func NewTournamentHandler(tournaments *service.TournamentService, settlement *service.SettlementService) *TournamentHandler {
+    return &TournamentHandler{tournaments: tournaments, settlement: settlement}
 }
 
 // GetTournaments godoc
@@ -34,7 +34,7 @@ func NewTournamentHandler(repo *repository.TournamentRepository) *TournamentHand
 // @Router /tournaments [get]
 func (h *TournamentHandler) GetTournaments(w http.ResponseWriter, r *http.Request) {
 
-    tournaments, err := h.repo.GetAllTournaments(r.Context())
+    tournaments, err := h.tournaments.List(r.Context())
     if err != nil {
         http.Error(w, "Failed to get tournaments", http.StatusInternalServerError)
         return
@@ -64,41 +64,60 @@ func (h *TournamentHandler) CreateTournament(w http.ResponseWriter, r *http.Requ
         return
     }
 
-    if req.Name == "" {
-        respondWithError(w, http.StatusBadRequest, "Name is required")
-        return
-    }
-    
-    if req.PrizePool <= 0 {
-        respondWithError(w, http.StatusBadRequest, "Prize pool must be positive")
-        return
-    }
-    
-    if req.EndDate.Before(req.StartDate) {
-        respondWithError(w, http.StatusBadRequest, "End date must be after start date")
-        return
-    }
-
     tournament := models.Tournament{
-        Name:      req.Name,
-        PrizePool: req.PrizePool,
-        StartDate: req.StartDate,
-        EndDate:   req.EndDate,
+        Name:                    req.Name,
+        PrizePool:               req.PrizePool,
+        PoolMode:                req.PoolMode,
+        GuaranteedPrizePool:     req.GuaranteedPrizePool,
+        RakePercentage:          req.RakePercentage,
+        StartDate:               req.StartDate,
+        EndDate:                 req.EndDate,
+        MinBet:                  req.MinBet,
+        MaxBet:                  req.MaxBet,
+        MaxStakePerPlayer:       req.MaxStakePerPlayer,
+        MaxParticipants:         req.MaxParticipants,
+        EntryFee:                req.EntryFee,
+        BettingOpensAt:          req.BettingOpensAt,
+        BettingClosesAt:         req.BettingClosesAt,
+        LateRegistrationMinutes: req.LateRegistrationMinutes,
+        SeasonID:                req.SeasonID,
     }
 
-    if err := h.repo.Create(r.Context(), &tournament); err != nil {
-        log.Printf("Failed to create tournament: %v", err) // Add logging
+    if err := h.tournaments.Create(r.Context(), &tournament); err != nil {
+        var invalid *service.ValidationError
+        if errors.As(err, &invalid) {
+            respondWithError(w, http.StatusBadRequest, invalid.Message)
+            return
+        }
+        if errors.Is(err, repository.ErrSeasonNotFound) {
+            respondWithError(w, http.StatusBadRequest, "Season not found")
+            return
+        }
+        logging.FromContext(r.Context()).Error("failed to create tournament", "error", err)
         respondWithError(w, http.StatusInternalServerError, "Failed to create tournament: "+err.Error())
         return
     }
     
     response := dtos.TournamentResponse{
-        ID:        tournament.ID,
-        Name:      tournament.Name,
-        PrizePool: tournament.PrizePool,
-        StartDate: tournament.StartDate,
-        EndDate:   tournament.EndDate,
-        CreatedAt: tournament.CreatedAt,
+        ID:                      tournament.ID,
+        Name:                    tournament.Name,
+        PrizePool:               tournament.PrizePool,
+        PoolMode:                tournament.PoolMode,
+        GuaranteedPrizePool:     tournament.GuaranteedPrizePool,
+        RakePercentage:          tournament.RakePercentage,
+        RakeCollected:           tournament.RakeCollected,
+        StartDate:               tournament.StartDate,
+        EndDate:                 tournament.EndDate,
+        MinBet:                  tournament.MinBet,
+        MaxBet:                  tournament.MaxBet,
+        MaxStakePerPlayer:       tournament.MaxStakePerPlayer,
+        MaxParticipants:         tournament.MaxParticipants,
+        EntryFee:                tournament.EntryFee,
+        BettingOpensAt:          tournament.BettingOpensAt,
+        BettingClosesAt:         tournament.BettingClosesAt,
+        LateRegistrationMinutes: tournament.LateRegistrationMinutes,
+        SeasonID:                tournament.SeasonID,
+        CreatedAt:               tournament.CreatedAt,
     }
     
     respondWithJSON(w, http.StatusCreated, response)
@@ -111,6 +130,12 @@ func respondWithError(w http.ResponseWriter, code int, message string) {
     json.NewEncoder(w).Encode(ErrorResponse{Error: message})
 }
 
+func respondWithDetailedError(w http.ResponseWriter, status int, code string, message string) {
+    w.Header().Set("Content-Type", "application/json")
+    w.WriteHeader(status)
+    json.NewEncoder(w).Encode(DetailedErrorResponse{Error: message, Code: code})
+}
+
 func respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
     w.Header().Set("Content-Type", "application/json")
     w.WriteHeader(code)
@@ -136,8 +161,9 @@ func extractIDFromURL(r *http.Request) (string, error) {
 // @Success 202 {object} map[string]interface{} "message: Prizes distributed successfully"
 // @Failure 400 {object} handlers.ErrorResponse
 // @Failure 404 {object} handlers.ErrorResponse
+// @Failure 409 {object} handlers.ErrorResponse
 // @Failure 500 {object} handlers.ErrorResponse
-// @Router /tournaments/{id}/prizes [post]
+// @Router /tournaments/prizes/{id} [post]
 func (h *TournamentHandler) DistributePrizes(w http.ResponseWriter, r *http.Request) {
     idStr, err := extractIDFromURL(r)
     if err != nil {
@@ -150,29 +176,18 @@ func (h *TournamentHandler) DistributePrizes(w http.ResponseWriter, r *http.Requ
         return
     }
 
-    exists, err := h.repo.Exists(r.Context(), uint(tournamentID))
-    if err != nil || !exists {
-        respondWithError(w, http.StatusNotFound, "Tournament not found")
-        return
-    }
-
-    // Execute distribution
-    if err := h.repo.DistributePrizes(r.Context(), uint(tournamentID)); err != nil {
-        log.Printf("Prize distribution error: %v", err)
-        
-        var mysqlErr *mysql.MySQLError
-        if errors.As(err, &mysqlErr) {
-            switch mysqlErr.Number {
-            case 1329: // No data found
-                respondWithError(w, http.StatusBadRequest, "No eligible bets for tournament")
-                return
-            case 1365: // Division by zero
-                respondWithError(w, http.StatusConflict, "Cannot distribute prizes - invalid participant count")
-                return
-            }
+    if _, err := h.settlement.SettleTournament(r.Context(), uint(tournamentID)); err != nil {
+        switch {
+        case errors.Is(err, repository.ErrPrizesAlreadyDistributed):
+            respondWithError(w, http.StatusConflict, "Tournament prizes have already been distributed")
+        case errors.Is(err, repository.ErrNoBets):
+            respondWithError(w, http.StatusBadRequest, "No eligible bets for tournament")
+        case errors.Is(err, repository.ErrTournamentNotFound):
+            respondWithError(w, http.StatusNotFound, "Tournament not found")
+        default:
+            logging.FromContext(r.Context()).Error("prize distribution failed", "tournament_id", tournamentID, "error", err)
+            respondWithError(w, http.StatusInternalServerError, "Prize distribution failed")
         }
-
-        respondWithError(w, http.StatusInternalServerError, "Prize distribution failed")
         return
     }
 
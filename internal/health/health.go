@@ -0,0 +1,156 @@
+// Package health reports whether the service can serve traffic: the database
+// answers, its schema is at the version the code expects, and the background
+// jobs are running.
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"igaming/internal/dialect"
+	"igaming/internal/jobs"
+	"igaming/internal/migrations"
+	"time"
+)
+
+// DefaultTimeout bounds each readiness check.
+const DefaultTimeout = 2 * time.Second
+
+// Check and report statuses. A degraded check is reported but does not make
+// the service unready.
+const (
+	StatusOK       = "ok"
+	StatusDegraded = "degraded"
+	StatusFail     = "fail"
+)
+
+// Check is the outcome of a single check.
+type Check struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	DurationMs int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+	Details    any    `json:"details,omitempty"`
+}
+
+// Report is the outcome of all readiness checks.
+type Report struct {
+	Status    string    `json:"status"`
+	CheckedAt time.Time `json:"checked_at"`
+	Checks    []Check   `json:"checks"`
+}
+
+// Ready reports whether the report allows serving traffic.
+func (r Report) Ready() bool {
+	return r.Status != StatusFail
+}
+
+// JobStatuses reports the background jobs, normally a *jobs.Scheduler.
+type JobStatuses interface {
+	Status() []jobs.Status
+}
+
+type Checker struct {
+	db      *sql.DB
+	dialect dialect.Dialect
+	jobs    JobStatuses
+	timeout time.Duration
+}
+
+// NewChecker returns a checker for the service. db is nil when the service
+// runs without a database, which skips the database and migration checks;
+// otherwise d is its dialect.
+func NewChecker(db *sql.DB, d dialect.Dialect, jobs JobStatuses, timeout time.Duration) *Checker {
+	return &Checker{db: db, dialect: d, jobs: jobs, timeout: timeout}
+}
+
+// Ready runs every readiness check.
+func (c *Checker) Ready(ctx context.Context) Report {
+	report := Report{
+		Status:    StatusOK,
+		CheckedAt: time.Now().UTC(),
+	}
+	if c.db != nil {
+		report.Checks = append(report.Checks,
+			c.run(ctx, "database", c.checkDatabase),
+			c.run(ctx, "migrations", c.checkMigrations),
+		)
+	}
+	report.Checks = append(report.Checks, c.run(ctx, "jobs", c.checkJobs))
+
+	for _, check := range report.Checks {
+		switch {
+		case check.Status == StatusFail:
+			report.Status = StatusFail
+		case check.Status == StatusDegraded && report.Status == StatusOK:
+			report.Status = StatusDegraded
+		}
+	}
+	return report
+}
+
+func (c *Checker) run(ctx context.Context, name string, fn func(ctx context.Context) (string, any, error)) Check {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	started := time.Now()
+	status, details, err := fn(ctx)
+
+	check := Check{
+		Name:       name,
+		Status:     status,
+		DurationMs: time.Since(started).Milliseconds(),
+		Details:    details,
+	}
+	if err != nil {
+		check.Error = err.Error()
+	}
+	return check
+}
+
+func (c *Checker) checkDatabase(ctx context.Context) (string, any, error) {
+	if err := c.db.PingContext(ctx); err != nil {
+		return StatusFail, nil, err
+	}
+
+	stats := c.db.Stats()
+	return StatusOK, map[string]int{
+		"open_connections": stats.OpenConnections,
+		"in_use":           stats.InUse,
+		"idle":             stats.Idle,
+	}, nil
+}
+
+func (c *Checker) checkMigrations(ctx context.Context) (string, any, error) {
+	expected, err := migrations.Latest(c.dialect)
+	if err != nil {
+		return StatusFail, nil, err
+	}
+
+	current, err := migrations.Version(ctx, c.db)
+	if err != nil {
+		return StatusFail, nil, err
+	}
+
+	details := map[string]int64{"current": current, "expected": expected}
+	switch {
+	case current < expected:
+		return StatusFail, details, fmt.Errorf("database is at version %d, expected %d", current, expected)
+	case current > expected:
+		// A newer release has migrated the schema, e.g. during a rolling
+		// deploy. Migrations only add to the schema, so keep serving.
+		return StatusDegraded, details, fmt.Errorf("database is at version %d, newer than %d", current, expected)
+	}
+	return StatusOK, details, nil
+}
+
+func (c *Checker) checkJobs(ctx context.Context) (string, any, error) {
+	statuses := c.jobs.Status()
+
+	for _, s := range statuses {
+		if s.LastError != "" {
+			return StatusDegraded, statuses, fmt.Errorf("job %s failed: %s", s.Name, s.LastError)
+		}
+	}
+	return StatusOK, statuses, nil
+}
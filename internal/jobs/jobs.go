@@ -0,0 +1,154 @@
+// Package jobs runs background work on a fixed interval and keeps track of
+// how each run went.
+package jobs
+
+import (
+	"context"
+	"igaming/internal/logging"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+var tracer = otel.Tracer("igaming/internal/jobs")
+
+// Job is a unit of periodic background work.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	// RunAtStart runs the job once as soon as the scheduler starts instead
+	// of waiting for the first interval.
+	RunAtStart bool
+	Run        func(ctx context.Context) error
+}
+
+// Status is the outcome of a job's runs so far.
+type Status struct {
+	Name         string        `json:"name"`
+	Interval     string        `json:"interval"`
+	Running      bool          `json:"running"`
+	Runs         int           `json:"runs"`
+	Failures     int           `json:"failures"`
+	LastRunAt    *time.Time    `json:"last_run_at"`
+	LastSuccess  *time.Time    `json:"last_success_at"`
+	LastDuration time.Duration `json:"last_duration_ns"`
+	LastError    string        `json:"last_error,omitempty"`
+}
+
+// Scheduler runs jobs, each in its own goroutine. Runs of the same job never
+// overlap.
+type Scheduler struct {
+	mu     sync.Mutex
+	jobs   []Job
+	status map[string]*Status
+	wg     sync.WaitGroup
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{status: make(map[string]*Status)}
+}
+
+// Add registers a job. Jobs must be added before Start.
+func (s *Scheduler) Add(job Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs = append(s.jobs, job)
+	s.status[job.Name] = &Status{Name: job.Name, Interval: job.Interval.String()}
+}
+
+// Start runs every job until ctx is done. Use Wait to block until the
+// running jobs have returned.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	jobs := append([]Job(nil), s.jobs...)
+	s.mu.Unlock()
+
+	for _, job := range jobs {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.loop(ctx, job)
+		}()
+	}
+}
+
+// Wait blocks until every job loop has stopped.
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+// Status returns the status of every job in the order they were added.
+func (s *Scheduler) Status() []Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make([]Status, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		statuses = append(statuses, *s.status[job.Name])
+	}
+	return statuses
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	if job.RunAtStart {
+		s.run(ctx, job)
+	}
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.run(ctx, job)
+		}
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	started := time.Now()
+	s.update(job.Name, func(st *Status) {
+		st.Running = true
+		at := started.UTC()
+		st.LastRunAt = &at
+	})
+
+	// The job's statements are traced under one span per run.
+	ctx, span := tracer.Start(ctx, "job "+job.Name)
+	err := job.Run(ctx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+
+	finished := time.Now()
+	s.update(job.Name, func(st *Status) {
+		st.Running = false
+		st.Runs++
+		st.LastDuration = finished.Sub(started)
+		if err != nil {
+			st.Failures++
+			st.LastError = err.Error()
+			return
+		}
+		at := finished.UTC()
+		st.LastSuccess = &at
+		st.LastError = ""
+	})
+
+	if err != nil && ctx.Err() == nil {
+		logging.FromContext(ctx).Error("job failed", "job", job.Name, "error", err)
+	}
+}
+
+func (s *Scheduler) update(name string, fn func(st *Status)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fn(s.status[name])
+}
@@ -0,0 +1,89 @@
+// Package leaderboard ranks tournament participants and projects their prizes
+// the same way a tournament is settled.
+package leaderboard
+
+import (
+	"igaming/internal/models"
+	"math"
+)
+
+// PrizeTiers are the shares of the prize pool paid to placements 1, 2 and 3.
+var PrizeTiers = []float64{0.50, 0.30, 0.20}
+
+// Build turns standings sorted by total bet (highest first) into leaderboard
+// entries. Players with equal totals share a placement (dense rank) and split
+// the tiers their group covers, exactly like settlement does.
+func Build(standings []models.TournamentStanding, prizePool float64) []models.LeaderboardEntry {
+	entries := make([]models.LeaderboardEntry, len(standings))
+
+	placement := 0
+	for i := 0; i < len(standings); {
+		placement++
+
+		j := i
+		for j < len(standings) && standings[j].TotalBet == standings[i].TotalBet {
+			j++
+		}
+		groupSize := j - i
+		prize := Prize(placement, groupSize, prizePool)
+
+		gap := 0.0
+		if i > 0 {
+			gap = round2(standings[i-1].TotalBet - standings[i].TotalBet)
+		}
+
+		for k := i; k < j; k++ {
+			entries[k] = models.LeaderboardEntry{
+				Position:       k + 1,
+				Placement:      placement,
+				PlayerID:       standings[k].PlayerID,
+				PlayerName:     standings[k].PlayerName,
+				TotalBet:       standings[k].TotalBet,
+				TieGroupSize:   groupSize,
+				ProjectedPrize: prize,
+				GapToNext:      gap,
+			}
+		}
+		i = j
+	}
+
+	return entries
+}
+
+// Prize returns what each member of a tie group of groupSize players at the
+// given placement receives from prizePool. The group takes the tiers from its
+// placement up to placement+groupSize-1 and shares them equally.
+func Prize(placement, groupSize int, prizePool float64) float64 {
+	if placement > len(PrizeTiers) || groupSize <= 0 {
+		return 0
+	}
+
+	share := 0.0
+	last := min(placement+groupSize-1, len(PrizeTiers))
+	for p := placement; p <= last; p++ {
+		share += PrizeTiers[p-1]
+	}
+
+	return round2(share * prizePool / float64(groupSize))
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// Diff returns the entries of next that are new or differ from the entry of
+// the same player in prev.
+func Diff(prev, next []models.LeaderboardEntry) []models.LeaderboardEntry {
+	old := make(map[uint]models.LeaderboardEntry, len(prev))
+	for _, e := range prev {
+		old[e.PlayerID] = e
+	}
+
+	changed := []models.LeaderboardEntry{}
+	for _, e := range next {
+		if o, ok := old[e.PlayerID]; !ok || o != e {
+			changed = append(changed, e)
+		}
+	}
+	return changed
+}
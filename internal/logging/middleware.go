@@ -0,0 +1,129 @@
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIDHeader carries the request ID from the client or proxy, and back
+// in the response.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds the request IDs accepted from clients.
+const maxRequestIDLength = 128
+
+// request is what the middleware learns about a request while it is served.
+type request struct {
+	id       string
+	playerID atomic.Uint64
+}
+
+type requestKey struct{}
+
+// RequestID returns the ID of the request being served with ctx, or "".
+func RequestID(ctx context.Context) string {
+	if req, ok := ctx.Value(requestKey{}).(*request); ok {
+		return req.id
+	}
+	return ""
+}
+
+// SetPlayerID records the player the request acts for, for its log line.
+// Routes under /players/{id} get it from the URL; handlers that learn the
+// player from the request body call this.
+func SetPlayerID(ctx context.Context, playerID uint) {
+	if req, ok := ctx.Value(requestKey{}).(*request); ok {
+		req.playerID.Store(uint64(playerID))
+	}
+}
+
+// Middleware gives each request an ID, taken from X-Request-ID when the
+// client sent a usable one, and returns it in the response header. The
+// request context carries a logger with the ID, and with the trace ID when
+// the request is traced. Once the request is done one line is logged with
+// its route, status, duration, response size and player.
+func Middleware(base *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			started := time.Now()
+
+			req := &request{id: r.Header.Get(RequestIDHeader)}
+			if !validRequestID(req.id) {
+				req.id = newRequestID()
+			}
+			w.Header().Set(RequestIDHeader, req.id)
+
+			logger := base.With("request_id", req.id)
+			if sc := trace.SpanContextFromContext(r.Context()); sc.IsValid() {
+				logger = logger.With("trace_id", sc.TraceID().String())
+			}
+			ctx := context.WithValue(r.Context(), requestKey{}, req)
+			ctx = WithLogger(ctx, logger)
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			route := ""
+			if rctx := chi.RouteContext(ctx); rctx != nil {
+				route = rctx.RoutePattern()
+				if req.playerID.Load() == 0 && strings.HasPrefix(route, "/players/{id}") {
+					if id, err := strconv.ParseUint(rctx.URLParam("id"), 10, 32); err == nil {
+						req.playerID.Store(id)
+					}
+				}
+			}
+
+			attrs := []slog.Attr{
+				slog.String("method", r.Method),
+				slog.String("route", route),
+				slog.Int("status", status),
+				slog.Float64("duration_ms", float64(time.Since(started).Microseconds())/1000),
+				slog.Int("bytes", ww.BytesWritten()),
+			}
+			if id := req.playerID.Load(); id != 0 {
+				attrs = append(attrs, slog.Uint64("player_id", id))
+			}
+
+			level := slog.LevelInfo
+			if status >= http.StatusInternalServerError {
+				level = slog.LevelError
+			}
+			logger.LogAttrs(context.WithoutCancel(ctx), level, "request", attrs...)
+		})
+	}
+}
+
+// validRequestID accepts IDs of printable ASCII without spaces, so a client
+// cannot break the log line or the response header.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,50 @@
+package logging
+
+import (
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveKeys are attribute keys whose values are never logged. Keys
+// containing "password" are redacted as well.
+var sensitiveKeys = map[string]bool{
+	"email":         true,
+	"authorization": true,
+	"cookie":        true,
+	"token":         true,
+	"secret":        true,
+}
+
+// emailPattern matches email addresses inside free text, such as error
+// messages that quote the address they are about.
+var emailPattern = regexp.MustCompile(`[^\s@"'<>(),;:]+@[^\s@"'<>(),;:]+\.[A-Za-z]{2,}`)
+
+// Redact replaces the email addresses in s.
+func Redact(s string) string {
+	if !strings.Contains(s, "@") {
+		return s
+	}
+	return emailPattern.ReplaceAllString(s, redacted)
+}
+
+// redactAttr drops the values of sensitive attributes and the email
+// addresses in messages, strings and errors.
+func redactAttr(_ []string, a slog.Attr) slog.Attr {
+	key := strings.ToLower(a.Key)
+	if sensitiveKeys[key] || strings.Contains(key, "password") {
+		return slog.String(a.Key, redacted)
+	}
+
+	switch a.Value.Kind() {
+	case slog.KindString:
+		a.Value = slog.StringValue(Redact(a.Value.String()))
+	case slog.KindAny:
+		if err, ok := a.Value.Any().(error); ok {
+			a.Value = slog.StringValue(Redact(err.Error()))
+		}
+	}
+	return a
+}
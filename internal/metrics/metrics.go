@@ -0,0 +1,237 @@
+// Package metrics exposes the service metrics in the Prometheus text format:
+// HTTP traffic per route, the database connection pool, and the bets, prize
+// distributions and player balances the platform is accountable for, and the
+// transactions the database aborted and the repositories ran again.
+//
+// The counters updated while serving requests are resolved once per label
+// set and cached in a sync.Map, so recording a bet or a request only reads
+// the map and adds to atomics; nothing on the bet path waits for a lock.
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"igaming/internal/repository/dberr"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "igaming"
+
+// Prize distribution scopes
+const (
+	ScopeTournament = "tournament"
+	ScopeSeason     = "season"
+)
+
+// DefaultScrapeTimeout bounds the database queries run for a scrape.
+const DefaultScrapeTimeout = 2 * time.Second
+
+// Metrics holds the service metrics and the registry they are served from.
+// It is safe for concurrent use.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	// routes caches the request metrics by routeKey
+	routes sync.Map
+
+	bets    *prometheus.CounterVec
+	wagered *prometheus.CounterVec
+	// tournaments caches the bet metrics by tournament ID
+	tournaments sync.Map
+
+	distributions *prometheus.CounterVec
+
+	retries   *prometheus.CounterVec
+	exhausted *prometheus.CounterVec
+}
+
+// tournamentMetrics are the resolved bet counters of one tournament.
+type tournamentMetrics struct {
+	bets    prometheus.Counter
+	wagered prometheus.Counter
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests served, by method, route pattern and status.",
+		}, []string{"method", "route", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Time taken to serve HTTP requests, by method and route pattern.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		bets: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "bets_placed_total",
+			Help:      "Bets placed, by tournament.",
+		}, []string{"tournament_id"}),
+		wagered: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "bet_amount_wagered_total",
+			Help:      "Total amount wagered, by tournament.",
+		}, []string{"tournament_id"}),
+		distributions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "prize_distributions_total",
+			Help:      "Prize distributions attempted, by scope (tournament or season) and result (succeeded or failed).",
+		}, []string{"scope", "result"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transaction_retries_total",
+			Help:      "Transactions run again after the database aborted them, by reason (deadlock or serialization_failure).",
+		}, []string{"reason"}),
+		exhausted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transaction_retries_exhausted_total",
+			Help:      "Transactions given up after the database aborted every attempt, by reason of the last abort.",
+		}, []string{"reason"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.duration,
+		m.bets,
+		m.wagered,
+		m.distributions,
+		m.retries,
+		m.exhausted,
+	)
+	return m
+}
+
+// Handler serves the metrics. A collector that fails, such as the balance
+// liability when the database is down, is logged and left out of the scrape
+// rather than failing it.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{
+		ErrorLog:      errorLog{},
+		ErrorHandling: promhttp.ContinueOnError,
+	})
+}
+
+// RegisterDB adds the connection pool statistics of db (sql.DB.Stats) as the
+// go_sql_* metrics labelled with name.
+func (m *Metrics) RegisterDB(db *sql.DB, name string) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// RegisterLiability adds the total balance owed to players, read with total
+// on each scrape.
+func (m *Metrics) RegisterLiability(total func(ctx context.Context) (float64, error), timeout time.Duration) {
+	m.registry.MustRegister(&liabilityCollector{
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "balance_liability"),
+			"Sum of all player account balances, what the platform owes its players.",
+			nil, nil,
+		),
+		total:   total,
+		timeout: timeout,
+	})
+}
+
+// BetPlaced counts a bet and its amount against its tournament.
+func (m *Metrics) BetPlaced(tournamentID uint, amount float64) {
+	t := m.tournament(tournamentID)
+	t.bets.Inc()
+	t.wagered.Add(amount)
+}
+
+// TournamentSettled drops the bet series of a settled tournament, which takes
+// no more bets, so the tournament_id label does not grow with every
+// tournament ever run. A bet committed just before the settlement may still
+// add its series back when counted after it.
+func (m *Metrics) TournamentSettled(tournamentID uint) {
+	label := strconv.FormatUint(uint64(tournamentID), 10)
+	m.tournaments.Delete(tournamentID)
+	m.bets.DeleteLabelValues(label)
+	m.wagered.DeleteLabelValues(label)
+}
+
+// PrizeDistribution counts a prize distribution of scope that ended with err.
+func (m *Metrics) PrizeDistribution(scope string, err error) {
+	result := "succeeded"
+	if err != nil {
+		result = "failed"
+	}
+	m.distributions.WithLabelValues(scope, result).Inc()
+}
+
+// TransactionRetried counts a transaction run again after the database
+// aborted it with an error of kind. It implements repository.RetryObserver.
+func (m *Metrics) TransactionRetried(kind dberr.Kind) {
+	m.retries.WithLabelValues(reason(kind)).Inc()
+}
+
+// TransactionRetriesExhausted counts a transaction given up after its last
+// attempt was aborted with an error of kind.
+func (m *Metrics) TransactionRetriesExhausted(kind dberr.Kind) {
+	m.exhausted.WithLabelValues(reason(kind)).Inc()
+}
+
+// reason returns the label of kind, as in "serialization_failure".
+func reason(kind dberr.Kind) string {
+	return strings.ReplaceAll(kind.String(), " ", "_")
+}
+
+func (m *Metrics) tournament(id uint) *tournamentMetrics {
+	if t, ok := m.tournaments.Load(id); ok {
+		return t.(*tournamentMetrics)
+	}
+
+	label := strconv.FormatUint(uint64(id), 10)
+	t, _ := m.tournaments.LoadOrStore(id, &tournamentMetrics{
+		bets:    m.bets.WithLabelValues(label),
+		wagered: m.wagered.WithLabelValues(label),
+	})
+	return t.(*tournamentMetrics)
+}
+
+// liabilityCollector reads the balance liability from the database when
+// scraped, so it includes balance changes made outside the API.
+type liabilityCollector struct {
+	desc    *prometheus.Desc
+	total   func(ctx context.Context) (float64, error)
+	timeout time.Duration
+}
+
+func (c *liabilityCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *liabilityCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	total, err := c.total(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.desc, err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, total)
+}
+
+// errorLog reports scrape errors through the default logger.
+type errorLog struct{}
+
+func (errorLog) Println(v ...any) {
+	slog.Error("metrics scrape failed", "error", fmt.Sprint(v...))
+}
@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// unmatchedRoute labels requests no route matched, so that arbitrary paths do
+// not each become a series.
+const unmatchedRoute = "unmatched"
+
+// routeKey identifies the request metrics of one label set.
+type routeKey struct {
+	method string
+	route  string
+	status int
+}
+
+// routeMetrics are the resolved request metrics of one label set.
+type routeMetrics struct {
+	requests prometheus.Counter
+	duration prometheus.Observer
+}
+
+// Middleware counts each request and records how long it took, labelled
+// with the chi route pattern rather than the path.
+func (m *Metrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started := time.Now()
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		route := ""
+		if rctx := chi.RouteContext(r.Context()); rctx != nil {
+			route = rctx.RoutePattern()
+		}
+
+		rm := m.route(routeKey{method: method(r.Method), route: route, status: status})
+		rm.requests.Inc()
+		rm.duration.Observe(time.Since(started).Seconds())
+	})
+}
+
+func (m *Metrics) route(key routeKey) *routeMetrics {
+	if rm, ok := m.routes.Load(key); ok {
+		return rm.(*routeMetrics)
+	}
+
+	route := key.route
+	if route == "" {
+		route = unmatchedRoute
+	}
+	rm, _ := m.routes.LoadOrStore(key, &routeMetrics{
+		requests: m.requests.WithLabelValues(key.method, route, strconv.Itoa(key.status)),
+		duration: m.duration.WithLabelValues(key.method, route),
+	})
+	return rm.(*routeMetrics)
+}
+
+// method returns the request method, or "OTHER" for a method outside the
+// standard ones so clients cannot create series at will.
+func method(m string) string {
+	switch m {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return m
+	}
+	return "OTHER"
+}
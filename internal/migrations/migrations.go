@@ -0,0 +1,94 @@
+// Package migrations holds the SQL migrations (goose format), applies them
+// and reports which version a database is at. Each dialect has its own set
+// of files in a directory named after it; the sets share version numbers, so
+// a version means the same schema on every database.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"igaming/internal/dialect"
+	"io/fs"
+	"strings"
+)
+
+// querier is satisfied by *sql.DB and *sql.Conn.
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// FS holds the migration files of every dialect.
+//
+//go:embed mysql/*.sql postgres/*.sql sqlite/*.sql
+var FS embed.FS
+
+// dialectFS returns the migration files of d.
+func dialectFS(d dialect.Dialect) (fs.FS, error) {
+	fsys, err := fs.Sub(FS, string(d))
+	if err != nil {
+		return nil, fmt.Errorf("no migrations for %s: %w", d, err)
+	}
+	return fsys, nil
+}
+
+// Latest returns the highest migration version shipped with this build for
+// d, the version the code expects the database to be at.
+func Latest(d dialect.Dialect) (int64, error) {
+	fsys, err := dialectFS(d)
+	if err != nil {
+		return 0, err
+	}
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return 0, fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	var latest int64
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".sql") {
+			continue
+		}
+		v, err := fileVersion(e.Name())
+		if err != nil {
+			return 0, err
+		}
+		latest = max(latest, v)
+	}
+	return latest, nil
+}
+
+// Version returns the version the database is migrated to according to
+// goose's goose_db_version table: the newest version whose latest record is
+// an apply rather than a rollback. It is 0 for a database never migrated.
+func Version(ctx context.Context, db querier) (int64, error) {
+	rows, err := db.QueryContext(ctx,
+		"SELECT version_id, is_applied FROM goose_db_version ORDER BY id DESC")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read migration version: %w", err)
+	}
+	defer rows.Close()
+
+	seen := make(map[int64]bool)
+	for rows.Next() {
+		var version int64
+		var applied bool
+		if err := rows.Scan(&version, &applied); err != nil {
+			return 0, fmt.Errorf("failed to scan migration version: %w", err)
+		}
+		if seen[version] {
+			continue
+		}
+		seen[version] = true
+		if applied {
+			return version, nil
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("rows error: %w", err)
+	}
+	return 0, nil
+}
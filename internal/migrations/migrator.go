@@ -0,0 +1,394 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"igaming/internal/dialect"
+	"igaming/internal/repository/dberr"
+	"log"
+	"time"
+)
+
+// lockName names the lock held while migrating, so instances that start
+// together do not apply the same migration twice: a MySQL named lock, or a
+// Postgres advisory lock keyed by its hash. SQLite has no named locks and
+// takes the database write lock instead.
+const lockName = "igaming_migrations"
+
+// DefaultLockTimeout is how long Up and Down wait for another instance to
+// finish migrating.
+const DefaultLockTimeout = time.Minute
+
+var (
+	// ErrSchemaTooNew means the database has migrations this build does not
+	// know about.
+	ErrSchemaTooNew = errors.New("database schema is newer than this build")
+	// ErrUnknownVersion means the database is at a version this build has no
+	// migration for, so there is nothing to roll it back with.
+	ErrUnknownVersion = errors.New("database is at a migration version this build does not have")
+	// ErrLockTimeout means another instance held the migration lock too long.
+	ErrLockTimeout = errors.New("timed out waiting for the migration lock")
+)
+
+// Status is whether a migration has been applied.
+type Status struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	AppliedAt *time.Time `json:"applied_at"`
+}
+
+// Migrator applies the embedded migrations. It records them in goose's
+// goose_db_version table, so databases migrated with the goose CLI carry on
+// where it left off.
+//
+// On Postgres and SQLite each migration runs in a transaction together with
+// its record, so a failed migration leaves nothing behind. MySQL commits DDL
+// implicitly, so there a migration cannot be rolled back as a whole: if a
+// statement fails, the ones before it stay applied and the migration is not
+// recorded. Fix the schema by hand before retrying.
+type Migrator struct {
+	db          *sql.DB
+	dialect     dialect.Dialect
+	migrations  []Migration
+	lockTimeout time.Duration
+}
+
+func NewMigrator(db *sql.DB, d dialect.Dialect, lockTimeout time.Duration) (*Migrator, error) {
+	all, err := Load(d)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, dialect: d, migrations: all, lockTimeout: lockTimeout}, nil
+}
+
+// Latest returns the newest migration version in the build.
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Up applies every pending migration in order and returns the versions it
+// applied.
+func (m *Migrator) Up(ctx context.Context) ([]int64, error) {
+	var applied []int64
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		current, err := Version(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if current > m.Latest() {
+			return fmt.Errorf("%w: database is at version %d, this build only knows up to %d", ErrSchemaTooNew, current, m.Latest())
+		}
+
+		for _, mig := range m.migrations {
+			if mig.Version <= current {
+				continue
+			}
+			if err := m.run(ctx, conn, mig.Version, mig.Name, mig.Up, true); err != nil {
+				return err
+			}
+			applied = append(applied, mig.Version)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down rolls back the latest applied migration and returns its version, or 0
+// when there was nothing to roll back.
+func (m *Migrator) Down(ctx context.Context) (int64, error) {
+	var rolledBack int64
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		current, err := Version(ctx, conn)
+		if err != nil || current == 0 {
+			return err
+		}
+
+		for _, mig := range m.migrations {
+			if mig.Version != current {
+				continue
+			}
+			if err := m.run(ctx, conn, mig.Version, mig.Name, mig.Down, false); err != nil {
+				return err
+			}
+			rolledBack = mig.Version
+			return nil
+		}
+		return fmt.Errorf("%w: cannot roll back version %d", ErrUnknownVersion, current)
+	})
+	return rolledBack, err
+}
+
+// Status lists every migration in the build and when it was applied.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied := make(map[int64]time.Time)
+
+	exists, err := versionTableExists(ctx, m.db, m.dialect)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		rows, err := m.db.QueryContext(ctx,
+			"SELECT version_id, is_applied, tstamp FROM goose_db_version ORDER BY id DESC")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration history: %w", err)
+		}
+		defer rows.Close()
+
+		seen := make(map[int64]bool)
+		for rows.Next() {
+			var version int64
+			var isApplied bool
+			var at sql.NullTime
+			if err := rows.Scan(&version, &isApplied, &at); err != nil {
+				return nil, fmt.Errorf("failed to scan migration history: %w", err)
+			}
+			if seen[version] {
+				continue
+			}
+			seen[version] = true
+			if isApplied {
+				applied[version] = at.Time
+			}
+		}
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("rows error: %w", err)
+		}
+	}
+
+	statuses := make([]Status, len(m.migrations))
+	for i, mig := range m.migrations {
+		statuses[i] = Status{Version: mig.Version, Name: mig.Name}
+		if at, ok := applied[mig.Version]; ok {
+			statuses[i].AppliedAt = &at
+		}
+	}
+	return statuses, nil
+}
+
+// Check returns ErrSchemaTooNew when the database is ahead of the build.
+// A database behind the build is reported by the readiness check instead.
+func (m *Migrator) Check(ctx context.Context) (int64, error) {
+	exists, err := versionTableExists(ctx, m.db, m.dialect)
+	if err != nil || !exists {
+		return 0, err
+	}
+	current, err := Version(ctx, m.db)
+	if err != nil {
+		return 0, err
+	}
+	if current > m.Latest() {
+		return current, fmt.Errorf("%w: database is at version %d, this build only knows up to %d", ErrSchemaTooNew, current, m.Latest())
+	}
+	return current, nil
+}
+
+// locked runs fn on a single connection holding the migration lock, after
+// creating the version table if needed.
+func (m *Migrator) locked(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get a connection: %w", err)
+	}
+	defer conn.Close()
+
+	release, err := m.lock(ctx, conn)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := release(); err != nil {
+			log.Printf("Failed to release the migration lock: %v", err)
+		}
+	}()
+
+	if err := m.ensureVersionTable(ctx, conn); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+// lock takes the migration lock on conn, waiting up to the lock timeout, and
+// returns the function that releases it. The MySQL and Postgres locks belong
+// to the session, so they are released if the connection dies.
+func (m *Migrator) lock(ctx context.Context, conn *sql.Conn) (release func() error, err error) {
+	unlock := func(query string) func() error {
+		return func() error {
+			_, err := conn.ExecContext(context.Background(), query, lockName)
+			return err
+		}
+	}
+
+	switch m.dialect {
+	case dialect.Postgres:
+		// pg_advisory_lock waits at most lock_timeout, then fails with
+		// lock_not_available. Zero would mean waiting forever.
+		timeout := max(m.lockTimeout.Milliseconds(), 1)
+		if _, err := conn.ExecContext(ctx, fmt.Sprintf("SET lock_timeout = %d", timeout)); err != nil {
+			return nil, fmt.Errorf("failed to set the lock timeout: %w", err)
+		}
+		_, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock(hashtext($1))", lockName)
+		if _, resetErr := conn.ExecContext(ctx, "RESET lock_timeout"); resetErr != nil && err == nil {
+			err = resetErr
+		}
+		if dberr.Is(err, dberr.LockTimeout) {
+			return nil, ErrLockTimeout
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to take the migration lock: %w", err)
+		}
+		return unlock("SELECT pg_advisory_unlock(hashtext($1))"), nil
+
+	case dialect.SQLite:
+		// The lock is a write transaction held for the whole run; each
+		// migration is a savepoint in it (see run). The migrations applied
+		// are committed even when a later one fails.
+		var busyTimeout int64
+		if err := conn.QueryRowContext(ctx, "PRAGMA busy_timeout").Scan(&busyTimeout); err != nil {
+			return nil, fmt.Errorf("failed to read the busy timeout: %w", err)
+		}
+		timeout := max(m.lockTimeout.Milliseconds(), 1)
+		if _, err := conn.ExecContext(ctx, fmt.Sprintf("PRAGMA busy_timeout = %d", timeout)); err != nil {
+			return nil, fmt.Errorf("failed to set the lock timeout: %w", err)
+		}
+		_, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE")
+		if _, resetErr := conn.ExecContext(ctx, fmt.Sprintf("PRAGMA busy_timeout = %d", busyTimeout)); resetErr != nil && err == nil {
+			conn.ExecContext(ctx, "ROLLBACK")
+			err = resetErr
+		}
+		if dberr.Is(err, dberr.LockTimeout) {
+			return nil, ErrLockTimeout
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to take the migration lock: %w", err)
+		}
+		return func() error {
+			_, err := conn.ExecContext(context.Background(), "COMMIT")
+			return err
+		}, nil
+	}
+
+	// GET_LOCK returns 1 when acquired, 0 on timeout.
+	var got sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", lockName, int(m.lockTimeout.Seconds())).Scan(&got); err != nil {
+		return nil, fmt.Errorf("failed to take the migration lock: %w", err)
+	}
+	if got.Int64 != 1 {
+		return nil, ErrLockTimeout
+	}
+	return unlock("SELECT RELEASE_LOCK(?)"), nil
+}
+
+// execer is a connection or a transaction on it.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func (m *Migrator) run(ctx context.Context, conn *sql.Conn, version int64, name string, statements []string, up bool) (err error) {
+	direction := "up"
+	if !up {
+		direction = "down"
+	}
+
+	started := time.Now()
+	var q execer = conn
+	switch m.dialect {
+	case dialect.Postgres:
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer tx.Rollback()
+		q = tx
+	case dialect.SQLite:
+		if _, err := conn.ExecContext(ctx, "SAVEPOINT migration"); err != nil {
+			return fmt.Errorf("failed to create savepoint: %w", err)
+		}
+		defer func() {
+			if err != nil {
+				conn.ExecContext(context.Background(), "ROLLBACK TO migration")
+			}
+		}()
+	}
+
+	for i, stmt := range statements {
+		if _, err := q.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("migration %s (%s) failed at statement %d: %w", name, direction, i+1, err)
+		}
+	}
+	if _, err := q.ExecContext(ctx, m.dialect.Rebind(
+		"INSERT INTO goose_db_version (version_id, is_applied) VALUES (?, ?)"), version, up); err != nil {
+		return fmt.Errorf("failed to record migration %s: %w", name, err)
+	}
+
+	if tx, ok := q.(*sql.Tx); ok {
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit migration %s: %w", name, err)
+		}
+	}
+	if m.dialect == dialect.SQLite {
+		if _, err := conn.ExecContext(ctx, "RELEASE migration"); err != nil {
+			return fmt.Errorf("failed to commit migration %s: %w", name, err)
+		}
+	}
+
+	log.Printf("Migrated %s %s in %s", direction, name, time.Since(started).Round(time.Millisecond))
+	return nil
+}
+
+// ensureVersionTable creates goose_db_version the way goose does, including
+// its initial version 0 row.
+func (m *Migrator) ensureVersionTable(ctx context.Context, conn *sql.Conn) error {
+	exists, err := versionTableExists(ctx, conn, m.dialect)
+	if err != nil || exists {
+		return err
+	}
+
+	create := `CREATE TABLE goose_db_version (
+		id serial NOT NULL,
+		version_id bigint NOT NULL,
+		is_applied boolean NOT NULL,
+		tstamp timestamp NULL default now(),
+		PRIMARY KEY(id)
+	)`
+	if m.dialect == dialect.SQLite {
+		create = `CREATE TABLE goose_db_version (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		version_id INTEGER NOT NULL,
+		is_applied INTEGER NOT NULL,
+		tstamp TIMESTAMP DEFAULT (datetime('now'))
+	)`
+	}
+	if _, err := conn.ExecContext(ctx, create); err != nil {
+		return fmt.Errorf("failed to create goose_db_version: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx,
+		"INSERT INTO goose_db_version (version_id, is_applied) VALUES (0, TRUE)"); err != nil {
+		return fmt.Errorf("failed to initialise goose_db_version: %w", err)
+	}
+	return nil
+}
+
+func versionTableExists(ctx context.Context, q querier, d dialect.Dialect) (bool, error) {
+	schema := "DATABASE()"
+	if d == dialect.Postgres {
+		schema = "current_schema()"
+	}
+	query := `
+		SELECT COUNT(*) FROM information_schema.tables
+		 WHERE table_schema = ` + schema + ` AND table_name = 'goose_db_version'`
+	if d == dialect.SQLite {
+		query = "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'goose_db_version'"
+	}
+
+	var n int
+	err := q.QueryRowContext(ctx, query).Scan(&n)
+	if err != nil {
+		return false, fmt.Errorf("failed to look up goose_db_version: %w", err)
+	}
+	return n > 0, nil
+}
@@ -0,0 +1,77 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"igaming/internal/dialect"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+func newSQLiteMigrator(t *testing.T) *Migrator {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "migrations.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	m, err := NewMigrator(db, dialect.SQLite, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestMigratorUpAndDown(t *testing.T) {
+	ctx := context.Background()
+	m := newSQLiteMigrator(t)
+
+	applied, err := m.Up(ctx)
+	if err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	if len(applied) != len(m.migrations) || applied[len(applied)-1] != m.Latest() {
+		t.Fatalf("Up applied %v, want every version up to %d", applied, m.Latest())
+	}
+	if applied, err := m.Up(ctx); err != nil || len(applied) != 0 {
+		t.Fatalf("second Up applied %v, %v; want nothing", applied, err)
+	}
+
+	rolledBack, err := m.Down(ctx)
+	if err != nil || rolledBack != m.Latest() {
+		t.Fatalf("Down = %d, %v; want %d", rolledBack, err, m.Latest())
+	}
+	if current, err := Version(ctx, m.db); err != nil || current >= m.Latest() {
+		t.Fatalf("version after Down = %d, %v; want below %d", current, err, m.Latest())
+	}
+}
+
+func TestMigratorDownUnknownVersion(t *testing.T) {
+	ctx := context.Background()
+	m := newSQLiteMigrator(t)
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+
+	// A build that lacks a migration below its latest, such as one removed
+	// from the tree after it was applied.
+	latest := m.migrations[len(m.migrations)-1]
+	m.migrations = append(m.migrations[:len(m.migrations)-2:len(m.migrations)-2], latest)
+	if _, err := m.db.ExecContext(ctx, "DELETE FROM goose_db_version WHERE version_id = ?", latest.Version); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := m.Down(ctx)
+	if !errors.Is(err, ErrUnknownVersion) {
+		t.Fatalf("Down error %v, want ErrUnknownVersion", err)
+	}
+	if errors.Is(err, ErrSchemaTooNew) {
+		t.Error("a version below the latest reported as too new")
+	}
+}
@@ -0,0 +1,23 @@
+-- +goose Up
+
+ALTER TABLE tournaments
+    ADD COLUMN min_bet DECIMAL(15, 2) NULL DEFAULT NULL AFTER end_date,
+    ADD COLUMN max_bet DECIMAL(15, 2) NULL DEFAULT NULL AFTER min_bet,
+    ADD COLUMN max_stake_per_player DECIMAL(15, 2) NULL DEFAULT NULL AFTER max_bet,
+    ADD COLUMN max_participants INT NULL DEFAULT NULL AFTER max_stake_per_player,
+    ADD COLUMN entry_fee DECIMAL(15, 2) NULL DEFAULT NULL AFTER max_participants,
+    ADD CONSTRAINT chk_bet_range CHECK (min_bet IS NULL OR max_bet IS NULL OR min_bet <= max_bet),
+    ADD CONSTRAINT chk_max_participants CHECK (max_participants IS NULL OR max_participants > 0),
+    ADD CONSTRAINT chk_entry_fee CHECK (entry_fee IS NULL OR entry_fee > 0);
+
+-- +goose Down
+
+ALTER TABLE tournaments
+    DROP CHECK chk_entry_fee,
+    DROP CHECK chk_max_participants,
+    DROP CHECK chk_bet_range,
+    DROP COLUMN entry_fee,
+    DROP COLUMN max_participants,
+    DROP COLUMN max_stake_per_player,
+    DROP COLUMN max_bet,
+    DROP COLUMN min_bet;
@@ -0,0 +1,27 @@
+-- +goose Up
+
+ALTER TABLE tournaments
+    ADD COLUMN pool_mode ENUM('fixed', 'accumulating') NOT NULL DEFAULT 'fixed' AFTER prize_pool,
+    ADD COLUMN guaranteed_prize_pool DECIMAL(15, 2) NOT NULL DEFAULT 0.00 AFTER pool_mode,
+    ADD COLUMN rake_percentage DECIMAL(5, 2) NOT NULL DEFAULT 0.00 AFTER guaranteed_prize_pool,
+    ADD COLUMN rake_collected DECIMAL(15, 2) NOT NULL DEFAULT 0.00 AFTER rake_percentage,
+    ADD CONSTRAINT chk_guaranteed_prize_pool CHECK (guaranteed_prize_pool >= 0),
+    ADD CONSTRAINT chk_rake_percentage CHECK (rake_percentage BETWEEN 0 AND 100);
+
+UPDATE tournaments SET guaranteed_prize_pool = prize_pool;
+
+ALTER TABLE tournament_bets
+    ADD COLUMN rake_amount DECIMAL(15, 2) NOT NULL DEFAULT 0.00 AFTER bet_amount;
+
+-- +goose Down
+
+ALTER TABLE tournament_bets
+    DROP COLUMN rake_amount;
+
+ALTER TABLE tournaments
+    DROP CHECK chk_rake_percentage,
+    DROP CHECK chk_guaranteed_prize_pool,
+    DROP COLUMN rake_collected,
+    DROP COLUMN rake_percentage,
+    DROP COLUMN guaranteed_prize_pool,
+    DROP COLUMN pool_mode;
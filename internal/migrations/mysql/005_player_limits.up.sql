@@ -0,0 +1,26 @@
+-- +goose Up
+
+CREATE TABLE player_limits (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    player_id INT NOT NULL,
+    limit_type ENUM('wager', 'loss') NOT NULL,
+    period ENUM('daily', 'weekly', 'monthly') NOT NULL,
+    amount DECIMAL(15, 2) NULL DEFAULT NULL,
+    pending_amount DECIMAL(15, 2) NULL DEFAULT NULL,
+    pending_effective_at DATETIME NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY unique_player_limit (player_id, limit_type, period),
+    CONSTRAINT chk_limit_amount CHECK (amount IS NULL OR amount > 0),
+    CONSTRAINT chk_pending_limit_amount CHECK (pending_amount IS NULL OR pending_amount > 0),
+    FOREIGN KEY (player_id) REFERENCES players(id)
+) ENGINE=InnoDB;
+
+CREATE INDEX idx_bets_player_created ON tournament_bets(player_id, created_at);
+CREATE INDEX idx_results_player_created ON tournament_results(player_id, created_at);
+
+-- +goose Down
+
+DROP INDEX idx_results_player_created ON tournament_results;
+DROP INDEX idx_bets_player_created ON tournament_bets;
+DROP TABLE IF EXISTS player_limits;
@@ -0,0 +1,45 @@
+-- +goose Up
+
+CREATE TABLE player_exclusions (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    player_id INT NOT NULL,
+    duration ENUM('24h', '7d', '6m', 'permanent') NOT NULL,
+    starts_at DATETIME NOT NULL,
+    ends_at DATETIME NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_exclusion_period CHECK (ends_at IS NULL OR ends_at > starts_at),
+    FOREIGN KEY (player_id) REFERENCES players(id)
+) ENGINE=InnoDB;
+
+CREATE INDEX idx_exclusions_player_ends ON player_exclusions(player_id, ends_at);
+
+CREATE OR REPLACE VIEW player_rankings AS
+SELECT 
+    id AS player_id,
+    name AS player_name,
+    account_balance,
+    DENSE_RANK() OVER (ORDER BY account_balance DESC) AS player_rank
+FROM players p
+WHERE deleted_at IS NULL
+  AND NOT EXISTS (
+      SELECT 1
+        FROM player_exclusions e
+       WHERE e.player_id = p.id
+         AND e.starts_at <= UTC_TIMESTAMP()
+         AND (e.ends_at IS NULL OR e.ends_at > UTC_TIMESTAMP())
+  )
+ORDER BY player_rank;
+
+-- +goose Down
+
+CREATE OR REPLACE VIEW player_rankings AS
+SELECT 
+    id AS player_id,
+    name AS player_name,
+    account_balance,
+    DENSE_RANK() OVER (ORDER BY account_balance DESC) AS player_rank
+FROM players
+WHERE deleted_at IS NULL
+ORDER BY player_rank;
+
+DROP TABLE IF EXISTS player_exclusions;
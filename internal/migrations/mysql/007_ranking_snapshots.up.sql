@@ -0,0 +1,16 @@
+-- +goose Up
+
+CREATE TABLE ranking_snapshots (
+    taken_at DATETIME NOT NULL,
+    player_id INT NOT NULL,
+    player_rank INT NOT NULL,
+    account_balance DECIMAL(15, 2) NOT NULL,
+    PRIMARY KEY (taken_at, player_id),
+    FOREIGN KEY (player_id) REFERENCES players(id)
+) ENGINE=InnoDB;
+
+CREATE INDEX idx_ranking_snapshots_player ON ranking_snapshots(player_id, taken_at);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS ranking_snapshots;
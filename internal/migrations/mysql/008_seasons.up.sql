@@ -0,0 +1,96 @@
+-- +goose Up
+
+CREATE TABLE seasons (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    start_date DATETIME NOT NULL,
+    end_date DATETIME NOT NULL,
+    prize_pool DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    prizes_distributed BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    CONSTRAINT chk_season_dates CHECK (end_date > start_date),
+    CONSTRAINT chk_season_prize_pool CHECK (prize_pool >= 0)
+) ENGINE=InnoDB;
+
+-- Points awarded for each finishing placement in a tournament of the season
+CREATE TABLE season_points (
+    season_id INT NOT NULL,
+    placement INT NOT NULL,
+    points INT NOT NULL,
+    PRIMARY KEY (season_id, placement),
+    CONSTRAINT chk_season_points_placement CHECK (placement >= 1),
+    CONSTRAINT chk_season_points CHECK (points >= 0),
+    FOREIGN KEY (season_id) REFERENCES seasons(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
+
+CREATE TABLE season_results (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    season_id INT NOT NULL,
+    player_id INT NOT NULL,
+    placement INT NOT NULL,
+    prize_amount DECIMAL(15, 2) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY unique_season_player (season_id, player_id),
+    CONSTRAINT chk_season_result_placement CHECK (placement BETWEEN 1 AND 3),
+    FOREIGN KEY (season_id) REFERENCES seasons(id),
+    FOREIGN KEY (player_id) REFERENCES players(id)
+) ENGINE=InnoDB;
+
+ALTER TABLE tournaments
+    ADD COLUMN season_id INT NULL DEFAULT NULL AFTER late_registration_minutes,
+    ADD CONSTRAINT fk_tournaments_season FOREIGN KEY (season_id) REFERENCES seasons(id);
+
+-- Season standings: points from the placements in tournament_results. Ties
+-- are broken by most wins, then by who reached their points total first.
+CREATE VIEW season_standings AS
+WITH scored AS (
+    SELECT
+        t.season_id,
+        r.player_id,
+        r.placement,
+        COALESCE(sp.points, 0) AS points,
+        r.created_at
+    FROM tournament_results r
+    JOIN tournaments t ON t.id = r.tournament_id
+    LEFT JOIN season_points sp ON sp.season_id = t.season_id AND sp.placement = r.placement
+    WHERE t.season_id IS NOT NULL
+),
+totals AS (
+    SELECT
+        season_id,
+        player_id,
+        SUM(points) AS points,
+        SUM(placement = 1) AS wins,
+        COUNT(*) AS tournaments_placed,
+        MAX(CASE WHEN points > 0 THEN created_at END) AS achieved_at
+    FROM scored
+    GROUP BY season_id, player_id
+)
+SELECT
+    season_id,
+    player_id,
+    points,
+    wins,
+    tournaments_placed,
+    achieved_at,
+    DENSE_RANK() OVER (
+        PARTITION BY season_id
+        ORDER BY points DESC, wins DESC, achieved_at IS NULL, achieved_at
+    ) AS placement
+FROM totals;
+
+-- Season prizes are distributed by the service, so there is no
+-- DistributeSeasonPrizes procedure.
+
+-- +goose Down
+
+DROP VIEW IF EXISTS season_standings;
+
+ALTER TABLE tournaments
+    DROP FOREIGN KEY fk_tournaments_season,
+    DROP COLUMN season_id;
+
+DROP TABLE IF EXISTS season_results;
+DROP TABLE IF EXISTS season_points;
+DROP TABLE IF EXISTS seasons;
@@ -0,0 +1,53 @@
+-- +goose Up
+
+CREATE TABLE player_ratings (
+    player_id INT PRIMARY KEY,
+    rating DECIMAL(8, 2) NOT NULL,
+    tournaments_played INT NOT NULL DEFAULT 0,
+    updated_at DATETIME NOT NULL,
+    FOREIGN KEY (player_id) REFERENCES players(id)
+) ENGINE=InnoDB;
+
+CREATE INDEX idx_player_ratings_rating ON player_ratings(rating DESC);
+
+CREATE TABLE rating_history (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    player_id INT NOT NULL,
+    tournament_id INT NOT NULL,
+    placement INT NOT NULL,
+    field_size INT NOT NULL,
+    rating_before DECIMAL(8, 2) NOT NULL,
+    rating_after DECIMAL(8, 2) NOT NULL,
+    created_at DATETIME NOT NULL,
+    UNIQUE KEY unique_rating_player_tournament (player_id, tournament_id),
+    FOREIGN KEY (player_id) REFERENCES players(id),
+    FOREIGN KEY (tournament_id) REFERENCES tournaments(id)
+) ENGINE=InnoDB;
+
+CREATE INDEX idx_rating_history_tournament ON rating_history(tournament_id);
+
+-- Rated players by skill, with the same visibility rules as player_rankings
+CREATE VIEW player_rating_rankings AS
+SELECT 
+    r.player_id,
+    p.name AS player_name,
+    r.rating,
+    r.tournaments_played,
+    DENSE_RANK() OVER (ORDER BY r.rating DESC) AS rating_rank
+FROM player_ratings r
+JOIN players p ON p.id = r.player_id
+WHERE p.deleted_at IS NULL
+  AND NOT EXISTS (
+      SELECT 1
+        FROM player_exclusions e
+       WHERE e.player_id = p.id
+         AND e.starts_at <= UTC_TIMESTAMP()
+         AND (e.ends_at IS NULL OR e.ends_at > UTC_TIMESTAMP())
+  )
+ORDER BY rating_rank;
+
+-- +goose Down
+
+DROP VIEW IF EXISTS player_rating_rankings;
+DROP TABLE IF EXISTS rating_history;
+DROP TABLE IF EXISTS player_ratings;
@@ -0,0 +1,112 @@
+-- +goose Up
+
+-- Prizes are paid by the settlement service in the same unit of work as the
+-- rest of a settlement. The procedures committed on their own and were no
+-- longer called, so they are dropped to keep one set of payout rules.
+-- DistributeSeasonPrizes only exists on databases migrated with an early
+-- version of 008.
+DROP PROCEDURE IF EXISTS DistributePrizes;
+DROP PROCEDURE IF EXISTS DistributeSeasonPrizes;
+
+-- +goose Down
+
+-- Restores DistributePrizes as 001 created it.
+-- +goose StatementBegin
+CREATE PROCEDURE DistributePrizes(IN target_tournament_id INT)
+BEGIN
+    DECLARE total_prize_pool DECIMAL(15,2);
+    DECLARE distribution_status BOOLEAN;
+
+    START TRANSACTION;
+
+    SELECT prize_pool, prizes_distributed
+      INTO total_prize_pool, distribution_status
+      FROM tournaments
+     WHERE id = target_tournament_id
+       FOR UPDATE;
+
+    IF distribution_status THEN
+        SIGNAL SQLSTATE '45000'
+          SET MESSAGE_TEXT = 'Prizes already distributed';
+    END IF;
+
+    IF NOT EXISTS (SELECT 1
+                     FROM tournament_bets
+                    WHERE tournament_id = target_tournament_id) THEN
+        SIGNAL SQLSTATE '45000'
+           SET MESSAGE_TEXT = 'No bets found';
+    END IF;
+
+    CREATE TEMPORARY TABLE tmp_prize_distribution AS
+        WITH summed AS (
+            SELECT 
+                player_id, 
+                SUM(bet_amount) AS total_bet_amount
+            FROM tournament_bets
+            WHERE tournament_id = target_tournament_id
+            GROUP BY player_id
+        ),
+        ranked AS (
+            SELECT
+                player_id,
+                total_bet_amount,
+                DENSE_RANK() OVER (ORDER BY total_bet_amount DESC) AS placement
+            FROM summed
+        ),
+        placement_counts AS (
+            SELECT 
+                placement, 
+                COUNT(*) AS group_size
+            FROM ranked
+            WHERE placement <= 3
+            GROUP BY placement
+        ),
+        tier_percentages AS (
+            SELECT 1 AS placement, 0.50 AS pct
+            UNION ALL SELECT 2, 0.30
+            UNION ALL SELECT 3, 0.20
+        ),
+        prize_calc AS (
+            SELECT
+                r.player_id,
+                r.placement,
+                ROUND(
+                    COALESCE(
+                        (
+                            SELECT SUM(tp.pct)
+                            FROM tier_percentages tp
+                            WHERE tp.placement BETWEEN pc.placement 
+                                AND LEAST(pc.placement + pc.group_size, 4) - 1
+                        ) * total_prize_pool 
+                        / NULLIF(pc.group_size, 0),
+                        0
+                    ),
+                    2
+                ) AS prize
+        FROM ranked r
+        JOIN placement_counts pc ON r.placement = pc.placement
+        WHERE r.placement <= 3
+        )
+    SELECT player_id, placement, prize
+    FROM prize_calc;
+
+    INSERT INTO tournament_results (tournament_id, player_id, placement, prize_amount)
+    SELECT target_tournament_id, player_id, placement, prize
+      FROM tmp_prize_distribution
+    ON DUPLICATE KEY UPDATE
+      placement    = VALUES(placement),
+      prize_amount = VALUES(prize_amount);
+
+    UPDATE players p
+      JOIN tmp_prize_distribution pd ON p.id = pd.player_id
+       SET p.account_balance = p.account_balance + pd.prize;
+
+    UPDATE tournaments
+       SET prizes_distributed = TRUE
+     WHERE id = target_tournament_id;
+
+    DROP TEMPORARY TABLE IF EXISTS tmp_prize_distribution;
+
+    COMMIT;
+END;
+-- +goose StatementEnd
@@ -0,0 +1,167 @@
+package migrations
+
+import (
+	"bufio"
+	"fmt"
+	"igaming/internal/dialect"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Migration is one parsed migration file.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      []string
+	Down    []string
+}
+
+// Load parses every migration of d, ordered by version.
+func Load(d dialect.Dialect) ([]Migration, error) {
+	fsys, err := dialectFS(d)
+	if err != nil {
+		return nil, err
+	}
+	return load(fsys)
+}
+
+func load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	var all []Migration
+	seen := make(map[int64]string)
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".sql") {
+			continue
+		}
+		version, err := fileVersion(e.Name())
+		if err != nil {
+			return nil, err
+		}
+		if other, ok := seen[version]; ok {
+			return nil, fmt.Errorf("migrations %s and %s share version %d", other, e.Name(), version)
+		}
+		seen[version] = e.Name()
+
+		data, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", e.Name(), err)
+		}
+		up, down, err := parse(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: %w", e.Name(), err)
+		}
+		all = append(all, Migration{Version: version, Name: e.Name(), Up: up, Down: down})
+	}
+
+	sort.Slice(all, func(i, j int) bool { return all[i].Version < all[j].Version })
+	return all, nil
+}
+
+func fileVersion(name string) (int64, error) {
+	prefix, _, ok := strings.Cut(name, "_")
+	if !ok {
+		return 0, fmt.Errorf("invalid migration file name %q", name)
+	}
+	v, err := strconv.ParseInt(prefix, 10, 64)
+	if err != nil || v <= 0 {
+		return 0, fmt.Errorf("invalid migration file name %q", name)
+	}
+	return v, nil
+}
+
+// parse splits a goose SQL file into its up and down statements. As in goose,
+// a statement ends at a line ending in a semicolon, except between
+// StatementBegin and StatementEnd, which hold one statement such as a stored
+// procedure. Whole-line comments outside those blocks are dropped.
+func parse(src string) (up, down []string, err error) {
+	const (
+		none = iota
+		inUp
+		inDown
+	)
+	section := none
+	inBlock := false
+	var buf strings.Builder
+
+	flush := func() {
+		stmt := strings.TrimSpace(buf.String())
+		buf.Reset()
+		if stmt == "" {
+			return
+		}
+		if section == inUp {
+			up = append(up, stmt)
+		} else {
+			down = append(down, stmt)
+		}
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(src))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		if annotation, ok := strings.CutPrefix(trimmed, "-- +goose "); ok {
+			switch strings.TrimSpace(annotation) {
+			case "Up":
+				if inBlock || section != none {
+					return nil, nil, fmt.Errorf("unexpected Up annotation")
+				}
+				section = inUp
+			case "Down":
+				if inBlock || section != inUp {
+					return nil, nil, fmt.Errorf("unexpected Down annotation")
+				}
+				flush()
+				section = inDown
+			case "StatementBegin":
+				if inBlock || section == none {
+					return nil, nil, fmt.Errorf("unexpected StatementBegin annotation")
+				}
+				flush()
+				inBlock = true
+			case "StatementEnd":
+				if !inBlock {
+					return nil, nil, fmt.Errorf("StatementEnd without StatementBegin")
+				}
+				flush()
+				inBlock = false
+			default:
+				return nil, nil, fmt.Errorf("unsupported annotation %q", trimmed)
+			}
+			continue
+		}
+
+		if section == none {
+			continue
+		}
+		if !inBlock && strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+		if !inBlock && strings.HasSuffix(trimmed, ";") {
+			flush()
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	if inBlock {
+		return nil, nil, fmt.Errorf("StatementBegin without StatementEnd")
+	}
+	if section == none {
+		return nil, nil, fmt.Errorf("missing Up annotation")
+	}
+	flush()
+	return up, down, nil
+}
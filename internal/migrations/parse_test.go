@@ -0,0 +1,243 @@
+package migrations
+
+import (
+	"igaming/internal/dialect"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		wantUp   []string
+		wantDown []string
+		wantErr  string
+	}{
+		{
+			name: "up and down",
+			src: `-- +goose Up
+CREATE TABLE a (id INT);
+CREATE TABLE b (
+    id INT
+);
+
+-- +goose Down
+DROP TABLE b;
+DROP TABLE a;
+`,
+			wantUp:   []string{"CREATE TABLE a (id INT);", "CREATE TABLE b (\n    id INT\n);"},
+			wantDown: []string{"DROP TABLE b;", "DROP TABLE a;"},
+		},
+		{
+			name: "up only",
+			src:  "-- +goose Up\nCREATE TABLE a (id INT);\n",
+			wantUp: []string{
+				"CREATE TABLE a (id INT);",
+			},
+		},
+		{
+			name: "comments",
+			src: `-- Adds the a table.
+-- +goose Up
+-- The table a.
+CREATE TABLE a (
+    -- The key.
+    id INT
+);
+  -- indented comment
+-- +goose Down
+-- Drops it again.
+DROP TABLE a;
+`,
+			wantUp:   []string{"CREATE TABLE a (\n    id INT\n);"},
+			wantDown: []string{"DROP TABLE a;"},
+		},
+		{
+			name: "statement block",
+			src: `-- +goose Up
+CREATE TABLE a (id INT);
+-- +goose StatementBegin
+CREATE TRIGGER a_touch BEFORE UPDATE ON a
+FOR EACH ROW BEGIN
+    -- kept inside the block
+    SET NEW.id = OLD.id;
+END;
+-- +goose StatementEnd
+CREATE INDEX a_id ON a (id);
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER a_touch;
+-- +goose StatementEnd
+DROP TABLE a;
+`,
+			wantUp: []string{
+				"CREATE TABLE a (id INT);",
+				"CREATE TRIGGER a_touch BEFORE UPDATE ON a\nFOR EACH ROW BEGIN\n    -- kept inside the block\n    SET NEW.id = OLD.id;\nEND;",
+				"CREATE INDEX a_id ON a (id);",
+			},
+			wantDown: []string{"DROP TRIGGER a_touch;", "DROP TABLE a;"},
+		},
+		{
+			name:   "statement without a semicolon at the end",
+			src:    "-- +goose Up\nCREATE TABLE a (id INT)\n",
+			wantUp: []string{"CREATE TABLE a (id INT)"},
+		},
+		{
+			name:    "missing Up",
+			src:     "CREATE TABLE a (id INT);\n",
+			wantErr: "missing Up annotation",
+		},
+		{
+			name:    "Down before Up",
+			src:     "-- +goose Down\nDROP TABLE a;\n-- +goose Up\nCREATE TABLE a (id INT);\n",
+			wantErr: "unexpected Down annotation",
+		},
+		{
+			name:    "second Up",
+			src:     "-- +goose Up\nCREATE TABLE a (id INT);\n-- +goose Up\n",
+			wantErr: "unexpected Up annotation",
+		},
+		{
+			name:    "Down inside a block",
+			src:     "-- +goose Up\n-- +goose StatementBegin\nCREATE TABLE a (id INT);\n-- +goose Down\n",
+			wantErr: "unexpected Down annotation",
+		},
+		{
+			name:    "unclosed StatementBegin",
+			src:     "-- +goose Up\n-- +goose StatementBegin\nCREATE TABLE a (id INT);\n",
+			wantErr: "StatementBegin without StatementEnd",
+		},
+		{
+			name:    "StatementEnd without StatementBegin",
+			src:     "-- +goose Up\nCREATE TABLE a (id INT);\n-- +goose StatementEnd\n",
+			wantErr: "StatementEnd without StatementBegin",
+		},
+		{
+			name:    "StatementBegin before Up",
+			src:     "-- +goose StatementBegin\nCREATE TABLE a (id INT);\n-- +goose StatementEnd\n",
+			wantErr: "unexpected StatementBegin annotation",
+		},
+		{
+			name:    "unsupported annotation",
+			src:     "-- +goose Up\n-- +goose NO TRANSACTION\nCREATE TABLE a (id INT);\n",
+			wantErr: "unsupported annotation",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			up, down, err := parse(tc.src)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("parse error %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if !reflect.DeepEqual(up, tc.wantUp) {
+				t.Errorf("up = %q, want %q", up, tc.wantUp)
+			}
+			if !reflect.DeepEqual(down, tc.wantDown) {
+				t.Errorf("down = %q, want %q", down, tc.wantDown)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	file := func(sql string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(sql)} }
+	valid := "-- +goose Up\nCREATE TABLE a (id INT);\n-- +goose Down\nDROP TABLE a;\n"
+
+	t.Run("ordered by version", func(t *testing.T) {
+		all, err := load(fstest.MapFS{
+			"010_later.sql":  file(valid),
+			"002_second.sql": file(valid),
+			"1_first.sql":    file(valid),
+			"README.md":      file("not a migration"),
+			"old/003_x.sql":  file(valid),
+		})
+		if err != nil {
+			t.Fatalf("load: %v", err)
+		}
+		var versions []int64
+		for _, m := range all {
+			versions = append(versions, m.Version)
+		}
+		if want := []int64{1, 2, 10}; !reflect.DeepEqual(versions, want) {
+			t.Errorf("versions = %v, want %v", versions, want)
+		}
+		if all[0].Name != "1_first.sql" || len(all[0].Up) != 1 || len(all[0].Down) != 1 {
+			t.Errorf("first migration = %+v", all[0])
+		}
+	})
+
+	invalid := []struct {
+		name    string
+		fsys    fstest.MapFS
+		wantErr string
+	}{
+		{
+			name:    "duplicate version",
+			fsys:    fstest.MapFS{"001_a.sql": file(valid), "1_b.sql": file(valid)},
+			wantErr: "share version 1",
+		},
+		{
+			name:    "no version",
+			fsys:    fstest.MapFS{"init.sql": file(valid)},
+			wantErr: `invalid migration file name "init.sql"`,
+		},
+		{
+			name:    "version not a number",
+			fsys:    fstest.MapFS{"v1_init.sql": file(valid)},
+			wantErr: `invalid migration file name "v1_init.sql"`,
+		},
+		{
+			name:    "version zero",
+			fsys:    fstest.MapFS{"000_init.sql": file(valid)},
+			wantErr: `invalid migration file name "000_init.sql"`,
+		},
+		{
+			name:    "invalid file",
+			fsys:    fstest.MapFS{"001_init.sql": file("CREATE TABLE a (id INT);\n")},
+			wantErr: "migration 001_init.sql: missing Up annotation",
+		},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := load(tc.fsys)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("load error %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+// TestLoadEmbedded checks the shipped migrations parse and that every
+// dialect has the same versions.
+func TestLoadEmbedded(t *testing.T) {
+	var want []int64
+	for _, d := range []dialect.Dialect{dialect.MySQL, dialect.Postgres, dialect.SQLite} {
+		all, err := Load(d)
+		if err != nil {
+			t.Fatalf("%s: %v", d, err)
+		}
+		var versions []int64
+		for _, m := range all {
+			if len(m.Up) == 0 || len(m.Down) == 0 {
+				t.Errorf("%s: migration %s lacks up or down statements", d, m.Name)
+			}
+			versions = append(versions, m.Version)
+		}
+		if want == nil {
+			want = versions
+		} else if !reflect.DeepEqual(versions, want) {
+			t.Errorf("%s versions = %v, want %v", d, versions, want)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+-- +goose Up
+
+-- Keeps updated_at current, the way MySQL's ON UPDATE CURRENT_TIMESTAMP does
+-- +goose StatementBegin
+CREATE FUNCTION set_updated_at() RETURNS trigger AS $$
+BEGIN
+    NEW.updated_at = now();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TABLE players (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    email VARCHAR(100) NOT NULL,
+    password_hash VARCHAR(255) NOT NULL,
+    account_balance NUMERIC(15, 2) DEFAULT 0.00,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ NULL DEFAULT NULL,
+    CONSTRAINT players_email_key UNIQUE (email),
+    CONSTRAINT chk_player_email_format CHECK (email ~ '^[^@]+@[^@]+\.[^@]+$')
+);
+
+CREATE TRIGGER players_updated_at BEFORE UPDATE ON players
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+CREATE TABLE tournaments (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    prize_pool NUMERIC(15, 2) NOT NULL,
+    start_date TIMESTAMPTZ NOT NULL,
+    end_date TIMESTAMPTZ NOT NULL,
+    prizes_distributed BOOLEAN DEFAULT FALSE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_valid_dates CHECK (end_date > start_date)
+);
+
+CREATE TRIGGER tournaments_updated_at BEFORE UPDATE ON tournaments
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+CREATE TABLE tournament_bets (
+    id SERIAL PRIMARY KEY,
+    player_id INT NOT NULL REFERENCES players(id),
+    tournament_id INT NOT NULL REFERENCES tournaments(id),
+    bet_amount NUMERIC(15, 2) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE tournament_results (
+    id SERIAL PRIMARY KEY,
+    tournament_id INT NOT NULL REFERENCES tournaments(id),
+    player_id INT NOT NULL REFERENCES players(id),
+    placement INT NOT NULL,
+    prize_amount NUMERIC(15, 2) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_tournament_player UNIQUE (tournament_id, player_id),
+    CONSTRAINT chk_valid_placement CHECK (placement BETWEEN 1 AND 3)
+);
+
+-- Prizes are distributed by the service, so unlike the MySQL schema there is
+-- no DistributePrizes procedure.
+
+CREATE VIEW player_rankings AS
+SELECT
+    id AS player_id,
+    name AS player_name,
+    account_balance,
+    DENSE_RANK() OVER (ORDER BY account_balance DESC) AS player_rank
+FROM players
+WHERE deleted_at IS NULL
+ORDER BY player_rank;
+
+CREATE INDEX idx_tournaments_dates ON tournaments(start_date, end_date);
+CREATE INDEX idx_players_balance ON players(account_balance DESC);
+CREATE INDEX idx_results_placement ON tournament_results(placement);
+CREATE INDEX idx_bets_tournament_player ON tournament_bets(tournament_id, player_id);
+CREATE INDEX idx_players_email ON players(email);
+CREATE INDEX idx_tournaments_name ON tournaments(name);
+CREATE INDEX idx_bets_created ON tournament_bets(created_at);
+CREATE INDEX idx_results_created ON tournament_results(created_at);
+
+-- +goose Down
+
+DROP VIEW IF EXISTS player_rankings;
+DROP TABLE IF EXISTS tournament_results;
+DROP TABLE IF EXISTS tournament_bets;
+DROP TABLE IF EXISTS tournaments;
+DROP TABLE IF EXISTS players;
+DROP FUNCTION IF EXISTS set_updated_at();
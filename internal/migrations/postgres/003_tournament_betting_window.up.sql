@@ -0,0 +1,17 @@
+-- +goose Up
+
+ALTER TABLE tournaments
+    ADD COLUMN betting_opens_at TIMESTAMPTZ NULL DEFAULT NULL,
+    ADD COLUMN betting_closes_at TIMESTAMPTZ NULL DEFAULT NULL,
+    ADD COLUMN late_registration_minutes INT NULL DEFAULT NULL,
+    ADD CONSTRAINT chk_betting_window CHECK (betting_opens_at IS NULL OR betting_closes_at IS NULL OR betting_opens_at < betting_closes_at),
+    ADD CONSTRAINT chk_late_registration CHECK (late_registration_minutes IS NULL OR late_registration_minutes >= 0);
+
+-- +goose Down
+
+ALTER TABLE tournaments
+    DROP CONSTRAINT chk_late_registration,
+    DROP CONSTRAINT chk_betting_window,
+    DROP COLUMN late_registration_minutes,
+    DROP COLUMN betting_closes_at,
+    DROP COLUMN betting_opens_at;
@@ -0,0 +1,29 @@
+-- +goose Up
+
+ALTER TABLE tournaments
+    ADD COLUMN pool_mode TEXT NOT NULL DEFAULT 'fixed',
+    ADD COLUMN guaranteed_prize_pool NUMERIC(15, 2) NOT NULL DEFAULT 0.00,
+    ADD COLUMN rake_percentage NUMERIC(5, 2) NOT NULL DEFAULT 0.00,
+    ADD COLUMN rake_collected NUMERIC(15, 2) NOT NULL DEFAULT 0.00,
+    ADD CONSTRAINT chk_pool_mode CHECK (pool_mode IN ('fixed', 'accumulating')),
+    ADD CONSTRAINT chk_guaranteed_prize_pool CHECK (guaranteed_prize_pool >= 0),
+    ADD CONSTRAINT chk_rake_percentage CHECK (rake_percentage BETWEEN 0 AND 100);
+
+UPDATE tournaments SET guaranteed_prize_pool = prize_pool;
+
+ALTER TABLE tournament_bets
+    ADD COLUMN rake_amount NUMERIC(15, 2) NOT NULL DEFAULT 0.00;
+
+-- +goose Down
+
+ALTER TABLE tournament_bets
+    DROP COLUMN rake_amount;
+
+ALTER TABLE tournaments
+    DROP CONSTRAINT chk_rake_percentage,
+    DROP CONSTRAINT chk_guaranteed_prize_pool,
+    DROP CONSTRAINT chk_pool_mode,
+    DROP COLUMN rake_collected,
+    DROP COLUMN rake_percentage,
+    DROP COLUMN guaranteed_prize_pool,
+    DROP COLUMN pool_mode;
@@ -0,0 +1,30 @@
+-- +goose Up
+
+CREATE TABLE player_limits (
+    id SERIAL PRIMARY KEY,
+    player_id INT NOT NULL REFERENCES players(id),
+    limit_type TEXT NOT NULL,
+    period TEXT NOT NULL,
+    amount NUMERIC(15, 2) NULL DEFAULT NULL,
+    pending_amount NUMERIC(15, 2) NULL DEFAULT NULL,
+    pending_effective_at TIMESTAMPTZ NULL DEFAULT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_player_limit UNIQUE (player_id, limit_type, period),
+    CONSTRAINT chk_limit_type CHECK (limit_type IN ('wager', 'loss')),
+    CONSTRAINT chk_limit_period CHECK (period IN ('daily', 'weekly', 'monthly')),
+    CONSTRAINT chk_limit_amount CHECK (amount IS NULL OR amount > 0),
+    CONSTRAINT chk_pending_limit_amount CHECK (pending_amount IS NULL OR pending_amount > 0)
+);
+
+CREATE TRIGGER player_limits_updated_at BEFORE UPDATE ON player_limits
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+CREATE INDEX idx_bets_player_created ON tournament_bets(player_id, created_at);
+CREATE INDEX idx_results_player_created ON tournament_results(player_id, created_at);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_results_player_created;
+DROP INDEX IF EXISTS idx_bets_player_created;
+DROP TABLE IF EXISTS player_limits;
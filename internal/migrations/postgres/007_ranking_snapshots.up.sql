@@ -0,0 +1,15 @@
+-- +goose Up
+
+CREATE TABLE ranking_snapshots (
+    taken_at TIMESTAMPTZ NOT NULL,
+    player_id INT NOT NULL REFERENCES players(id),
+    player_rank INT NOT NULL,
+    account_balance NUMERIC(15, 2) NOT NULL,
+    PRIMARY KEY (taken_at, player_id)
+);
+
+CREATE INDEX idx_ranking_snapshots_player ON ranking_snapshots(player_id, taken_at);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS ranking_snapshots;
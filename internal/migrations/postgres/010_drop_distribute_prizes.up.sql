@@ -0,0 +1,10 @@
+-- +goose Up
+
+-- Prizes are paid by the settlement service. Only the MySQL schema had the
+-- DistributePrizes procedure this version drops, so there is nothing to
+-- change here; the migration keeps the versions of every dialect in step.
+SELECT 1;
+
+-- +goose Down
+
+SELECT 1;
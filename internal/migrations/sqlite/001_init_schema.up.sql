@@ -0,0 +1,95 @@
+-- +goose Up
+
+-- Timestamps are stored as Unix microseconds, so they compare correctly
+-- whatever time zone they were written in. unixepoch('subsec') has
+-- millisecond precision.
+
+CREATE TABLE players (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(100) NOT NULL,
+    email VARCHAR(100) NOT NULL,
+    password_hash VARCHAR(255) NOT NULL,
+    account_balance REAL DEFAULT 0.00,
+    created_at TIMESTAMP DEFAULT (CAST(unixepoch('subsec') * 1000000 AS INTEGER)),
+    updated_at TIMESTAMP DEFAULT (CAST(unixepoch('subsec') * 1000000 AS INTEGER)),
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+    CONSTRAINT players_email_key UNIQUE (email),
+    CONSTRAINT chk_player_email_format CHECK (email LIKE '%_@_%._%' AND email NOT LIKE '%@%@%')
+);
+
+-- +goose StatementBegin
+CREATE TRIGGER players_updated_at AFTER UPDATE ON players
+FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE players SET updated_at = CAST(unixepoch('subsec') * 1000000 AS INTEGER) WHERE id = NEW.id;
+END;
+-- +goose StatementEnd
+
+CREATE TABLE tournaments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(100) NOT NULL,
+    prize_pool REAL NOT NULL,
+    start_date TIMESTAMP NOT NULL,
+    end_date TIMESTAMP NOT NULL,
+    prizes_distributed BOOLEAN DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT (CAST(unixepoch('subsec') * 1000000 AS INTEGER)),
+    updated_at TIMESTAMP DEFAULT (CAST(unixepoch('subsec') * 1000000 AS INTEGER)),
+    CONSTRAINT chk_valid_dates CHECK (end_date > start_date)
+);
+
+-- +goose StatementBegin
+CREATE TRIGGER tournaments_updated_at AFTER UPDATE ON tournaments
+FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE tournaments SET updated_at = CAST(unixepoch('subsec') * 1000000 AS INTEGER) WHERE id = NEW.id;
+END;
+-- +goose StatementEnd
+
+CREATE TABLE tournament_bets (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    player_id INTEGER NOT NULL REFERENCES players(id),
+    tournament_id INTEGER NOT NULL REFERENCES tournaments(id),
+    bet_amount REAL NOT NULL,
+    created_at TIMESTAMP DEFAULT (CAST(unixepoch('subsec') * 1000000 AS INTEGER))
+);
+
+CREATE TABLE tournament_results (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    tournament_id INTEGER NOT NULL REFERENCES tournaments(id),
+    player_id INTEGER NOT NULL REFERENCES players(id),
+    placement INTEGER NOT NULL,
+    prize_amount REAL NOT NULL,
+    created_at TIMESTAMP DEFAULT (CAST(unixepoch('subsec') * 1000000 AS INTEGER)),
+    CONSTRAINT unique_tournament_player UNIQUE (tournament_id, player_id),
+    CONSTRAINT chk_valid_placement CHECK (placement BETWEEN 1 AND 3)
+);
+
+-- Prizes are distributed by the service, so unlike the MySQL schema there is
+-- no DistributePrizes procedure.
+
+CREATE VIEW player_rankings AS
+SELECT
+    id AS player_id,
+    name AS player_name,
+    account_balance,
+    DENSE_RANK() OVER (ORDER BY account_balance DESC) AS player_rank
+FROM players
+WHERE deleted_at IS NULL
+ORDER BY player_rank;
+
+CREATE INDEX idx_tournaments_dates ON tournaments(start_date, end_date);
+CREATE INDEX idx_players_balance ON players(account_balance DESC);
+CREATE INDEX idx_results_placement ON tournament_results(placement);
+CREATE INDEX idx_bets_tournament_player ON tournament_bets(tournament_id, player_id);
+CREATE INDEX idx_players_email ON players(email);
+CREATE INDEX idx_tournaments_name ON tournaments(name);
+CREATE INDEX idx_bets_created ON tournament_bets(created_at);
+CREATE INDEX idx_results_created ON tournament_results(created_at);
+
+-- +goose Down
+
+DROP VIEW IF EXISTS player_rankings;
+DROP TABLE IF EXISTS tournament_results;
+DROP TABLE IF EXISTS tournament_bets;
+DROP TABLE IF EXISTS tournaments;
+DROP TABLE IF EXISTS players;
@@ -0,0 +1,20 @@
+-- +goose Up
+
+-- SQLite adds one column per statement, and a constraint only as part of a
+-- column; a column constraint may still check the other columns.
+ALTER TABLE tournaments ADD COLUMN min_bet REAL NULL DEFAULT NULL;
+ALTER TABLE tournaments ADD COLUMN max_bet REAL NULL DEFAULT NULL
+    CONSTRAINT chk_bet_range CHECK (min_bet IS NULL OR max_bet IS NULL OR min_bet <= max_bet);
+ALTER TABLE tournaments ADD COLUMN max_stake_per_player REAL NULL DEFAULT NULL;
+ALTER TABLE tournaments ADD COLUMN max_participants INTEGER NULL DEFAULT NULL
+    CONSTRAINT chk_max_participants CHECK (max_participants IS NULL OR max_participants > 0);
+ALTER TABLE tournaments ADD COLUMN entry_fee REAL NULL DEFAULT NULL
+    CONSTRAINT chk_entry_fee CHECK (entry_fee IS NULL OR entry_fee > 0);
+
+-- +goose Down
+
+ALTER TABLE tournaments DROP COLUMN entry_fee;
+ALTER TABLE tournaments DROP COLUMN max_participants;
+ALTER TABLE tournaments DROP COLUMN max_stake_per_player;
+ALTER TABLE tournaments DROP COLUMN max_bet;
+ALTER TABLE tournaments DROP COLUMN min_bet;
@@ -0,0 +1,13 @@
+-- +goose Up
+
+ALTER TABLE tournaments ADD COLUMN betting_opens_at TIMESTAMP NULL DEFAULT NULL;
+ALTER TABLE tournaments ADD COLUMN betting_closes_at TIMESTAMP NULL DEFAULT NULL
+    CONSTRAINT chk_betting_window CHECK (betting_opens_at IS NULL OR betting_closes_at IS NULL OR betting_opens_at < betting_closes_at);
+ALTER TABLE tournaments ADD COLUMN late_registration_minutes INTEGER NULL DEFAULT NULL
+    CONSTRAINT chk_late_registration CHECK (late_registration_minutes IS NULL OR late_registration_minutes >= 0);
+
+-- +goose Down
+
+ALTER TABLE tournaments DROP COLUMN late_registration_minutes;
+ALTER TABLE tournaments DROP COLUMN betting_closes_at;
+ALTER TABLE tournaments DROP COLUMN betting_opens_at;
@@ -0,0 +1,22 @@
+-- +goose Up
+
+ALTER TABLE tournaments ADD COLUMN pool_mode TEXT NOT NULL DEFAULT 'fixed'
+    CONSTRAINT chk_pool_mode CHECK (pool_mode IN ('fixed', 'accumulating'));
+ALTER TABLE tournaments ADD COLUMN guaranteed_prize_pool REAL NOT NULL DEFAULT 0.00
+    CONSTRAINT chk_guaranteed_prize_pool CHECK (guaranteed_prize_pool >= 0);
+ALTER TABLE tournaments ADD COLUMN rake_percentage REAL NOT NULL DEFAULT 0.00
+    CONSTRAINT chk_rake_percentage CHECK (rake_percentage BETWEEN 0 AND 100);
+ALTER TABLE tournaments ADD COLUMN rake_collected REAL NOT NULL DEFAULT 0.00;
+
+UPDATE tournaments SET guaranteed_prize_pool = prize_pool;
+
+ALTER TABLE tournament_bets ADD COLUMN rake_amount REAL NOT NULL DEFAULT 0.00;
+
+-- +goose Down
+
+ALTER TABLE tournament_bets DROP COLUMN rake_amount;
+
+ALTER TABLE tournaments DROP COLUMN rake_collected;
+ALTER TABLE tournaments DROP COLUMN rake_percentage;
+ALTER TABLE tournaments DROP COLUMN guaranteed_prize_pool;
+ALTER TABLE tournaments DROP COLUMN pool_mode;
@@ -0,0 +1,35 @@
+-- +goose Up
+
+CREATE TABLE player_limits (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    player_id INTEGER NOT NULL REFERENCES players(id),
+    limit_type TEXT NOT NULL,
+    period TEXT NOT NULL,
+    amount REAL NULL DEFAULT NULL,
+    pending_amount REAL NULL DEFAULT NULL,
+    pending_effective_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT (CAST(unixepoch('subsec') * 1000000 AS INTEGER)),
+    updated_at TIMESTAMP DEFAULT (CAST(unixepoch('subsec') * 1000000 AS INTEGER)),
+    CONSTRAINT unique_player_limit UNIQUE (player_id, limit_type, period),
+    CONSTRAINT chk_limit_type CHECK (limit_type IN ('wager', 'loss')),
+    CONSTRAINT chk_limit_period CHECK (period IN ('daily', 'weekly', 'monthly')),
+    CONSTRAINT chk_limit_amount CHECK (amount IS NULL OR amount > 0),
+    CONSTRAINT chk_pending_limit_amount CHECK (pending_amount IS NULL OR pending_amount > 0)
+);
+
+-- +goose StatementBegin
+CREATE TRIGGER player_limits_updated_at AFTER UPDATE ON player_limits
+FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE player_limits SET updated_at = CAST(unixepoch('subsec') * 1000000 AS INTEGER) WHERE id = NEW.id;
+END;
+-- +goose StatementEnd
+
+CREATE INDEX idx_bets_player_created ON tournament_bets(player_id, created_at);
+CREATE INDEX idx_results_player_created ON tournament_results(player_id, created_at);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_results_player_created;
+DROP INDEX IF EXISTS idx_bets_player_created;
+DROP TABLE IF EXISTS player_limits;
@@ -0,0 +1,50 @@
+-- +goose Up
+
+CREATE TABLE player_exclusions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    player_id INTEGER NOT NULL REFERENCES players(id),
+    duration TEXT NOT NULL,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT (CAST(unixepoch('subsec') * 1000000 AS INTEGER)),
+    CONSTRAINT chk_exclusion_duration CHECK (duration IN ('24h', '7d', '6m', 'permanent')),
+    CONSTRAINT chk_exclusion_period CHECK (ends_at IS NULL OR ends_at > starts_at)
+);
+
+CREATE INDEX idx_exclusions_player_ends ON player_exclusions(player_id, ends_at);
+
+-- SQLite cannot replace a view in place.
+DROP VIEW player_rankings;
+
+CREATE VIEW player_rankings AS
+SELECT 
+    id AS player_id,
+    name AS player_name,
+    account_balance,
+    DENSE_RANK() OVER (ORDER BY account_balance DESC) AS player_rank
+FROM players p
+WHERE deleted_at IS NULL
+  AND NOT EXISTS (
+      SELECT 1
+        FROM player_exclusions e
+       WHERE e.player_id = p.id
+         AND e.starts_at <= unixepoch('subsec') * 1000000
+         AND (e.ends_at IS NULL OR e.ends_at > unixepoch('subsec') * 1000000)
+  )
+ORDER BY player_rank;
+
+-- +goose Down
+
+DROP VIEW player_rankings;
+
+CREATE VIEW player_rankings AS
+SELECT 
+    id AS player_id,
+    name AS player_name,
+    account_balance,
+    DENSE_RANK() OVER (ORDER BY account_balance DESC) AS player_rank
+FROM players
+WHERE deleted_at IS NULL
+ORDER BY player_rank;
+
+DROP TABLE IF EXISTS player_exclusions;
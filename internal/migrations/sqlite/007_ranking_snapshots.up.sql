@@ -0,0 +1,15 @@
+-- +goose Up
+
+CREATE TABLE ranking_snapshots (
+    taken_at TIMESTAMP NOT NULL,
+    player_id INTEGER NOT NULL REFERENCES players(id),
+    player_rank INTEGER NOT NULL,
+    account_balance REAL NOT NULL,
+    PRIMARY KEY (taken_at, player_id)
+);
+
+CREATE INDEX idx_ranking_snapshots_player ON ranking_snapshots(player_id, taken_at);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS ranking_snapshots;
@@ -0,0 +1,50 @@
+-- +goose Up
+
+CREATE TABLE player_ratings (
+    player_id INTEGER PRIMARY KEY REFERENCES players(id),
+    rating REAL NOT NULL,
+    tournaments_played INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_player_ratings_rating ON player_ratings(rating DESC);
+
+CREATE TABLE rating_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    player_id INTEGER NOT NULL REFERENCES players(id),
+    tournament_id INTEGER NOT NULL REFERENCES tournaments(id),
+    placement INTEGER NOT NULL,
+    field_size INTEGER NOT NULL,
+    rating_before REAL NOT NULL,
+    rating_after REAL NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    CONSTRAINT unique_rating_player_tournament UNIQUE (player_id, tournament_id)
+);
+
+CREATE INDEX idx_rating_history_tournament ON rating_history(tournament_id);
+
+-- Rated players by skill, with the same visibility rules as player_rankings
+CREATE VIEW player_rating_rankings AS
+SELECT 
+    r.player_id,
+    p.name AS player_name,
+    r.rating,
+    r.tournaments_played,
+    DENSE_RANK() OVER (ORDER BY r.rating DESC) AS rating_rank
+FROM player_ratings r
+JOIN players p ON p.id = r.player_id
+WHERE p.deleted_at IS NULL
+  AND NOT EXISTS (
+      SELECT 1
+        FROM player_exclusions e
+       WHERE e.player_id = p.id
+         AND e.starts_at <= unixepoch('subsec') * 1000000
+         AND (e.ends_at IS NULL OR e.ends_at > unixepoch('subsec') * 1000000)
+  )
+ORDER BY rating_rank;
+
+-- +goose Down
+
+DROP VIEW IF EXISTS player_rating_rankings;
+DROP TABLE IF EXISTS rating_history;
+DROP TABLE IF EXISTS player_ratings;
@@ -0,0 +1,26 @@
+package models
+
+// TournamentStanding is a player's total bet in a tournament, the criterion
+// tournament placements are decided by.
+type TournamentStanding struct {
+	PlayerID   uint    `json:"player_id"`
+	PlayerName string  `json:"player_name"`
+	TotalBet   float64 `json:"total_bet"`
+}
+
+// LeaderboardEntry is a player's live position in a tournament.
+type LeaderboardEntry struct {
+	// 1-based row in the leaderboard
+	Position int `json:"position"`
+	// Placement shared by every player in the tie group (dense rank)
+	Placement  int     `json:"placement"`
+	PlayerID   uint    `json:"player_id"`
+	PlayerName string  `json:"player_name"`
+	TotalBet   float64 `json:"total_bet"`
+	// Number of players sharing the placement
+	TieGroupSize int `json:"tie_group_size"`
+	// Prize the player would receive if the tournament were settled now
+	ProjectedPrize float64 `json:"projected_prize"`
+	// Additional bet needed to draw level with the next placement up
+	GapToNext float64 `json:"gap_to_next"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+// Self-exclusion durations
+const (
+	ExclusionDuration24Hours   = "24h"
+	ExclusionDuration7Days     = "7d"
+	ExclusionDuration6Months   = "6m"
+	ExclusionDurationPermanent = "permanent"
+)
+
+// PlayerExclusion is a self-exclusion (or timeout) a player has placed on
+// themselves. While active the player cannot place bets and is hidden from
+// the rankings. Exclusions cannot be lifted early; timed ones simply expire.
+// swagger:model PlayerExclusion
+type PlayerExclusion struct {
+	// The unique identifier for the exclusion
+	// example: 1
+	ID uint `json:"id"`
+
+	// ID of the excluded player
+	// example: 123
+	PlayerID uint `json:"player_id"`
+
+	// Exclusion duration: "24h", "7d", "6m" or "permanent"
+	// example: 7d
+	Duration string `json:"duration"`
+
+	// When the exclusion started
+	// format: date-time
+	// example: 2023-09-01T10:15:00Z
+	StartsAt time.Time `json:"starts_at" swaggertype:"string" format:"date-time"`
+
+	// When the exclusion ends (never when null)
+	// format: date-time
+	// example: 2023-09-08T10:15:00Z
+	EndsAt *time.Time `json:"ends_at" swaggertype:"string" format:"date-time"`
+}
+
+// Active reports whether the exclusion is in force at now.
+func (e *PlayerExclusion) Active(now time.Time) bool {
+	return !now.Before(e.StartsAt) && (e.EndsAt == nil || now.Before(*e.EndsAt))
+}
+
+// ExclusionEnd returns when an exclusion of the given duration starting at
+// start ends, nil for permanent exclusions. ok is false for unknown durations.
+func ExclusionEnd(duration string, start time.Time) (end *time.Time, ok bool) {
+	var t time.Time
+	switch duration {
+	case ExclusionDuration24Hours:
+		t = start.Add(24 * time.Hour)
+	case ExclusionDuration7Days:
+		t = start.AddDate(0, 0, 7)
+	case ExclusionDuration6Months:
+		t = start.AddDate(0, 6, 0)
+	case ExclusionDurationPermanent:
+		return nil, true
+	default:
+		return nil, false
+	}
+	return &t, true
+}
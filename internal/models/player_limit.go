@@ -0,0 +1,119 @@
+package models
+
+import "time"
+
+// Responsible gambling limit types
+const (
+	// LimitTypeWager caps the total amount bet in a period.
+	LimitTypeWager = "wager"
+	// LimitTypeLoss caps the net loss (bets minus prizes won) in a period.
+	LimitTypeLoss = "loss"
+)
+
+// Responsible gambling limit periods. Periods are calendar based in UTC,
+// weeks start on Monday.
+const (
+	LimitPeriodDaily   = "daily"
+	LimitPeriodWeekly  = "weekly"
+	LimitPeriodMonthly = "monthly"
+)
+
+// PlayerLimit is a responsible gambling limit a player has set on themselves.
+// Lowering a limit applies immediately, raising or removing it only after a
+// cooling-off period, during which the new value is kept as pending.
+// swagger:model PlayerLimit
+type PlayerLimit struct {
+	// ID of the player the limit belongs to
+	// example: 123
+	PlayerID uint `json:"player_id"`
+
+	// Limit type: "wager" or "loss"
+	// example: wager
+	Type string `json:"type"`
+
+	// Limit period: "daily", "weekly" or "monthly"
+	// example: daily
+	Period string `json:"period"`
+
+	// Limit currently in force (no limit when null)
+	// example: 100.00
+	Amount *float64 `json:"amount"`
+
+	// Limit that replaces the current one once the cooling-off period ends
+	// (a pending removal when null and pending_effective_at is set)
+	// example: 250.00
+	PendingAmount *float64 `json:"pending_amount"`
+
+	// When the pending limit takes effect
+	// format: date-time
+	// example: 2023-09-02T10:15:00Z
+	PendingEffectiveAt *time.Time `json:"pending_effective_at,omitempty" swaggertype:"string" format:"date-time"`
+
+	// Amount counted against the limit in the current period
+	// example: 40.00
+	Used float64 `json:"used"`
+}
+
+// Resolve promotes the pending limit to the current one when its cooling-off
+// period is over at now.
+func (l *PlayerLimit) Resolve(now time.Time) {
+	if l.PendingEffectiveAt == nil || now.Before(*l.PendingEffectiveAt) {
+		return
+	}
+	l.Amount = l.PendingAmount
+	l.PendingAmount = nil
+	l.PendingEffectiveAt = nil
+}
+
+// Change requests amount (nil removes the limit) at now. Decreases take effect
+// immediately and cancel any pending change, increases are scheduled to take
+// effect after coolingOff.
+func (l *PlayerLimit) Change(amount *float64, now time.Time, coolingOff time.Duration) {
+	l.Resolve(now)
+
+	switch {
+	case amount != nil && (l.Amount == nil || *amount <= *l.Amount):
+		l.Amount = amount
+		l.PendingAmount = nil
+		l.PendingEffectiveAt = nil
+	case amount == nil && l.Amount == nil:
+		l.PendingAmount = nil
+		l.PendingEffectiveAt = nil
+	default:
+		effectiveAt := now.Add(coolingOff)
+		l.PendingAmount = amount
+		l.PendingEffectiveAt = &effectiveAt
+	}
+}
+
+// Empty reports whether the limit neither restricts nor is about to restrict
+// the player, so it no longer needs to be stored.
+func (l *PlayerLimit) Empty() bool {
+	return l.Amount == nil && l.PendingEffectiveAt == nil
+}
+
+// LimitPeriodStart returns the start of the period containing now.
+func LimitPeriodStart(period string, now time.Time) time.Time {
+	now = now.UTC()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch period {
+	case LimitPeriodWeekly:
+		sinceMonday := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -sinceMonday)
+	case LimitPeriodMonthly:
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+// ValidLimitType reports whether t is a known limit type.
+func ValidLimitType(t string) bool {
+	return t == LimitTypeWager || t == LimitTypeLoss
+}
+
+// ValidLimitPeriod reports whether p is a known limit period.
+func ValidLimitPeriod(p string) bool {
+	return p == LimitPeriodDaily || p == LimitPeriodWeekly || p == LimitPeriodMonthly
+}
@@ -5,4 +5,8 @@ type PlayerRanking struct {
     PlayerName     string  `json:"player_name" db:"player_name"`
     AccountBalance float64 `json:"account_balance" db:"account_balance"`
     Rank           int     `json:"rank" db:"player_rank"`
+    // Places moved up since yesterday / last week (negative when dropped),
+    // null when the player was not ranked then
+    RankChangeDay  *int    `json:"rank_change_day"`
+    RankChangeWeek *int    `json:"rank_change_week"`
 }
\ No newline at end of file
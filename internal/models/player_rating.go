@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// PlayerRating is a player's skill rating.
+type PlayerRating struct {
+	PlayerID          uint    `json:"player_id"`
+	PlayerName        string  `json:"player_name"`
+	Rating            float64 `json:"rating"`
+	TournamentsPlayed int     `json:"tournaments_played"`
+	// Dense rank among rated players, nil when not ranked (unrated,
+	// self-excluded or deleted)
+	Rank      *int       `json:"rank"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+// RatingChange is how one settled tournament changed a player's rating.
+type RatingChange struct {
+	PlayerID     uint      `json:"player_id"`
+	TournamentID uint      `json:"tournament_id"`
+	Placement    int       `json:"placement"`
+	FieldSize    int       `json:"field_size"`
+	RatingBefore float64   `json:"rating_before"`
+	RatingAfter  float64   `json:"rating_after"`
+	CreatedAt    time.Time `json:"created_at"`
+}
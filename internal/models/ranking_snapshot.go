@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// RankingSnapshot is a player's place in player_rankings at a point in time.
+type RankingSnapshot struct {
+	PlayerID       uint      `json:"player_id"`
+	TakenAt        time.Time `json:"taken_at"`
+	Rank           int       `json:"rank"`
+	AccountBalance float64   `json:"account_balance"`
+}
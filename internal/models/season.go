@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+// Season groups tournaments whose placements earn points towards a season
+// leaderboard.
+type Season struct {
+	ID                uint           `json:"id"`
+	Name              string         `json:"name"`
+	StartDate         time.Time      `json:"start_date"`
+	EndDate           time.Time      `json:"end_date"`
+	PrizePool         float64        `json:"prize_pool"`
+	PrizesDistributed bool           `json:"prizes_distributed"`
+	PointsTable       []SeasonPoints `json:"points_table"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+}
+
+// SeasonPoints is the number of points a tournament placement is worth.
+type SeasonPoints struct {
+	Placement int `json:"placement"`
+	Points    int `json:"points"`
+}
+
+// DefaultSeasonPoints is the points table used when a season is created
+// without one.
+var DefaultSeasonPoints = []SeasonPoints{
+	{Placement: 1, Points: 10},
+	{Placement: 2, Points: 6},
+	{Placement: 3, Points: 4},
+}
+
+// SeasonStanding is a player's position in a season. Players with equal
+// points are ordered by wins, then by who reached their total first.
+type SeasonStanding struct {
+	Placement         int    `json:"placement"`
+	PlayerID          uint   `json:"player_id"`
+	PlayerName        string `json:"player_name"`
+	Points            int    `json:"points"`
+	Wins              int    `json:"wins"`
+	TournamentsPlaced int    `json:"tournaments_placed"`
+	// When the player last scored, i.e. reached their current total
+	AchievedAt *time.Time `json:"achieved_at"`
+}
+
+// SeasonResult is a season prize paid to a player.
+type SeasonResult struct {
+	ID          uint      `json:"id"`
+	SeasonID    uint      `json:"season_id"`
+	PlayerID    uint      `json:"player_id"`
+	Placement   int       `json:"placement"`
+	PrizeAmount float64   `json:"prize_amount"`
+	CreatedAt   time.Time `json:"created_at"`
+}
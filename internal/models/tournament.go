@@ -16,11 +16,30 @@ type Tournament struct {
 	// example: World Championship
 	Name      string    `json:"name"`
 	
-	// Total prize pool in USD
+	// Current prize pool in USD (grows with every bet in accumulating mode)
 	// required: true
 	// minimum: 0
 	// example: 100000.00
 	PrizePool float64   `json:"prize_pool"`
+
+	// How the prize pool is funded: "fixed" or "accumulating"
+	// example: fixed
+	PoolMode string `json:"pool_mode"`
+
+	// Prize pool guaranteed by the house regardless of bets
+	// minimum: 0
+	// example: 50000.00
+	GuaranteedPrizePool float64 `json:"guaranteed_prize_pool"`
+
+	// Percentage of every bet kept by the house in accumulating mode
+	// minimum: 0
+	// maximum: 100
+	// example: 10
+	RakePercentage float64 `json:"rake_percentage"`
+
+	// Total rake booked by the house so far
+	// example: 1250.00
+	RakeCollected float64 `json:"rake_collected"`
 	
 	// Start date/time of the tournament
     // required: true
@@ -33,6 +52,50 @@ type Tournament struct {
     // format: date-time
     // example: 2023-09-05T18:00:00Z
     EndDate time.Time `json:"end_date" swaggertype:"string" format:"date-time"`
+
+	// Smallest amount accepted for a single bet (no limit when null)
+	// example: 10.00
+	MinBet *float64 `json:"min_bet,omitempty"`
+
+	// Largest amount accepted for a single bet (no limit when null)
+	// example: 500.00
+	MaxBet *float64 `json:"max_bet,omitempty"`
+
+	// Maximum total a single player may stake in the tournament
+	// example: 2000.00
+	MaxStakePerPlayer *float64 `json:"max_stake_per_player,omitempty"`
+
+	// Maximum number of distinct players that may place bets
+	// example: 100
+	MaxParticipants *int `json:"max_participants,omitempty"`
+
+	// Fixed entry fee; when set every player enters once by betting exactly this amount
+	// example: 50.00
+	EntryFee *float64 `json:"entry_fee,omitempty"`
+
+	// Time from which bets are accepted (no lower bound when null)
+	// format: date-time
+	// example: 2023-08-31T15:00:00Z
+	BettingOpensAt *time.Time `json:"betting_opens_at,omitempty" swaggertype:"string" format:"date-time"`
+
+	// Time after which bets are rejected (end_date when null)
+	// format: date-time
+	// example: 2023-09-05T18:00:00Z
+	BettingClosesAt *time.Time `json:"betting_closes_at,omitempty" swaggertype:"string" format:"date-time"`
+
+	// Minutes after start_date during which new players may still join
+	// (new players may join until betting closes when null)
+	// example: 30
+	LateRegistrationMinutes *int `json:"late_registration_minutes,omitempty"`
+
+	// Season the tournament counts towards (none when null)
+	// example: 1
+	SeasonID *uint `json:"season_id,omitempty"`
+
+	// Whether the prizes have been paid out
+	// readOnly: true
+	// example: false
+	PrizesDistributed bool `json:"prizes_distributed"`
     
     // Creation timestamp
     // readOnly: true
@@ -44,4 +107,30 @@ type Tournament struct {
 	// readOnly: true
 	// example: 2023-08-28T14:45:00Z
 	UpdatedAt time.Time `json:"updated_at"`
-}
\ No newline at end of file
+}
+
+// Prize pool modes
+const (
+	// PoolModeFixed pays out the prize pool set when the tournament was created.
+	PoolModeFixed = "fixed"
+	// PoolModeAccumulating pays out the guaranteed pool plus every bet after rake.
+	PoolModeAccumulating = "accumulating"
+)
+
+// BettingCloseTime returns the moment the tournament stops accepting bets.
+func (t *Tournament) BettingCloseTime() time.Time {
+	if t.BettingClosesAt != nil {
+		return *t.BettingClosesAt
+	}
+	return t.EndDate
+}
+
+// RegistrationCloseTime returns the moment new participants can no longer
+// join, or nil when they may join for as long as betting is open.
+func (t *Tournament) RegistrationCloseTime() *time.Time {
+	if t.LateRegistrationMinutes == nil {
+		return nil
+	}
+	closes := t.StartDate.Add(time.Duration(*t.LateRegistrationMinutes) * time.Minute)
+	return &closes
+}
@@ -26,6 +26,11 @@ type TournamentBet struct {
 	// minimum: 0.01
 	// example: 50.00
 	BetAmount    float64    `json:"bet_amount"`
+
+	// Part of the bet kept by the house as rake
+	// readOnly: true
+	// example: 5.00
+	RakeAmount   float64    `json:"rake_amount"`
 	
 	// Timestamp when the bet was placed
 	// readOnly: true
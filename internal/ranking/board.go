@@ -0,0 +1,334 @@
+package ranking
+
+import "slices"
+
+// Item is a player's position on a Board.
+type Item struct {
+	PlayerID uint
+	// Score in cents
+	Score int64
+	// Dense rank: players with equal scores share it
+	Rank int
+	// 1-based position in the board order (ties ordered by player ID)
+	Position int
+	// Number of players sharing the score
+	GroupSize int
+	// Score of the group ranked right above, equal to Score for rank 1
+	NextScore int64
+}
+
+// Board keeps players sorted by score, highest first. It is a treap over the
+// distinct scores, each node holding the players with that score, so rank
+// lookups, updates and the start of a page are O(log n). Board is not safe
+// for concurrent use.
+type Board struct {
+	root   *node
+	scores map[uint]int64
+	seed   uint64
+}
+
+type node struct {
+	score    int64
+	members  []uint
+	priority uint64
+	// left holds higher scores, so an in-order walk is descending
+	left, right *node
+	// distinct scores and players in the subtree
+	keys, count int
+}
+
+func NewBoard() *Board {
+	return &Board{scores: make(map[uint]int64), seed: 0x9e3779b97f4a7c15}
+}
+
+// Len returns the number of players on the board.
+func (b *Board) Len() int {
+	return len(b.scores)
+}
+
+// Score returns the player's score.
+func (b *Board) Score(playerID uint) (int64, bool) {
+	s, ok := b.scores[playerID]
+	return s, ok
+}
+
+// Set puts the player on the board with the given score.
+func (b *Board) Set(playerID uint, score int64) {
+	if old, ok := b.scores[playerID]; ok {
+		if old == score {
+			return
+		}
+		b.update(old, func(n *node) { n.members = remove(n.members, playerID) })
+	}
+	b.scores[playerID] = score
+	b.update(score, func(n *node) { n.members = insert(n.members, playerID) })
+}
+
+// Add changes the player's score by delta, adding the player with score delta
+// when not on the board yet.
+func (b *Board) Add(playerID uint, delta int64) {
+	b.Set(playerID, b.scores[playerID]+delta)
+}
+
+// AddExisting changes the score of a player already on the board and reports
+// whether the player was there.
+func (b *Board) AddExisting(playerID uint, delta int64) bool {
+	old, ok := b.scores[playerID]
+	if ok {
+		b.Set(playerID, old+delta)
+	}
+	return ok
+}
+
+// Remove takes the player off the board.
+func (b *Board) Remove(playerID uint) {
+	old, ok := b.scores[playerID]
+	if !ok {
+		return
+	}
+	delete(b.scores, playerID)
+	b.update(old, func(n *node) { n.members = remove(n.members, playerID) })
+}
+
+// Get returns the player's position.
+func (b *Board) Get(playerID uint) (Item, bool) {
+	score, ok := b.scores[playerID]
+	if !ok {
+		return Item{}, false
+	}
+
+	keys, count := above(b.root, score)
+	n := find(b.root, score)
+	idx, _ := slices.BinarySearch(n.members, playerID)
+
+	next := score
+	if keys > 0 {
+		next = nextHigher(b.root, score)
+	}
+
+	return Item{
+		PlayerID:  playerID,
+		Score:     score,
+		Rank:      keys + 1,
+		Position:  count + idx + 1,
+		GroupSize: len(n.members),
+		NextScore: next,
+	}, true
+}
+
+// Page returns up to limit players starting at the 0-based offset.
+func (b *Board) Page(offset, limit int) []Item {
+	items := []Item{}
+	if offset < 0 || limit <= 0 || offset >= b.Len() {
+		return items
+	}
+
+	w := walker{root: b.root, skip: offset, limit: limit, position: offset, out: items}
+	w.walk(b.root)
+	return w.out
+}
+
+// walker collects a page with an in-order walk, skipping whole subtrees that
+// lie before the offset.
+type walker struct {
+	root                  *node
+	skip, limit, position int
+	rank                  int
+	prev                  *node
+	out                   []Item
+}
+
+func (w *walker) walk(n *node) {
+	if n == nil || len(w.out) == w.limit {
+		return
+	}
+
+	if w.skip >= n.left.size() {
+		w.skip -= n.left.size()
+	} else {
+		w.walk(n.left)
+	}
+	if len(w.out) == w.limit {
+		return
+	}
+
+	if w.skip >= len(n.members) {
+		w.skip -= len(n.members)
+	} else {
+		w.visit(n)
+	}
+
+	w.walk(n.right)
+}
+
+func (w *walker) visit(n *node) {
+	var next int64
+	if w.prev == nil {
+		// First group of the page: rank it against the whole tree.
+		keys, _ := above(w.root, n.score)
+		w.rank = keys + 1
+		next = n.score
+		if keys > 0 {
+			next = nextHigher(w.root, n.score)
+		}
+	} else {
+		w.rank++
+		next = w.prev.score
+	}
+	w.prev = n
+
+	for _, id := range n.members[w.skip:] {
+		if len(w.out) == w.limit {
+			break
+		}
+		w.position++
+		w.out = append(w.out, Item{
+			PlayerID:  id,
+			Score:     n.score,
+			Rank:      w.rank,
+			Position:  w.position,
+			GroupSize: len(n.members),
+			NextScore: next,
+		})
+	}
+	w.skip = 0
+}
+
+// update cuts the node holding score out of the tree (creating it if needed),
+// lets fn change its members and puts it back, dropping it when empty.
+func (b *Board) update(score int64, fn func(n *node)) {
+	higher, rest := split(b.root, score)
+	n, lower := split(rest, score-1)
+	if n == nil {
+		n = &node{score: score, priority: b.nextPriority()}
+	}
+
+	fn(n)
+	if len(n.members) == 0 {
+		n = nil
+	} else {
+		n.recalc()
+	}
+
+	b.root = merge(merge(higher, n), lower)
+}
+
+// nextPriority is a splitmix64 step; treap priorities only need to be well
+// spread, not unpredictable.
+func (b *Board) nextPriority() uint64 {
+	b.seed += 0x9e3779b97f4a7c15
+	z := b.seed
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+func (n *node) size() int {
+	if n == nil {
+		return 0
+	}
+	return n.count
+}
+
+func (n *node) distinct() int {
+	if n == nil {
+		return 0
+	}
+	return n.keys
+}
+
+func (n *node) recalc() {
+	n.keys = n.left.distinct() + 1 + n.right.distinct()
+	n.count = n.left.size() + len(n.members) + n.right.size()
+}
+
+// split divides t into the nodes scoring above score and the rest.
+func split(t *node, score int64) (*node, *node) {
+	if t == nil {
+		return nil, nil
+	}
+	if t.score > score {
+		l, r := split(t.right, score)
+		t.right = l
+		t.recalc()
+		return t, r
+	}
+	l, r := split(t.left, score)
+	t.left = r
+	t.recalc()
+	return l, t
+}
+
+// merge joins two treaps where every score in a is above every score in b.
+func merge(a, b *node) *node {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if a.priority > b.priority {
+		a.right = merge(a.right, b)
+		a.recalc()
+		return a
+	}
+	b.left = merge(a, b.left)
+	b.recalc()
+	return b
+}
+
+func find(t *node, score int64) *node {
+	for t != nil && t.score != score {
+		if score > t.score {
+			t = t.left
+		} else {
+			t = t.right
+		}
+	}
+	return t
+}
+
+// above returns how many distinct scores and players rank above score.
+func above(t *node, score int64) (keys, count int) {
+	for t != nil {
+		if t.score > score {
+			keys += t.left.distinct() + 1
+			count += t.left.size() + len(t.members)
+			t = t.right
+		} else {
+			t = t.left
+		}
+	}
+	return keys, count
+}
+
+// nextHigher returns the lowest score above score. Callers make sure there is
+// one.
+func nextHigher(t *node, score int64) int64 {
+	var next int64
+	for t != nil {
+		if t.score > score {
+			next = t.score
+			t = t.right
+		} else {
+			t = t.left
+		}
+	}
+	return next
+}
+
+func insert(ids []uint, id uint) []uint {
+	i, found := slices.BinarySearch(ids, id)
+	if found {
+		return ids
+	}
+	return slices.Insert(ids, i, id)
+}
+
+func remove(ids []uint, id uint) []uint {
+	i, found := slices.BinarySearch(ids, id)
+	if !found {
+		return ids
+	}
+	return slices.Delete(ids, i, i+1)
+}
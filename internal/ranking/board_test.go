@@ -0,0 +1,194 @@
+package ranking
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// sortedItems is the reference the board is checked against: every player
+// sorted by score, highest first, and by ID within a score, ranked by brute
+// force.
+func sortedItems(scores map[uint]int64) []Item {
+	items := make([]Item, 0, len(scores))
+	for id, score := range scores {
+		items = append(items, Item{PlayerID: id, Score: score})
+	}
+	slices.SortFunc(items, func(a, b Item) int {
+		if a.Score != b.Score {
+			if a.Score > b.Score {
+				return -1
+			}
+			return 1
+		}
+		return int(a.PlayerID) - int(b.PlayerID)
+	})
+
+	groups := make(map[int64]int)
+	for _, it := range items {
+		groups[it.Score]++
+	}
+	rank := 0
+	for i := range items {
+		if i == 0 || items[i].Score != items[i-1].Score {
+			rank++
+		}
+		items[i].Rank = rank
+		items[i].Position = i + 1
+		items[i].GroupSize = groups[items[i].Score]
+		items[i].NextScore = items[i].Score
+		for j := i - 1; j >= 0; j-- {
+			if items[j].Score != items[i].Score {
+				items[i].NextScore = items[j].Score
+				break
+			}
+		}
+	}
+	return items
+}
+
+// checkTreap verifies the tree is ordered by score, heap-ordered by
+// priority, and that the subtree counts are right.
+func checkTreap(t *testing.T, n *node) (keys, count int) {
+	t.Helper()
+
+	if n == nil {
+		return 0, 0
+	}
+	if len(n.members) == 0 {
+		t.Fatalf("node %d has no members", n.score)
+	}
+	if !slices.IsSorted(n.members) {
+		t.Fatalf("node %d members %v are not sorted", n.score, n.members)
+	}
+	for _, child := range []*node{n.left, n.right} {
+		if child != nil && child.priority > n.priority {
+			t.Fatalf("node %d has a child with a higher priority", n.score)
+		}
+	}
+	if n.left != nil && n.left.score <= n.score {
+		t.Fatalf("left child %d of %d does not score higher", n.left.score, n.score)
+	}
+	if n.right != nil && n.right.score >= n.score {
+		t.Fatalf("right child %d of %d does not score lower", n.right.score, n.score)
+	}
+
+	lk, lc := checkTreap(t, n.left)
+	rk, rc := checkTreap(t, n.right)
+	keys, count = lk+1+rk, lc+len(n.members)+rc
+	if n.keys != keys || n.count != count {
+		t.Fatalf("node %d counts %d keys and %d players, want %d and %d", n.score, n.keys, n.count, keys, count)
+	}
+	return keys, count
+}
+
+func checkBoard(t *testing.T, b *Board, scores map[uint]int64, rng *rand.Rand) {
+	t.Helper()
+
+	if _, count := checkTreap(t, b.root); count != len(scores) || b.Len() != len(scores) {
+		t.Fatalf("board holds %d players (Len %d), want %d", count, b.Len(), len(scores))
+	}
+
+	want := sortedItems(scores)
+	for _, w := range want {
+		got, ok := b.Get(w.PlayerID)
+		if !ok || got != w {
+			t.Fatalf("Get(%d) = %+v, %t; want %+v", w.PlayerID, got, ok, w)
+		}
+	}
+	if _, ok := b.Get(0); ok {
+		t.Fatal("Get found a player never on the board")
+	}
+
+	if got := b.Page(0, len(want)+1); !slices.Equal(got, want) {
+		t.Fatalf("full page = %+v, want %+v", got, want)
+	}
+	for range 5 {
+		offset, limit := rng.IntN(len(want)+2), 1+rng.IntN(10)
+		end := min(offset+limit, len(want))
+		var page []Item
+		if offset < len(want) {
+			page = want[offset:end]
+		}
+		if got := b.Page(offset, limit); !slices.Equal(got, page) {
+			t.Fatalf("Page(%d, %d) = %+v, want %+v", offset, limit, got, page)
+		}
+	}
+}
+
+// TestBoardAgainstSortedSlice applies random operations to a board and to a
+// plain map of scores and compares every position after each one. Scores
+// are drawn from a small range so ties are common.
+func TestBoardAgainstSortedSlice(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	b := NewBoard()
+	scores := make(map[uint]int64)
+
+	for i := range 2000 {
+		id := uint(1 + rng.IntN(60))
+		switch op := rng.IntN(10); {
+		case op < 4:
+			score := int64(rng.IntN(15))
+			b.Set(id, score)
+			scores[id] = score
+		case op < 6:
+			delta := int64(rng.IntN(7) - 3)
+			b.Add(id, delta)
+			scores[id] += delta
+		case op < 8:
+			delta := int64(rng.IntN(7) - 3)
+			_, ok := scores[id]
+			if got := b.AddExisting(id, delta); got != ok {
+				t.Fatalf("step %d: AddExisting(%d) = %t, want %t", i, id, got, ok)
+			}
+			if ok {
+				scores[id] += delta
+			}
+		default:
+			b.Remove(id)
+			delete(scores, id)
+		}
+
+		for id, want := range scores {
+			if got, ok := b.Score(id); !ok || got != want {
+				t.Fatalf("step %d: Score(%d) = %d, %t; want %d", i, id, got, ok, want)
+			}
+		}
+		checkBoard(t, b, scores, rng)
+	}
+}
+
+func TestBoardTies(t *testing.T) {
+	b := NewBoard()
+	b.Set(3, 500)
+	b.Set(1, 500)
+	b.Set(2, 300)
+	b.Set(4, 300)
+	b.Set(5, 100)
+
+	want := []Item{
+		{PlayerID: 1, Score: 500, Rank: 1, Position: 1, GroupSize: 2, NextScore: 500},
+		{PlayerID: 3, Score: 500, Rank: 1, Position: 2, GroupSize: 2, NextScore: 500},
+		{PlayerID: 2, Score: 300, Rank: 2, Position: 3, GroupSize: 2, NextScore: 500},
+		{PlayerID: 4, Score: 300, Rank: 2, Position: 4, GroupSize: 2, NextScore: 500},
+		{PlayerID: 5, Score: 100, Rank: 3, Position: 5, GroupSize: 1, NextScore: 300},
+	}
+	if got := b.Page(0, 10); !slices.Equal(got, want) {
+		t.Fatalf("page = %+v, want %+v", got, want)
+	}
+
+	// Breaking the top tie moves player 3 down a rank, and ranks stay
+	// dense.
+	b.Add(3, -200)
+	if got, _ := b.Get(3); got.Rank != 2 || got.GroupSize != 3 || got.Position != 3 {
+		t.Errorf("player 3 after dropping = %+v", got)
+	}
+	if got, _ := b.Get(5); got.Rank != 3 {
+		t.Errorf("player 5 rank = %d, want 3", got.Rank)
+	}
+
+	b.Remove(1)
+	if got, _ := b.Get(3); got.Rank != 1 || got.NextScore != 300 {
+		t.Errorf("player 3 after the leader left = %+v", got)
+	}
+}
@@ -0,0 +1,139 @@
+package ranking
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Mismatch reasons
+const (
+	MismatchMissing    = "missing"
+	MismatchUnexpected = "unexpected"
+	MismatchScore      = "score"
+	MismatchRank       = "rank"
+)
+
+// Mismatch is a difference between a cached board and the database.
+type Mismatch struct {
+	// "global" or "tournament:<id>"
+	Board    string  `json:"board"`
+	PlayerID uint    `json:"player_id"`
+	Reason   string  `json:"reason"`
+	Expected float64 `json:"expected_score"`
+	Actual   float64 `json:"actual_score"`
+	// Dense ranks, 0 when the player is missing on that side
+	ExpectedRank int `json:"expected_rank"`
+	ActualRank   int `json:"actual_rank"`
+}
+
+// Report is the outcome of a consistency check.
+type Report struct {
+	CheckedAt      time.Time  `json:"checked_at"`
+	LastRebuildAt  time.Time  `json:"last_rebuild_at"`
+	Consistent     bool       `json:"consistent"`
+	PlayersChecked int        `json:"players_checked"`
+	BoardsChecked  int        `json:"boards_checked"`
+	MismatchCount  int        `json:"mismatch_count"`
+	Mismatches     []Mismatch `json:"mismatches"`
+}
+
+// Verify compares the cached boards with the player_rankings view and the
+// tournament standings in the database. Only the first mismatches are listed;
+// MismatchCount has the total.
+func (s *Service) Verify(ctx context.Context) (Report, error) {
+	rankings, err := s.players.GetRankings(ctx)
+	if err != nil {
+		return Report{}, fmt.Errorf("failed to load rankings: %w", err)
+	}
+
+	standings, err := s.tournaments.GetAllStandings(ctx)
+	if err != nil {
+		return Report{}, fmt.Errorf("failed to load standings: %w", err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	report := Report{
+		CheckedAt:     s.clock.Now(),
+		LastRebuildAt: s.refreshedAt,
+		Mismatches:    []Mismatch{},
+	}
+
+	expected := make(map[uint]expectedScore, len(rankings))
+	for _, r := range rankings {
+		expected[r.PlayerID] = expectedScore{cents(r.AccountBalance), r.Rank}
+	}
+	report.compare("global", s.global, expected)
+
+	for id, rows := range standings {
+		// Standings come ordered by total, so dense ranks follow from the
+		// order.
+		expected := make(map[uint]expectedScore, len(rows))
+		rank := 0
+		for i, st := range rows {
+			if i == 0 || st.TotalBet != rows[i-1].TotalBet {
+				rank++
+			}
+			expected[st.PlayerID] = expectedScore{cents(st.TotalBet), rank}
+		}
+
+		b, ok := s.boards[id]
+		if !ok {
+			b = NewBoard()
+		}
+		report.compare(fmt.Sprintf("tournament:%d", id), b, expected)
+	}
+
+	for id, b := range s.boards {
+		if _, ok := standings[id]; !ok && b.Len() > 0 {
+			report.compare(fmt.Sprintf("tournament:%d", id), b, nil)
+		}
+	}
+
+	report.Consistent = report.MismatchCount == 0
+	return report, nil
+}
+
+type expectedScore struct {
+	score int64
+	rank  int
+}
+
+func (r *Report) compare(name string, b *Board, expected map[uint]expectedScore) {
+	r.BoardsChecked++
+	r.PlayersChecked += len(expected)
+
+	for id, want := range expected {
+		got, ok := b.Get(id)
+		switch {
+		case !ok:
+			r.add(Mismatch{Board: name, PlayerID: id, Reason: MismatchMissing,
+				Expected: amount(want.score), ExpectedRank: want.rank})
+		case got.Score != want.score:
+			r.add(Mismatch{Board: name, PlayerID: id, Reason: MismatchScore,
+				Expected: amount(want.score), Actual: amount(got.Score),
+				ExpectedRank: want.rank, ActualRank: got.Rank})
+		case got.Rank != want.rank:
+			r.add(Mismatch{Board: name, PlayerID: id, Reason: MismatchRank,
+				Expected: amount(want.score), Actual: amount(got.Score),
+				ExpectedRank: want.rank, ActualRank: got.Rank})
+		}
+	}
+
+	for id, score := range b.scores {
+		if _, ok := expected[id]; !ok {
+			got, _ := b.Get(id)
+			r.add(Mismatch{Board: name, PlayerID: id, Reason: MismatchUnexpected,
+				Actual: amount(score), ActualRank: got.Rank})
+		}
+	}
+}
+
+func (r *Report) add(m Mismatch) {
+	r.MismatchCount++
+	if len(r.Mismatches) < maxMismatches {
+		r.Mismatches = append(r.Mismatches, m)
+	}
+}
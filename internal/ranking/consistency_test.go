@@ -0,0 +1,156 @@
+package ranking
+
+import (
+	"context"
+	"igaming/internal/clock"
+	"igaming/internal/models"
+	"testing"
+	"time"
+)
+
+// source serves fixed rankings and standings in place of the database.
+type source struct {
+	rankings  []models.PlayerRanking
+	standings map[uint][]models.TournamentStanding
+}
+
+func (s *source) GetRankings(ctx context.Context) ([]models.PlayerRanking, error) {
+	return s.rankings, nil
+}
+
+func (s *source) GetAllStandings(ctx context.Context) (map[uint][]models.TournamentStanding, error) {
+	return s.standings, nil
+}
+
+func newVerifiedService(t *testing.T) (*Service, *source) {
+	t.Helper()
+
+	src := &source{
+		rankings: []models.PlayerRanking{
+			{PlayerID: 1, AccountBalance: 900, Rank: 1},
+			{PlayerID: 2, AccountBalance: 500.25, Rank: 2},
+			{PlayerID: 3, AccountBalance: 500.25, Rank: 2},
+			{PlayerID: 4, AccountBalance: 10, Rank: 3},
+		},
+		standings: map[uint][]models.TournamentStanding{
+			7: {
+				{PlayerID: 1, TotalBet: 80},
+				{PlayerID: 2, TotalBet: 80},
+				{PlayerID: 4, TotalBet: 20},
+			},
+		},
+	}
+	s := NewService(src, src, nil, clock.NewManual(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)))
+	if err := s.Rebuild(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return s, src
+}
+
+func TestVerifyConsistent(t *testing.T) {
+	s, _ := newVerifiedService(t)
+
+	report, err := s.Verify(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Consistent || report.MismatchCount != 0 || len(report.Mismatches) != 0 {
+		t.Fatalf("report = %+v, want consistent", report)
+	}
+	if report.BoardsChecked != 2 || report.PlayersChecked != 7 {
+		t.Errorf("checked %d boards and %d players, want 2 and 7", report.BoardsChecked, report.PlayersChecked)
+	}
+	if !report.LastRebuildAt.Equal(report.CheckedAt) {
+		t.Errorf("last rebuild %v, want %v", report.LastRebuildAt, report.CheckedAt)
+	}
+}
+
+func TestVerifyMismatches(t *testing.T) {
+	s, src := newVerifiedService(t)
+
+	// The database moves on without the boards: player 4 overtakes the tie
+	// on 500.25, player 5 registers, player 1 leaves the tournament and a
+	// new tournament gets a bet.
+	src.rankings = []models.PlayerRanking{
+		{PlayerID: 1, AccountBalance: 900, Rank: 1},
+		{PlayerID: 4, AccountBalance: 600, Rank: 2},
+		{PlayerID: 2, AccountBalance: 500.25, Rank: 3},
+		{PlayerID: 3, AccountBalance: 500.25, Rank: 3},
+		{PlayerID: 5, AccountBalance: 1, Rank: 4},
+	}
+	src.standings = map[uint][]models.TournamentStanding{
+		7: {
+			{PlayerID: 2, TotalBet: 80},
+			{PlayerID: 4, TotalBet: 20},
+		},
+		8: {
+			{PlayerID: 3, TotalBet: 5},
+		},
+	}
+
+	report, err := s.Verify(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Consistent {
+		t.Fatal("report is consistent after the database changed")
+	}
+
+	want := map[Mismatch]bool{
+		{Board: "global", PlayerID: 4, Reason: MismatchScore, Expected: 600, Actual: 10, ExpectedRank: 2, ActualRank: 3}:       true,
+		{Board: "global", PlayerID: 2, Reason: MismatchRank, Expected: 500.25, Actual: 500.25, ExpectedRank: 3, ActualRank: 2}: true,
+		{Board: "global", PlayerID: 3, Reason: MismatchRank, Expected: 500.25, Actual: 500.25, ExpectedRank: 3, ActualRank: 2}: true,
+		{Board: "global", PlayerID: 5, Reason: MismatchMissing, Expected: 1, ExpectedRank: 4}:                                  true,
+		{Board: "tournament:7", PlayerID: 1, Reason: MismatchUnexpected, Actual: 80, ActualRank: 1}:                            true,
+		{Board: "tournament:8", PlayerID: 3, Reason: MismatchMissing, Expected: 5, ExpectedRank: 1}:                            true,
+	}
+	if report.MismatchCount != len(want) || len(report.Mismatches) != len(want) {
+		t.Errorf("%d mismatches (%d listed), want %d: %+v", report.MismatchCount, len(report.Mismatches), len(want), report.Mismatches)
+	}
+	for _, m := range report.Mismatches {
+		if !want[m] {
+			t.Errorf("unexpected mismatch %+v", m)
+		}
+	}
+
+	// A rebuild catches up with the database.
+	if err := s.Rebuild(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if report, err := s.Verify(context.Background()); err != nil || !report.Consistent {
+		t.Fatalf("report after rebuild = %+v, %v; want consistent", report, err)
+	}
+}
+
+func TestVerifyReportsBoardsOfTournamentsGone(t *testing.T) {
+	s, src := newVerifiedService(t)
+	src.standings = nil
+
+	report, err := s.Verify(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.MismatchCount != 3 {
+		t.Fatalf("%d mismatches, want the 3 players of tournament 7: %+v", report.MismatchCount, report.Mismatches)
+	}
+	for _, m := range report.Mismatches {
+		if m.Board != "tournament:7" || m.Reason != MismatchUnexpected {
+			t.Errorf("unexpected mismatch %+v", m)
+		}
+	}
+}
+
+func TestVerifyCapsListedMismatches(t *testing.T) {
+	s, src := newVerifiedService(t)
+	for id := uint(100); id < 100+maxMismatches+20; id++ {
+		src.rankings = append(src.rankings, models.PlayerRanking{PlayerID: id, AccountBalance: 1, Rank: 4})
+	}
+
+	report, err := s.Verify(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.MismatchCount != maxMismatches+20 || len(report.Mismatches) != maxMismatches {
+		t.Fatalf("%d mismatches, %d listed; want %d and %d", report.MismatchCount, len(report.Mismatches), maxMismatches+20, maxMismatches)
+	}
+}
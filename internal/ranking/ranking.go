@@ -0,0 +1,356 @@
+// Package ranking keeps the global balance ranking and every tournament
+// leaderboard sorted in memory. Boards are rebuilt from the database on
+// startup and periodically, and updated incrementally as bets are placed and
+// prizes are paid, so rank lookups and top-N pages never hit the database.
+// Rank movement is measured against the ranking snapshots taken a day and a
+// week ago.
+package ranking
+
+import (
+	"context"
+	"fmt"
+	"igaming/internal/clock"
+	"igaming/internal/leaderboard"
+	"igaming/internal/models"
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DefaultRefreshInterval is how often the boards are rebuilt from the
+// database. Rebuilds pick up changes the incremental updates cannot see, such
+// as self-exclusions expiring or balances changed outside the API.
+const DefaultRefreshInterval = 5 * time.Minute
+
+// maxMismatches caps how many differences a consistency report lists.
+const maxMismatches = 100
+
+// RankingSource loads the global ranking, normally the player_rankings view.
+type RankingSource interface {
+	GetRankings(ctx context.Context) ([]models.PlayerRanking, error)
+}
+
+// StandingsSource loads the tournament standings.
+type StandingsSource interface {
+	GetAllStandings(ctx context.Context) (map[uint][]models.TournamentStanding, error)
+}
+
+// HistorySource loads past rankings, normally the ranking snapshots.
+type HistorySource interface {
+	RanksAt(ctx context.Context, at time.Time) (map[uint]int, error)
+}
+
+// Periods rank movement is reported for
+const (
+	PeriodDay  = "day"
+	PeriodWeek = "week"
+)
+
+// Service owns the in-memory boards. It is safe for concurrent use.
+type Service struct {
+	players     RankingSource
+	tournaments StandingsSource
+	history     HistorySource
+	clock       clock.Clock
+
+	mu          sync.RWMutex
+	global      *Board
+	boards      map[uint]*Board
+	names       map[uint]string
+	refreshedAt time.Time
+	// ranks a day and a week ago
+	dayAgo, weekAgo map[uint]int
+}
+
+func NewService(players RankingSource, tournaments StandingsSource, history HistorySource, clk clock.Clock) *Service {
+	return &Service{
+		players:     players,
+		tournaments: tournaments,
+		history:     history,
+		clock:       clk,
+		global:      NewBoard(),
+		boards:      make(map[uint]*Board),
+		names:       make(map[uint]string),
+	}
+}
+
+// Rebuild reloads every board from the database and swaps them in. Updates
+// applied while the data is loading may be lost or counted twice; the next
+// rebuild corrects them and Verify reports any drift in between.
+func (s *Service) Rebuild(ctx context.Context) error {
+	rankings, err := s.players.GetRankings(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load rankings: %w", err)
+	}
+
+	standings, err := s.tournaments.GetAllStandings(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load standings: %w", err)
+	}
+
+	global := NewBoard()
+	names := make(map[uint]string, len(rankings))
+	for _, r := range rankings {
+		global.Set(r.PlayerID, cents(r.AccountBalance))
+		names[r.PlayerID] = r.PlayerName
+	}
+
+	boards := make(map[uint]*Board, len(standings))
+	for id, rows := range standings {
+		b := NewBoard()
+		for _, st := range rows {
+			b.Set(st.PlayerID, cents(st.TotalBet))
+			names[st.PlayerID] = st.PlayerName
+		}
+		boards[id] = b
+	}
+
+	s.mu.Lock()
+	s.global = global
+	s.boards = boards
+	s.names = names
+	s.refreshedAt = s.clock.Now()
+	s.mu.Unlock()
+
+	return nil
+}
+
+// RefreshHistory reloads the ranks a day and a week ago that rank movement
+// is measured against. It should run after every new snapshot.
+func (s *Service) RefreshHistory(ctx context.Context) error {
+	now := s.clock.Now()
+
+	dayAgo, err := s.history.RanksAt(ctx, now.AddDate(0, 0, -1))
+	if err != nil {
+		return fmt.Errorf("failed to load ranks a day ago: %w", err)
+	}
+
+	weekAgo, err := s.history.RanksAt(ctx, now.AddDate(0, 0, -7))
+	if err != nil {
+		return fmt.Errorf("failed to load ranks a week ago: %w", err)
+	}
+
+	s.mu.Lock()
+	s.dayAgo = dayAgo
+	s.weekAgo = weekAgo
+	s.mu.Unlock()
+
+	return nil
+}
+
+// PlayerCreated adds a new player to the global ranking.
+func (s *Service) PlayerCreated(p *models.Player) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.names[p.ID] = p.Name
+	s.global.Set(p.ID, cents(p.AccountBalance))
+}
+
+// PlayerExcluded takes a self-excluded player off the global ranking. The
+// player comes back with the first rebuild after the exclusion ends.
+func (s *Service) PlayerExcluded(playerID uint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.global.Remove(playerID)
+}
+
+// BetPlaced moves the stake from the player's balance to their tournament
+// total.
+func (s *Service) BetPlaced(bet *models.TournamentBet) {
+	amount := cents(bet.BetAmount)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.global.AddExisting(bet.PlayerID, -amount)
+
+	b, ok := s.boards[bet.TournamentID]
+	if !ok {
+		b = NewBoard()
+		s.boards[bet.TournamentID] = b
+	}
+	b.Add(bet.PlayerID, amount)
+}
+
+// PrizesPaid credits settled prizes to the players' balances. Players that
+// are not ranked (e.g. self-excluded) stay off the board.
+func (s *Service) PrizesPaid(results []models.TournamentResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, r := range results {
+		s.global.AddExisting(r.PlayerID, cents(r.PrizeAmount))
+	}
+}
+
+// SeasonPrizesPaid credits season prizes to the players' balances.
+func (s *Service) SeasonPrizesPaid(results []models.SeasonResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, r := range results {
+		s.global.AddExisting(r.PlayerID, cents(r.PrizeAmount))
+	}
+}
+
+// Rankings returns up to limit players of the global ranking starting at
+// offset, together with the number of ranked players. A limit of 0 returns
+// everyone from offset on.
+func (s *Service) Rankings(offset, limit int) ([]models.PlayerRanking, int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	total := s.global.Len()
+	if limit == 0 {
+		limit = total
+	}
+
+	items := s.global.Page(offset, limit)
+	rankings := make([]models.PlayerRanking, 0, len(items))
+	for _, it := range items {
+		rankings = append(rankings, s.playerRanking(it))
+	}
+	return rankings, total
+}
+
+// PlayerRank returns the player's global ranking. ok is false when the player
+// is not ranked.
+func (s *Service) PlayerRank(playerID uint) (ranking models.PlayerRanking, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	it, ok := s.global.Get(playerID)
+	if !ok {
+		return models.PlayerRanking{}, false
+	}
+	return s.playerRanking(it), true
+}
+
+// Climbers returns up to limit ranked players who moved up the most over the
+// period (PeriodDay or PeriodWeek), biggest climb first. Players without a
+// rank at the start of the period are not included.
+func (s *Service) Climbers(period string, limit int) []models.PlayerRanking {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	base := s.dayAgo
+	if period == PeriodWeek {
+		base = s.weekAgo
+	}
+
+	type climb struct {
+		item  Item
+		moved int
+	}
+	var climbs []climb
+	for playerID, was := range base {
+		it, ok := s.global.Get(playerID)
+		if ok && was > it.Rank {
+			climbs = append(climbs, climb{it, was - it.Rank})
+		}
+	}
+
+	slices.SortFunc(climbs, func(a, b climb) int {
+		if a.moved != b.moved {
+			return b.moved - a.moved
+		}
+		if a.item.Rank != b.item.Rank {
+			return a.item.Rank - b.item.Rank
+		}
+		return int(a.item.PlayerID) - int(b.item.PlayerID)
+	})
+
+	rankings := make([]models.PlayerRanking, 0, min(limit, len(climbs)))
+	for _, c := range climbs[:min(limit, len(climbs))] {
+		rankings = append(rankings, s.playerRanking(c.item))
+	}
+	return rankings
+}
+
+// Leaderboard returns up to limit entries of the tournament leaderboard
+// starting at offset, with prizes projected from prizePool, together with the
+// number of participants.
+func (s *Service) Leaderboard(tournamentID uint, prizePool float64, offset, limit int) ([]models.LeaderboardEntry, int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	b, ok := s.boards[tournamentID]
+	if !ok {
+		return []models.LeaderboardEntry{}, 0
+	}
+	return s.entries(b.Page(offset, limit), prizePool), b.Len()
+}
+
+// LeaderboardAround returns a page of up to limit entries centred on the
+// player, with the page offset and the number of participants. ok is false
+// when the player has no bets in the tournament.
+func (s *Service) LeaderboardAround(tournamentID uint, prizePool float64, playerID uint, limit int) (entries []models.LeaderboardEntry, offset, total int, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	b, found := s.boards[tournamentID]
+	if !found {
+		return nil, 0, 0, false
+	}
+
+	it, found := b.Get(playerID)
+	if !found {
+		return nil, 0, 0, false
+	}
+
+	total = b.Len()
+	offset = max(0, min(it.Position-1-limit/2, total-limit))
+	return s.entries(b.Page(offset, limit), prizePool), offset, total, true
+}
+
+func (s *Service) playerRanking(it Item) models.PlayerRanking {
+	return models.PlayerRanking{
+		PlayerID:       it.PlayerID,
+		PlayerName:     s.names[it.PlayerID],
+		AccountBalance: amount(it.Score),
+		Rank:           it.Rank,
+		RankChangeDay:  rankChange(s.dayAgo, it),
+		RankChangeWeek: rankChange(s.weekAgo, it),
+	}
+}
+
+// rankChange is how many places the player moved up since the snapshot
+// (negative when they dropped), or nil when they were not ranked then.
+func rankChange(base map[uint]int, it Item) *int {
+	was, ok := base[it.PlayerID]
+	if !ok {
+		return nil
+	}
+	change := was - it.Rank
+	return &change
+}
+
+func (s *Service) entries(items []Item, prizePool float64) []models.LeaderboardEntry {
+	entries := make([]models.LeaderboardEntry, 0, len(items))
+	for _, it := range items {
+		entries = append(entries, models.LeaderboardEntry{
+			Position:       it.Position,
+			Placement:      it.Rank,
+			PlayerID:       it.PlayerID,
+			PlayerName:     s.names[it.PlayerID],
+			TotalBet:       amount(it.Score),
+			TieGroupSize:   it.GroupSize,
+			ProjectedPrize: leaderboard.Prize(it.Rank, it.GroupSize, prizePool),
+			GapToNext:      amount(it.NextScore - it.Score),
+		})
+	}
+	return entries
+}
+
+// Scores are kept in cents so incremental updates do not accumulate floating
+// point error and ties compare exactly, like the DECIMAL columns they mirror.
+func cents(v float64) int64 {
+	return int64(math.Round(v * 100))
+}
+
+func amount(c int64) float64 {
+	return float64(c) / 100
+}
@@ -0,0 +1,72 @@
+// Package rating computes skill ratings from tournament placements with a
+// multiplayer Elo: a tournament counts as a head-to-head game between every
+// pair of participants, won by the better placed one.
+package rating
+
+import "math"
+
+const (
+	// Initial is the rating of a player who has not played a rated
+	// tournament yet.
+	Initial = 1500.0
+	// K is the largest change a single tournament can make to a rating.
+	K = 32.0
+)
+
+// Participant is a player's result in a tournament.
+type Participant struct {
+	PlayerID uint
+	// Dense placement, lower is better; equal placements are a draw
+	Placement int
+	// Rating before the tournament
+	Rating float64
+}
+
+// Update returns each participant's rating after the tournament, in the
+// order given. Every pairing is scored 1 for the better placement, 0.5 for a
+// draw and 0 otherwise against the Elo expectation, and the sum is scaled by
+// K/(n-1) so a tournament moves a rating by at most K however large the
+// field is. Up to rounding, the field's total rating does not change.
+func Update(field []Participant) []float64 {
+	n := len(field)
+	ratings := make([]float64, n)
+	for i, p := range field {
+		ratings[i] = p.Rating
+	}
+	if n < 2 {
+		return ratings
+	}
+
+	scale := K / float64(n-1)
+	for i, p := range field {
+		delta := 0.0
+		for j, q := range field {
+			if i == j {
+				continue
+			}
+			delta += score(p.Placement, q.Placement) - Expected(p.Rating, q.Rating)
+		}
+		ratings[i] = round2(p.Rating + scale*delta)
+	}
+	return ratings
+}
+
+// Expected is the probability that a player rated a beats one rated b.
+func Expected(a, b float64) float64 {
+	return 1 / (1 + math.Pow(10, (b-a)/400))
+}
+
+func score(placement, other int) float64 {
+	switch {
+	case placement < other:
+		return 1
+	case placement == other:
+		return 0.5
+	default:
+		return 0
+	}
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,152 @@
+package rating
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestExpected(t *testing.T) {
+	tests := []struct {
+		a, b, want float64
+	}{
+		{1500, 1500, 0.5},
+		{1900, 1500, 10.0 / 11},
+		{1500, 1900, 1.0 / 11},
+		{1600, 1400, 1 / (1 + math.Pow(10, -0.5))},
+	}
+	for _, tc := range tests {
+		if got := Expected(tc.a, tc.b); math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("Expected(%v, %v) = %v, want %v", tc.a, tc.b, got, tc.want)
+		}
+		if sum := Expected(tc.a, tc.b) + Expected(tc.b, tc.a); math.Abs(sum-1) > 1e-9 {
+			t.Errorf("Expected(%v, %v) and its reverse add up to %v, want 1", tc.a, tc.b, sum)
+		}
+	}
+}
+
+func TestUpdateTwoPlayers(t *testing.T) {
+	tests := []struct {
+		name  string
+		field []Participant
+		want  []float64
+	}{
+		{
+			name: "equal ratings",
+			field: []Participant{
+				{PlayerID: 1, Placement: 1, Rating: Initial},
+				{PlayerID: 2, Placement: 2, Rating: Initial},
+			},
+			want: []float64{1516, 1484},
+		},
+		{
+			name: "favourite wins",
+			field: []Participant{
+				{PlayerID: 1, Placement: 1, Rating: 1600},
+				{PlayerID: 2, Placement: 2, Rating: 1400},
+			},
+			// 32 * (1 - 0.7597)
+			want: []float64{1607.69, 1392.31},
+		},
+		{
+			name: "upset",
+			field: []Participant{
+				{PlayerID: 1, Placement: 2, Rating: 1600},
+				{PlayerID: 2, Placement: 1, Rating: 1400},
+			},
+			// 32 * 0.7597
+			want: []float64{1575.69, 1424.31},
+		},
+		{
+			name: "draw",
+			field: []Participant{
+				{PlayerID: 1, Placement: 1, Rating: 1600},
+				{PlayerID: 2, Placement: 1, Rating: 1400},
+			},
+			// 32 * (0.5 - 0.7597)
+			want: []float64{1591.69, 1408.31},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Update(tc.field)
+			for i := range tc.want {
+				if got[i] != tc.want[i] {
+					t.Errorf("ratings = %v, want %v", got, tc.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateMultiplayerTies(t *testing.T) {
+	// Players 2 and 3 share second place. Each gains half a game on the
+	// winner's loss and the last player's win, so they stay put.
+	field := []Participant{
+		{PlayerID: 1, Placement: 1, Rating: Initial},
+		{PlayerID: 2, Placement: 2, Rating: Initial},
+		{PlayerID: 3, Placement: 2, Rating: Initial},
+		{PlayerID: 4, Placement: 3, Rating: Initial},
+	}
+	want := []float64{1516, 1500, 1500, 1484}
+
+	got := Update(field)
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ratings = %v, want %v", got, want)
+		}
+	}
+
+	// A field where everyone ties is a draw all round.
+	for i := range field {
+		field[i].Placement = 1
+	}
+	for i, r := range Update(field) {
+		if r != Initial {
+			t.Errorf("player %d rating after a full tie = %v, want %v", field[i].PlayerID, r, Initial)
+		}
+	}
+}
+
+func TestUpdateSmallFields(t *testing.T) {
+	if got := Update(nil); len(got) != 0 {
+		t.Errorf("Update(nil) = %v", got)
+	}
+	if got := Update([]Participant{{PlayerID: 1, Placement: 1, Rating: 1432.5}}); len(got) != 1 || got[0] != 1432.5 {
+		t.Errorf("single player rating = %v, want it unchanged", got)
+	}
+}
+
+// TestUpdateConservesRating checks on random fields that the total rating
+// only moves by rounding and no rating moves by more than K.
+func TestUpdateConservesRating(t *testing.T) {
+	rng := rand.New(rand.NewPCG(3, 4))
+
+	for range 200 {
+		n := 2 + rng.IntN(30)
+		field := make([]Participant, n)
+		before := 0.0
+		for i := range field {
+			field[i] = Participant{
+				PlayerID:  uint(i + 1),
+				Placement: 1 + rng.IntN(n),
+				Rating:    round2(1000 + rng.Float64()*1000),
+			}
+			before += field[i].Rating
+		}
+
+		after := 0.0
+		for i, r := range Update(field) {
+			if math.Abs(r-field[i].Rating) > K {
+				t.Fatalf("rating moved from %v to %v, more than K", field[i].Rating, r)
+			}
+			after += r
+		}
+		// Each rating is rounded to the cent, so by half a cent at most.
+		if math.Abs(after-before) > 0.005*float64(n)+1e-6 {
+			t.Fatalf("field of %d changed its total rating from %.2f to %.2f", n, before, after)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// Package dberr classifies database errors the same way whichever database
+// returned them, so repositories and the code retrying transactions do not
+// need to know MySQL error numbers, Postgres SQLSTATE codes or SQLite result
+// codes.
+package dberr
+
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// Kind is the class of a database error.
+type Kind int
+
+const (
+	// Other is any error not classified below, including errors that did
+	// not come from the database.
+	Other Kind = iota
+	// UniqueViolation is a duplicate primary or unique key.
+	UniqueViolation
+	// ForeignKeyViolation is a reference to a missing row, or a delete of a
+	// row still referenced.
+	ForeignKeyViolation
+	// CheckViolation is a row rejected by a CHECK constraint.
+	CheckViolation
+	// Deadlock means the database aborted the transaction to break a
+	// deadlock.
+	Deadlock
+	// SerializationFailure means the transaction could not be serialized
+	// with concurrent ones.
+	SerializationFailure
+	// LockTimeout means a lock was not granted in time.
+	LockTimeout
+)
+
+func (k Kind) String() string {
+	switch k {
+	case UniqueViolation:
+		return "unique violation"
+	case ForeignKeyViolation:
+		return "foreign key violation"
+	case CheckViolation:
+		return "check violation"
+	case Deadlock:
+		return "deadlock"
+	case SerializationFailure:
+		return "serialization failure"
+	case LockTimeout:
+		return "lock timeout"
+	}
+	return "other"
+}
+
+// MySQL error numbers
+var mysqlKinds = map[uint16]Kind{
+	1062: UniqueViolation,     // ER_DUP_ENTRY
+	1451: ForeignKeyViolation, // ER_ROW_IS_REFERENCED_2
+	1452: ForeignKeyViolation, // ER_NO_REFERENCED_ROW_2
+	3819: CheckViolation,      // ER_CHECK_CONSTRAINT_VIOLATED
+	1213: Deadlock,            // ER_LOCK_DEADLOCK
+	1205: LockTimeout,         // ER_LOCK_WAIT_TIMEOUT
+}
+
+// Postgres SQLSTATE codes
+var postgresKinds = map[string]Kind{
+	"23505": UniqueViolation,
+	"23503": ForeignKeyViolation,
+	"23514": CheckViolation,
+	"40P01": Deadlock,
+	"40001": SerializationFailure,
+	"55P03": LockTimeout,
+}
+
+// SQLite extended result codes
+var sqliteKinds = map[int]Kind{
+	sqlite3.SQLITE_CONSTRAINT_UNIQUE:     UniqueViolation,
+	sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY: UniqueViolation,
+	sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY: ForeignKeyViolation,
+	sqlite3.SQLITE_CONSTRAINT_CHECK:      CheckViolation,
+	// A transaction that read an old snapshot cannot write.
+	sqlite3.SQLITE_BUSY_SNAPSHOT: SerializationFailure,
+	// The busy timeout ran out waiting for the write lock.
+	sqlite3.SQLITE_BUSY: LockTimeout,
+}
+
+// Classify returns the kind of err, looking through wrapped errors.
+func Classify(err error) Kind {
+	var myErr *mysql.MySQLError
+	if errors.As(err, &myErr) {
+		return mysqlKinds[myErr.Number]
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return postgresKinds[pgErr.Code]
+	}
+
+	var liteErr *sqlite.Error
+	if errors.As(err, &liteErr) {
+		return sqliteKinds[liteErr.Code()]
+	}
+
+	return Other
+}
+
+// Is reports whether err is of kind k.
+func Is(err error, k Kind) bool {
+	return Classify(err) == k
+}
+
+// Retryable reports whether the database aborted the transaction because of
+// concurrent ones, so running it again from the start may succeed.
+func Retryable(err error) bool {
+	switch Classify(err) {
+	case Deadlock, SerializationFailure:
+		return true
+	}
+	return false
+}
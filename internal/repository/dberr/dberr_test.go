@@ -0,0 +1,180 @@
+package dberr
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	_ "modernc.org/sqlite"
+)
+
+func TestClassifyMySQL(t *testing.T) {
+	tests := []struct {
+		number uint16
+		want   Kind
+	}{
+		{1062, UniqueViolation},
+		{1451, ForeignKeyViolation},
+		{1452, ForeignKeyViolation},
+		{3819, CheckViolation},
+		{1213, Deadlock},
+		{1205, LockTimeout},
+		{1146, Other}, // ER_NO_SUCH_TABLE
+	}
+	for _, tc := range tests {
+		err := fmt.Errorf("failed to save: %w", &mysql.MySQLError{Number: tc.number})
+		if got := Classify(err); got != tc.want {
+			t.Errorf("MySQL error %d: got %v, want %v", tc.number, got, tc.want)
+		}
+	}
+}
+
+func TestClassifyPostgres(t *testing.T) {
+	tests := []struct {
+		code string
+		want Kind
+	}{
+		{"23505", UniqueViolation},
+		{"23503", ForeignKeyViolation},
+		{"23514", CheckViolation},
+		{"40P01", Deadlock},
+		{"40001", SerializationFailure},
+		{"55P03", LockTimeout},
+		{"42P01", Other}, // undefined_table
+	}
+	for _, tc := range tests {
+		err := fmt.Errorf("failed to save: %w", &pgconn.PgError{Code: tc.code})
+		if got := Classify(err); got != tc.want {
+			t.Errorf("SQLSTATE %s: got %v, want %v", tc.code, got, tc.want)
+		}
+	}
+}
+
+// TestClassifySQLite provokes each error on a real database, as the driver's
+// errors cannot be built by hand.
+func TestClassifySQLite(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "dberr.db")
+	open := func(t *testing.T) *sql.DB {
+		t.Helper()
+		db, err := sql.Open("sqlite", path+"?_pragma=foreign_keys(1)&_pragma=journal_mode(WAL)&_pragma=busy_timeout(0)")
+		if err != nil {
+			t.Fatal(err)
+		}
+		db.SetMaxOpenConns(1)
+		t.Cleanup(func() { db.Close() })
+		return db
+	}
+
+	db := open(t)
+	_, err := db.Exec(`
+		CREATE TABLE parents (id INTEGER PRIMARY KEY, name TEXT UNIQUE);
+		CREATE TABLE children (id INTEGER PRIMARY KEY, parent_id INTEGER REFERENCES parents (id), age INTEGER CHECK (age >= 0));
+		INSERT INTO parents (id, name) VALUES (1, 'a');`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  Kind
+	}{
+		{"unique", "INSERT INTO parents (id, name) VALUES (2, 'a')", UniqueViolation},
+		{"primary key", "INSERT INTO parents (id, name) VALUES (1, 'b')", UniqueViolation},
+		{"foreign key", "INSERT INTO children (parent_id, age) VALUES (99, 1)", ForeignKeyViolation},
+		{"check", "INSERT INTO children (parent_id, age) VALUES (1, -1)", CheckViolation},
+		{"other", "INSERT INTO missing (id) VALUES (1)", Other},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := db.Exec(tc.query)
+			if err == nil {
+				t.Fatal("statement succeeded, want an error")
+			}
+			if got := Classify(err); got != tc.want {
+				t.Errorf("got %v, want %v; error: %v", got, tc.want, err)
+			}
+		})
+	}
+
+	t.Run("lock timeout", func(t *testing.T) {
+		other := open(t)
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer tx.Rollback()
+		if _, err := tx.Exec("INSERT INTO parents (name) VALUES ('locked')"); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = other.Exec("INSERT INTO parents (name) VALUES ('waiting')")
+		if got := Classify(err); got != LockTimeout {
+			t.Errorf("got %v, want %v; error: %v", got, LockTimeout, err)
+		}
+		if Retryable(err) {
+			t.Error("a lock timeout is retryable")
+		}
+	})
+
+	t.Run("serialization failure", func(t *testing.T) {
+		other := open(t)
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer tx.Rollback()
+		// The read pins the transaction's snapshot.
+		var n int
+		if err := tx.QueryRow("SELECT COUNT(*) FROM parents").Scan(&n); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := other.Exec("INSERT INTO parents (name) VALUES ('newer')"); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = tx.Exec("INSERT INTO parents (name) VALUES ('stale')")
+		if got := Classify(err); got != SerializationFailure {
+			t.Errorf("got %v, want %v; error: %v", got, SerializationFailure, err)
+		}
+		if !Retryable(err) {
+			t.Error("a serialization failure is not retryable")
+		}
+	})
+}
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&mysql.MySQLError{Number: 1213}, true},
+		{&pgconn.PgError{Code: "40P01"}, true},
+		{&pgconn.PgError{Code: "40001"}, true},
+		{&mysql.MySQLError{Number: 1205}, false},
+		{&pgconn.PgError{Code: "23505"}, false},
+		{errors.New("connection refused"), false},
+		{nil, false},
+	}
+	for _, tc := range tests {
+		if got := Retryable(tc.err); got != tc.want {
+			t.Errorf("Retryable(%v) = %t, want %t", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestIs(t *testing.T) {
+	err := fmt.Errorf("failed to create player: %w", &pgconn.PgError{Code: "23505"})
+	if !Is(err, UniqueViolation) {
+		t.Error("wrapped unique violation not recognised")
+	}
+	if Is(err, Deadlock) {
+		t.Error("unique violation reported as a deadlock")
+	}
+}
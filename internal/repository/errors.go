@@ -0,0 +1,42 @@
+package repository
+
+import "errors"
+
+// Sentinel errors returned (wrapped) by the repositories so callers can
+// tell business rule violations apart from database failures.
+var (
+	ErrPlayerNotFound     = errors.New("player not found")
+	ErrTournamentNotFound = errors.New("tournament not found")
+	ErrSeasonNotFound     = errors.New("season not found")
+	ErrInsufficientFunds  = errors.New("insufficient funds")
+
+	// ErrEmailTaken is returned when registering an email another player
+	// already uses.
+	ErrEmailTaken = errors.New("email is already registered")
+
+	// Tournament bet limits
+	ErrBetBelowMinimum         = errors.New("bet amount is below the tournament minimum")
+	ErrBetAboveMaximum         = errors.New("bet amount is above the tournament maximum")
+	ErrStakeLimitExceeded      = errors.New("total stake limit for the tournament exceeded")
+	ErrParticipantLimitReached = errors.New("tournament participant limit reached")
+	ErrEntryFeeMismatch        = errors.New("bet amount must equal the tournament entry fee")
+	ErrAlreadyEntered          = errors.New("player has already paid the tournament entry fee")
+
+	// ErrBettingWindowClosed is returned for bets placed outside the
+	// tournament's betting window or after late registration has ended.
+	ErrBettingWindowClosed = errors.New("tournament is not accepting bets")
+
+	// ErrLimitExceeded is returned when a bet would break one of the
+	// player's responsible gambling limits.
+	ErrLimitExceeded = errors.New("responsible gambling limit exceeded")
+
+	// ErrPlayerExcluded is returned for any betting activity of a player
+	// with an active self-exclusion.
+	ErrPlayerExcluded = errors.New("player is self-excluded")
+
+	// Prize distribution
+	ErrPrizesAlreadyDistributed = errors.New("prizes already distributed")
+	ErrNoBets                   = errors.New("no bets placed in the tournament")
+	ErrSeasonNotEnded           = errors.New("season has not ended")
+	ErrNoSeasonPoints           = errors.New("no points scored in the season")
+)
@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"igaming/internal/models"
+	"igaming/internal/repository"
+	"slices"
+)
+
+type PlayerExclusionRepository struct {
+	db *db
+}
+
+// Create starts a new self-exclusion for the player from now.
+func (r *PlayerExclusionRepository) Create(ctx context.Context, exclusion *models.PlayerExclusion) error {
+	r.db.lock()
+	defer r.db.unlock()
+
+	now := r.db.clock.Now()
+	endsAt, ok := models.ExclusionEnd(exclusion.Duration, now)
+	if !ok {
+		return fmt.Errorf("unknown exclusion duration %q", exclusion.Duration)
+	}
+
+	if r.db.player(exclusion.PlayerID) == nil {
+		return fmt.Errorf("player with ID %d does not exist: %w", exclusion.PlayerID, repository.ErrPlayerNotFound)
+	}
+
+	exclusion.ID = uint(len(r.db.exclusions) + 1)
+	exclusion.StartsAt = now
+	exclusion.EndsAt = endsAt
+	truncateOnRollback(r.db, &r.db.exclusions)
+	r.db.exclusions = append(r.db.exclusions, *exclusion)
+	return nil
+}
+
+// GetByPlayer returns all exclusions of the player, newest first.
+func (r *PlayerExclusionRepository) GetByPlayer(ctx context.Context, playerID uint) ([]models.PlayerExclusion, error) {
+	r.db.rlock()
+	defer r.db.runlock()
+
+	return r.db.filterExclusions(func(e *models.PlayerExclusion) bool {
+		return e.PlayerID == playerID
+	}), nil
+}
+
+// GetActive returns every exclusion in force right now.
+func (r *PlayerExclusionRepository) GetActive(ctx context.Context) ([]models.PlayerExclusion, error) {
+	r.db.rlock()
+	defer r.db.runlock()
+
+	now := r.db.clock.Now()
+	return r.db.filterExclusions(func(e *models.PlayerExclusion) bool {
+		return e.Active(now)
+	}), nil
+}
+
+// CheckNotExcluded returns ErrPlayerExcluded while the player has an active
+// exclusion.
+func (r *PlayerExclusionRepository) CheckNotExcluded(ctx context.Context, playerID uint) error {
+	r.db.rlock()
+	defer r.db.runlock()
+
+	if e := r.db.activeExclusion(playerID, r.db.clock.Now()); e != nil {
+		return repository.ExcludedError(e)
+	}
+	return nil
+}
+
+// filterExclusions returns the matching exclusions, newest first.
+func (d *db) filterExclusions(match func(e *models.PlayerExclusion) bool) []models.PlayerExclusion {
+	exclusions := []models.PlayerExclusion{}
+	for i := range d.exclusions {
+		if match(&d.exclusions[i]) {
+			exclusions = append(exclusions, d.exclusions[i])
+		}
+	}
+
+	slices.SortStableFunc(exclusions, func(a, b models.PlayerExclusion) int {
+		if c := b.StartsAt.Compare(a.StartsAt); c != 0 {
+			return c
+		}
+		return int(b.ID) - int(a.ID)
+	})
+	return exclusions
+}
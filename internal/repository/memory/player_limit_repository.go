@@ -0,0 +1,116 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"igaming/internal/models"
+	"igaming/internal/repository"
+	"slices"
+	"strings"
+	"time"
+)
+
+type PlayerLimitRepository struct {
+	db         *db
+	coolingOff time.Duration
+}
+
+// GetLimits returns the player's limits with their usage in the current period.
+func (r *PlayerLimitRepository) GetLimits(ctx context.Context, playerID uint) ([]models.PlayerLimit, error) {
+	r.db.rlock()
+	defer r.db.runlock()
+
+	if r.db.player(playerID) == nil {
+		return nil, fmt.Errorf("player with ID %d does not exist: %w", playerID, repository.ErrPlayerNotFound)
+	}
+
+	now := r.db.clock.Now()
+	limits := r.db.playerLimits(playerID, now)
+	for i := range limits {
+		wagered, won := r.db.playerActivity(playerID, models.LimitPeriodStart(limits[i].Period, now))
+		limits[i].Used = repository.LimitUsage(limits[i].Type, wagered, won)
+	}
+	return limits, nil
+}
+
+// SetLimits applies the requested limit changes.
+func (r *PlayerLimitRepository) SetLimits(ctx context.Context, playerID uint, changes []models.PlayerLimit) error {
+	r.db.lock()
+	defer r.db.unlock()
+
+	if r.db.player(playerID) == nil {
+		return fmt.Errorf("player with ID %d does not exist: %w", playerID, repository.ErrPlayerNotFound)
+	}
+
+	now := r.db.clock.Now()
+	existing := r.db.playerLimits(playerID, now)
+	restoreTableOnRollback(r.db, &r.db.limits)
+
+	for _, change := range changes {
+		limit := models.PlayerLimit{PlayerID: playerID, Type: change.Type, Period: change.Period}
+		for _, l := range existing {
+			if l.Type == change.Type && l.Period == change.Period {
+				limit = l
+				break
+			}
+		}
+
+		limit.Change(change.Amount, now, r.coolingOff)
+		r.db.saveLimit(limit)
+	}
+	return nil
+}
+
+// saveLimit replaces the stored limit of the same type and period, deleting
+// it when it is empty.
+func (d *db) saveLimit(l models.PlayerLimit) {
+	d.limits = slices.DeleteFunc(d.limits, func(s models.PlayerLimit) bool {
+		return s.PlayerID == l.PlayerID && s.Type == l.Type && s.Period == l.Period
+	})
+	if !l.Empty() {
+		d.limits = append(d.limits, l)
+	}
+}
+
+// limitPeriodOrder sorts periods from the shortest.
+var limitPeriodOrder = map[string]int{
+	models.LimitPeriodDaily:   1,
+	models.LimitPeriodWeekly:  2,
+	models.LimitPeriodMonthly: 3,
+}
+
+// playerLimits returns the player's limits resolved at now, so limits whose
+// cooling-off period is over are reported with their new amount.
+func (d *db) playerLimits(playerID uint, now time.Time) []models.PlayerLimit {
+	limits := []models.PlayerLimit{}
+	for _, l := range d.limits {
+		if l.PlayerID == playerID {
+			l.Resolve(now)
+			limits = append(limits, l)
+		}
+	}
+
+	slices.SortFunc(limits, func(a, b models.PlayerLimit) int {
+		if c := strings.Compare(a.Type, b.Type); c != 0 {
+			return c
+		}
+		return limitPeriodOrder[a.Period] - limitPeriodOrder[b.Period]
+	})
+	return limits
+}
+
+// playerActivity returns how much the player has bet and won in prizes since
+// the given time.
+func (d *db) playerActivity(playerID uint, since time.Time) (wagered, won float64) {
+	for _, b := range d.bets {
+		if b.PlayerID == playerID && !b.CreatedAt.Before(since) {
+			wagered += b.BetAmount
+		}
+	}
+	for _, res := range d.results {
+		if res.PlayerID == playerID && !res.CreatedAt.Before(since) {
+			won += res.PrizeAmount
+		}
+	}
+	return round2(wagered), round2(won)
+}
@@ -0,0 +1,104 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"igaming/internal/models"
+	"igaming/internal/repository"
+)
+
+type PlayerRepository struct {
+	db *db
+}
+
+func (r *PlayerRepository) Create(ctx context.Context, player *models.Player) error {
+	r.db.lock()
+	defer r.db.unlock()
+
+	for _, p := range r.db.players {
+		if p.Email == player.Email {
+			return fmt.Errorf("failed to create player: %s: %w", player.Email, repository.ErrEmailTaken)
+		}
+	}
+
+	now := r.db.clock.Now()
+	player.ID = uint(len(r.db.players) + 1)
+	player.CreatedAt = now
+	player.UpdatedAt = now
+	truncateOnRollback(r.db, &r.db.players)
+	r.db.players = append(r.db.players, *player)
+	return nil
+}
+
+func (r *PlayerRepository) GetAllPlayers(ctx context.Context) ([]models.Player, error) {
+	r.db.rlock()
+	defer r.db.runlock()
+
+	var players []models.Player
+	for _, p := range r.db.players {
+		p.PasswordHash = ""
+		players = append(players, p)
+	}
+	return players, nil
+}
+
+func (r *PlayerRepository) GetPlayerByID(ctx context.Context, id uint) (*models.Player, error) {
+	r.db.rlock()
+	defer r.db.runlock()
+
+	p := r.db.player(id)
+	if p == nil {
+		return nil, fmt.Errorf("player with ID %d not found: %w", id, repository.ErrPlayerNotFound)
+	}
+	player := *p
+	return &player, nil
+}
+
+// GetForUpdate returns the player. A unit of work holds the write lock, so
+// the row stays locked until it ends.
+func (r *PlayerRepository) GetForUpdate(ctx context.Context, id uint) (*models.Player, error) {
+	r.db.rlock()
+	defer r.db.runlock()
+
+	p := r.db.player(id)
+	if p == nil {
+		return nil, fmt.Errorf("player with ID %d does not exist: %w", id, repository.ErrPlayerNotFound)
+	}
+	player := *p
+	return &player, nil
+}
+
+// AdjustBalance adds delta, which may be negative, to the balance.
+func (r *PlayerRepository) AdjustBalance(ctx context.Context, id uint, delta float64) error {
+	r.db.lock()
+	defer r.db.unlock()
+
+	p := r.db.player(id)
+	if p == nil {
+		return fmt.Errorf("player with ID %d does not exist: %w", id, repository.ErrPlayerNotFound)
+	}
+
+	restoreOnRollback(r.db, &r.db.players, int(id-1))
+	p.AccountBalance = round2(p.AccountBalance + delta)
+	p.UpdatedAt = r.db.clock.Now()
+	return nil
+}
+
+// TotalBalance returns the sum of every player's balance.
+func (r *PlayerRepository) TotalBalance(ctx context.Context) (float64, error) {
+	r.db.rlock()
+	defer r.db.runlock()
+
+	var total float64
+	for _, p := range r.db.players {
+		total += p.AccountBalance
+	}
+	return round2(total), nil
+}
+
+func (r *PlayerRepository) GetRankings(ctx context.Context) ([]models.PlayerRanking, error) {
+	r.db.rlock()
+	defer r.db.runlock()
+
+	return r.db.rankings(r.db.clock.Now()), nil
+}
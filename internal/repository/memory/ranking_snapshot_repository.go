@@ -0,0 +1,121 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"igaming/internal/models"
+	"igaming/internal/repository"
+	"slices"
+	"time"
+)
+
+type RankingSnapshotRepository struct {
+	db       *db
+	interval time.Duration
+}
+
+// Take snapshots the current rankings. The snapshot time is truncated to the
+// snapshot interval, so taking it again within the same interval only adds
+// players missing from it.
+func (r *RankingSnapshotRepository) Take(ctx context.Context) error {
+	r.db.lock()
+	defer r.db.unlock()
+
+	now := r.db.clock.Now()
+	takenAt := now.Truncate(r.interval)
+
+	taken := make(map[uint]bool)
+	for _, s := range r.db.snapshots {
+		if s.TakenAt.Equal(takenAt) {
+			taken[s.PlayerID] = true
+		}
+	}
+
+	truncateOnRollback(r.db, &r.db.snapshots)
+	for _, ranking := range r.db.rankings(now) {
+		if taken[ranking.PlayerID] {
+			continue
+		}
+		r.db.snapshots = append(r.db.snapshots, models.RankingSnapshot{
+			PlayerID:       ranking.PlayerID,
+			TakenAt:        takenAt,
+			Rank:           ranking.Rank,
+			AccountBalance: ranking.AccountBalance,
+		})
+	}
+	return nil
+}
+
+// RanksAt returns every player's rank in the latest snapshot taken at or
+// before at. The map is empty when there is no such snapshot.
+func (r *RankingSnapshotRepository) RanksAt(ctx context.Context, at time.Time) (map[uint]int, error) {
+	r.db.rlock()
+	defer r.db.runlock()
+
+	var latest time.Time
+	for _, s := range r.db.snapshots {
+		if !s.TakenAt.After(at) && s.TakenAt.After(latest) {
+			latest = s.TakenAt
+		}
+	}
+
+	ranks := make(map[uint]int)
+	if latest.IsZero() {
+		return ranks, nil
+	}
+	for _, s := range r.db.snapshots {
+		if s.TakenAt.Equal(latest) {
+			ranks[s.PlayerID] = s.Rank
+		}
+	}
+	return ranks, nil
+}
+
+// GetHistory returns the player's snapshots taken between from and to
+// (inclusive), oldest first.
+func (r *RankingSnapshotRepository) GetHistory(ctx context.Context, playerID uint, from, to time.Time) ([]models.RankingSnapshot, error) {
+	r.db.rlock()
+	defer r.db.runlock()
+
+	if r.db.player(playerID) == nil {
+		return nil, fmt.Errorf("player with ID %d does not exist: %w", playerID, repository.ErrPlayerNotFound)
+	}
+
+	history := []models.RankingSnapshot{}
+	for _, s := range r.db.snapshots {
+		if s.PlayerID == playerID && !s.TakenAt.Before(from) && !s.TakenAt.After(to) {
+			history = append(history, s)
+		}
+	}
+	slices.SortStableFunc(history, func(a, b models.RankingSnapshot) int {
+		return a.TakenAt.Compare(b.TakenAt)
+	})
+	return history, nil
+}
+
+// Prune deletes the snapshots taken before before and thins those taken
+// before hourlyBefore out to the first of each day.
+func (r *RankingSnapshotRepository) Prune(ctx context.Context, hourlyBefore, before time.Time) error {
+	r.db.lock()
+	defer r.db.unlock()
+
+	var times []time.Time
+	for _, s := range r.db.snapshots {
+		if s.TakenAt.Before(hourlyBefore) || s.TakenAt.Before(before) {
+			times = append(times, s.TakenAt)
+		}
+	}
+	slices.SortFunc(times, time.Time.Compare)
+	times = slices.CompactFunc(times, time.Time.Equal)
+
+	prune := repository.SnapshotsToPrune(times, hourlyBefore, before)
+	if len(prune) == 0 {
+		return nil
+	}
+	restoreTableOnRollback(r.db, &r.db.snapshots)
+	r.db.snapshots = slices.DeleteFunc(r.db.snapshots, func(s models.RankingSnapshot) bool {
+		_, found := slices.BinarySearchFunc(prune, s.TakenAt, time.Time.Compare)
+		return found
+	})
+	return nil
+}
@@ -0,0 +1,214 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"igaming/internal/models"
+	"igaming/internal/rating"
+	"igaming/internal/repository"
+	"maps"
+	"slices"
+	"time"
+)
+
+// RatingRepository keeps the skill ratings in step with settled tournaments,
+// rating each once, in the order tournaments were settled.
+type RatingRepository struct {
+	db *db
+}
+
+// settledTournament is a settled tournament's field in placement order.
+type settledTournament struct {
+	id        uint
+	settledAt time.Time
+	field     []repository.RatedPlacement
+}
+
+// ApplyPending rates every settled tournament that has not been rated yet,
+// oldest settlement first, and returns how many it rated.
+func (r *RatingRepository) ApplyPending(ctx context.Context) (int, error) {
+	r.db.lock()
+	defer r.db.unlock()
+
+	r.db.journalRatings()
+	rated := 0
+	for _, t := range r.db.settledTournaments() {
+		if r.db.ratedTournament[t.id] {
+			continue
+		}
+		r.db.rate(t)
+		rated++
+	}
+	return rated, nil
+}
+
+// Recompute throws away all ratings and rates every settled tournament again
+// from scratch. It returns the number of tournaments and players rated.
+func (r *RatingRepository) Recompute(ctx context.Context) (tournaments, players int, err error) {
+	r.db.lock()
+	defer r.db.unlock()
+
+	r.db.journalRatings()
+	r.db.ratings = make(map[uint]*repository.RatingState)
+	r.db.ratingHistory = nil
+	r.db.ratedTournament = make(map[uint]bool)
+
+	settled := r.db.settledTournaments()
+	for _, t := range settled {
+		r.db.rate(t)
+	}
+	return len(settled), len(r.db.ratings), nil
+}
+
+// journalRatings makes the rating changes that follow undoable.
+func (d *db) journalRatings() {
+	if d.tx == nil {
+		return
+	}
+	ratings := make(map[uint]*repository.RatingState, len(d.ratings))
+	for id, s := range d.ratings {
+		state := *s
+		ratings[id] = &state
+	}
+	history := d.ratingHistory
+	rated := maps.Clone(d.ratedTournament)
+	d.onRollback(func() {
+		d.ratings, d.ratingHistory, d.ratedTournament = ratings, history, rated
+	})
+}
+
+func (d *db) rate(t settledTournament) {
+	changes := repository.RateTournament(d.ratings, t.id, t.field, t.settledAt)
+	d.ratingHistory = append(d.ratingHistory, changes...)
+	d.ratedTournament[t.id] = true
+}
+
+// settledTournaments lists every settled tournament with each participant's
+// dense placement by total bet, in settlement order.
+func (d *db) settledTournaments() []settledTournament {
+	settledAt := make(map[uint]time.Time)
+	for _, res := range d.results {
+		if at, ok := settledAt[res.TournamentID]; !ok || res.CreatedAt.Before(at) {
+			settledAt[res.TournamentID] = res.CreatedAt
+		}
+	}
+
+	var settled []settledTournament
+	for id, standings := range d.allStandings() {
+		at, ok := settledAt[id]
+		if !ok || !d.tournament(id).PrizesDistributed {
+			continue
+		}
+
+		t := settledTournament{id: id, settledAt: at}
+		for _, p := range placements(standings) {
+			t.field = append(t.field, repository.RatedPlacement{PlayerID: p.playerID, Placement: p.placement})
+		}
+		settled = append(settled, t)
+	}
+
+	slices.SortFunc(settled, func(a, b settledTournament) int {
+		if c := a.settledAt.Compare(b.settledAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.id, b.id)
+	})
+	return settled
+}
+
+// GetRating returns the player's rating, the initial rating when they have
+// not played a rated tournament.
+func (r *RatingRepository) GetRating(ctx context.Context, playerID uint) (*models.PlayerRating, error) {
+	r.db.rlock()
+	defer r.db.runlock()
+
+	p := r.db.player(playerID)
+	if p == nil {
+		return nil, fmt.Errorf("player with ID %d not found: %w", playerID, repository.ErrPlayerNotFound)
+	}
+
+	pr := models.PlayerRating{PlayerID: p.ID, PlayerName: p.Name, Rating: rating.Initial}
+	if s, ok := r.db.ratings[playerID]; ok {
+		updatedAt := s.UpdatedAt
+		pr.Rating = s.Rating
+		pr.TournamentsPlayed = s.Played
+		pr.UpdatedAt = &updatedAt
+	}
+	for _, ranked := range r.db.ratingRankings() {
+		if ranked.PlayerID == playerID {
+			pr.Rank = ranked.Rank
+		}
+	}
+	return &pr, nil
+}
+
+// GetHistory returns the player's most recent rating changes, newest first.
+func (r *RatingRepository) GetHistory(ctx context.Context, playerID uint, limit int) ([]models.RatingChange, error) {
+	r.db.rlock()
+	defer r.db.runlock()
+
+	history := []models.RatingChange{}
+	for i := len(r.db.ratingHistory) - 1; i >= 0; i-- {
+		if c := r.db.ratingHistory[i]; c.PlayerID == playerID {
+			history = append(history, c)
+		}
+	}
+
+	slices.SortStableFunc(history, func(a, b models.RatingChange) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return history[:min(limit, len(history))], nil
+}
+
+// GetLeaderboard returns a page of rated players by rating, best first,
+// together with the number of ranked players.
+func (r *RatingRepository) GetLeaderboard(ctx context.Context, offset, limit int) ([]models.PlayerRating, int, error) {
+	r.db.rlock()
+	defer r.db.runlock()
+
+	ranked := r.db.ratingRankings()
+	start := min(offset, len(ranked))
+	end := min(start+limit, len(ranked))
+	return append([]models.PlayerRating{}, ranked[start:end]...), len(ranked), nil
+}
+
+// ratingRankings is the player_rating_rankings view: rated players with the
+// same visibility rules as the rankings, densely ranked by rating.
+func (d *db) ratingRankings() []models.PlayerRating {
+	now := d.clock.Now()
+
+	var ranked []models.PlayerRating
+	for id, s := range d.ratings {
+		p := d.player(id)
+		if !d.visible(p, now) {
+			continue
+		}
+		updatedAt := s.UpdatedAt
+		ranked = append(ranked, models.PlayerRating{
+			PlayerID:          id,
+			PlayerName:        p.Name,
+			Rating:            s.Rating,
+			TournamentsPlayed: s.Played,
+			UpdatedAt:         &updatedAt,
+		})
+	}
+
+	slices.SortFunc(ranked, func(a, b models.PlayerRating) int {
+		if c := cmp.Compare(b.Rating, a.Rating); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.PlayerID, b.PlayerID)
+	})
+	for i := range ranked {
+		rank := 1
+		if i > 0 {
+			rank = *ranked[i-1].Rank
+			if ranked[i].Rating != ranked[i-1].Rating {
+				rank++
+			}
+		}
+		ranked[i].Rank = &rank
+	}
+	return ranked
+}
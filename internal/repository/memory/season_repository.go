@@ -0,0 +1,262 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"igaming/internal/models"
+	"igaming/internal/repository"
+	"slices"
+)
+
+type SeasonRepository struct {
+	db *db
+}
+
+// Create inserts the season together with its points table.
+func (r *SeasonRepository) Create(ctx context.Context, season *models.Season) error {
+	r.db.lock()
+	defer r.db.unlock()
+
+	if err := checkPointsTable(season.PointsTable); err != nil {
+		return err
+	}
+
+	now := r.db.clock.Now()
+	season.ID = uint(len(r.db.seasons) + 1)
+	truncateOnRollback(r.db, &r.db.seasons)
+	r.db.seasons = append(r.db.seasons, models.Season{
+		ID:          season.ID,
+		Name:        season.Name,
+		StartDate:   season.StartDate,
+		EndDate:     season.EndDate,
+		PrizePool:   season.PrizePool,
+		PointsTable: sortedPoints(season.PointsTable),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	})
+	return nil
+}
+
+// GetAll returns every season without its points table.
+func (r *SeasonRepository) GetAll(ctx context.Context) ([]models.Season, error) {
+	r.db.rlock()
+	defer r.db.runlock()
+
+	seasons := []models.Season{}
+	for _, s := range r.db.seasons {
+		s.PointsTable = nil
+		seasons = append(seasons, s)
+	}
+
+	slices.SortStableFunc(seasons, func(a, b models.Season) int {
+		if c := b.StartDate.Compare(a.StartDate); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.ID, a.ID)
+	})
+	return seasons, nil
+}
+
+// GetByID returns the season with its points table.
+func (r *SeasonRepository) GetByID(ctx context.Context, id uint) (*models.Season, error) {
+	r.db.rlock()
+	defer r.db.runlock()
+
+	s := r.db.season(id)
+	if s == nil {
+		return nil, fmt.Errorf("season with ID %d not found: %w", id, repository.ErrSeasonNotFound)
+	}
+	season := *s
+	season.PointsTable = sortedPoints(s.PointsTable)
+	return &season, nil
+}
+
+// SetPoints replaces the season's points table. Standings are computed from
+// the table on every read, so the change applies to tournaments already
+// played as well.
+func (r *SeasonRepository) SetPoints(ctx context.Context, seasonID uint, points []models.SeasonPoints) error {
+	r.db.lock()
+	defer r.db.unlock()
+
+	s := r.db.season(seasonID)
+	if s == nil {
+		return fmt.Errorf("season with ID %d does not exist: %w", seasonID, repository.ErrSeasonNotFound)
+	}
+	if s.PrizesDistributed {
+		return fmt.Errorf("%w: the points table of season %d is final", repository.ErrPrizesAlreadyDistributed, seasonID)
+	}
+	if err := checkPointsTable(points); err != nil {
+		return err
+	}
+
+	restoreOnRollback(r.db, &r.db.seasons, int(seasonID-1))
+	s.PointsTable = sortedPoints(points)
+	s.UpdatedAt = r.db.clock.Now()
+	return nil
+}
+
+// GetStandings returns the season standings ordered by placement.
+func (r *SeasonRepository) GetStandings(ctx context.Context, seasonID uint) ([]models.SeasonStanding, error) {
+	r.db.rlock()
+	defer r.db.runlock()
+
+	return r.db.seasonStandings(seasonID), nil
+}
+
+// GetForUpdate returns the season without its points table. A unit of work
+// holds the write lock, so the row stays locked until it ends.
+func (r *SeasonRepository) GetForUpdate(ctx context.Context, id uint) (*models.Season, error) {
+	r.db.rlock()
+	defer r.db.runlock()
+
+	s := r.db.season(id)
+	if s == nil {
+		return nil, fmt.Errorf("season with ID %d does not exist: %w", id, repository.ErrSeasonNotFound)
+	}
+	season := *s
+	season.PointsTable = nil
+	return &season, nil
+}
+
+// CreateResults records the placements and prizes of a settlement.
+func (r *SeasonRepository) CreateResults(ctx context.Context, results []models.SeasonResult) error {
+	r.db.lock()
+	defer r.db.unlock()
+
+	truncateOnRollback(r.db, &r.db.seasonResults)
+	for i := range results {
+		results[i].ID = uint(len(r.db.seasonResults) + 1)
+		r.db.seasonResults = append(r.db.seasonResults, results[i])
+	}
+	return nil
+}
+
+// MarkPrizesDistributed marks the season as settled.
+func (r *SeasonRepository) MarkPrizesDistributed(ctx context.Context, id uint) error {
+	r.db.lock()
+	defer r.db.unlock()
+
+	s := r.db.season(id)
+	if s == nil {
+		return fmt.Errorf("season with ID %d does not exist: %w", id, repository.ErrSeasonNotFound)
+	}
+
+	restoreOnRollback(r.db, &r.db.seasons, int(id-1))
+	s.PrizesDistributed = true
+	s.UpdatedAt = r.db.clock.Now()
+	return nil
+}
+
+// GetResults returns the prizes paid for the season, best placement first.
+func (r *SeasonRepository) GetResults(ctx context.Context, seasonID uint) ([]models.SeasonResult, error) {
+	r.db.rlock()
+	defer r.db.runlock()
+
+	// Results are recorded in placement order, ties by player ID.
+	results := []models.SeasonResult{}
+	for _, res := range r.db.seasonResults {
+		if res.SeasonID == seasonID {
+			results = append(results, res)
+		}
+	}
+	return results, nil
+}
+
+// seasonStandings is the season_standings view: points for the placements in
+// the season's tournament results, ties broken by most wins, then by who
+// reached their points total first.
+func (d *db) seasonStandings(seasonID uint) []models.SeasonStanding {
+	s := d.season(seasonID)
+	if s == nil {
+		return []models.SeasonStanding{}
+	}
+	points := make(map[int]int, len(s.PointsTable))
+	for _, p := range s.PointsTable {
+		points[p.Placement] = p.Points
+	}
+
+	totals := make(map[uint]*models.SeasonStanding)
+	for _, res := range d.results {
+		t := d.tournament(res.TournamentID)
+		if t.SeasonID == nil || *t.SeasonID != seasonID {
+			continue
+		}
+
+		st, ok := totals[res.PlayerID]
+		if !ok {
+			st = &models.SeasonStanding{PlayerID: res.PlayerID, PlayerName: d.player(res.PlayerID).Name}
+			totals[res.PlayerID] = st
+		}
+		st.Points += points[res.Placement]
+		st.TournamentsPlaced++
+		if res.Placement == 1 {
+			st.Wins++
+		}
+		if points[res.Placement] > 0 && (st.AchievedAt == nil || res.CreatedAt.After(*st.AchievedAt)) {
+			at := res.CreatedAt
+			st.AchievedAt = &at
+		}
+	}
+
+	standings := make([]models.SeasonStanding, 0, len(totals))
+	for _, st := range totals {
+		standings = append(standings, *st)
+	}
+	slices.SortFunc(standings, func(a, b models.SeasonStanding) int {
+		if c := compareStanding(a, b); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.PlayerID, b.PlayerID)
+	})
+	for i := range standings {
+		standings[i].Placement = 1
+		if i > 0 {
+			standings[i].Placement = standings[i-1].Placement
+			if compareStanding(standings[i-1], standings[i]) != 0 {
+				standings[i].Placement++
+			}
+		}
+	}
+	return standings
+}
+
+// compareStanding orders standings by points and wins, best first, then by
+// when the points were reached, earliest first and never last.
+func compareStanding(a, b models.SeasonStanding) int {
+	if c := cmp.Compare(b.Points, a.Points); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(b.Wins, a.Wins); c != 0 {
+		return c
+	}
+	switch {
+	case a.AchievedAt == nil && b.AchievedAt == nil:
+		return 0
+	case a.AchievedAt == nil:
+		return 1
+	case b.AchievedAt == nil:
+		return -1
+	}
+	return a.AchievedAt.Compare(*b.AchievedAt)
+}
+
+// checkPointsTable enforces the season_points key: one row per placement.
+func checkPointsTable(points []models.SeasonPoints) error {
+	seen := make(map[int]bool, len(points))
+	for _, p := range points {
+		if seen[p.Placement] {
+			return fmt.Errorf("failed to save points for placement %d: duplicate placement", p.Placement)
+		}
+		seen[p.Placement] = true
+	}
+	return nil
+}
+
+func sortedPoints(points []models.SeasonPoints) []models.SeasonPoints {
+	sorted := append([]models.SeasonPoints{}, points...)
+	slices.SortFunc(sorted, func(a, b models.SeasonPoints) int {
+		return cmp.Compare(a.Placement, b.Placement)
+	})
+	return sorted
+}
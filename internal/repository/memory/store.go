@@ -0,0 +1,372 @@
+// Package memory implements the repositories in process memory, so the API
+// can run without a database: for local development, demos and tests.
+//
+// All repositories of a store share one set of tables behind a single lock.
+// Every write validates first and mutates only once nothing can fail, while
+// holding the write lock, so it is atomic and isolated like the database
+// transactions it stands in for. A unit of work holds the write lock until
+// it ends and journals its changes, so they can be undone when it fails.
+// Nothing is persisted.
+package memory
+
+import (
+	"cmp"
+	"context"
+	"igaming/internal/clock"
+	"igaming/internal/fixtures"
+	"igaming/internal/models"
+	"igaming/internal/repository"
+	"igaming/internal/tracing"
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// tables holds the data. Rows are kept in insertion order and IDs are
+// assigned sequentially from 1, so row i has ID i+1.
+type tables struct {
+	mu    sync.RWMutex
+	clock clock.Clock
+
+	players         []models.Player
+	tournaments     []models.Tournament
+	bets            []models.TournamentBet
+	results         []models.TournamentResult
+	limits          []models.PlayerLimit
+	exclusions      []models.PlayerExclusion
+	snapshots       []models.RankingSnapshot
+	seasons         []models.Season
+	seasonResults   []models.SeasonResult
+	ratings         map[uint]*repository.RatingState
+	ratingHistory   []models.RatingChange
+	ratedTournament map[uint]bool
+}
+
+// db is what the repositories of a store see of the tables. Outside a unit
+// of work every repository call takes the lock itself. In a unit of work tx
+// is set: the lock is already held and changes are journaled.
+type db struct {
+	*tables
+	tx *txn
+}
+
+// txn is the undo journal of a unit of work.
+type txn struct {
+	undo []func()
+}
+
+func (d *db) lock() {
+	if d.tx == nil {
+		d.mu.Lock()
+	}
+}
+
+func (d *db) unlock() {
+	if d.tx == nil {
+		d.mu.Unlock()
+	}
+}
+
+func (d *db) rlock() {
+	if d.tx == nil {
+		d.mu.RLock()
+	}
+}
+
+func (d *db) runlock() {
+	if d.tx == nil {
+		d.mu.RUnlock()
+	}
+}
+
+// onRollback registers undo to run when the unit of work the change is made
+// in fails. Outside a unit of work changes are final.
+func (d *db) onRollback(undo func()) {
+	if d.tx != nil {
+		d.tx.undo = append(d.tx.undo, undo)
+	}
+}
+
+// rollbackTo undoes the changes journaled after mark, newest first.
+func (t *txn) rollbackTo(mark int) {
+	for i := len(t.undo) - 1; i >= mark; i-- {
+		t.undo[i]()
+	}
+	t.undo = t.undo[:mark]
+}
+
+// truncateOnRollback makes rows appended to the table from now on undoable.
+func truncateOnRollback[T any](d *db, table *[]T) {
+	n := len(*table)
+	d.onRollback(func() { *table = (*table)[:n] })
+}
+
+// restoreOnRollback makes changes to row i of the table undoable. Undo runs
+// newest first, so the rows appended later are gone by the time the row is
+// restored and i is still valid.
+func restoreOnRollback[T any](d *db, table *[]T, i int) {
+	old := (*table)[i]
+	d.onRollback(func() { (*table)[i] = old })
+}
+
+// restoreTableOnRollback makes any change to the table undoable.
+func restoreTableOnRollback[T any](d *db, table *[]T) {
+	if d.tx == nil {
+		return
+	}
+	old := slices.Clone(*table)
+	d.onRollback(func() { *table = old })
+}
+
+type unitOfWork struct {
+	db *db
+}
+
+// Do runs fn holding the write lock. A unit of work started in another one
+// joins it and only undoes its own changes when it fails.
+func (u unitOfWork) Do(ctx context.Context, fn func(tx *repository.Store) error) error {
+	tx := u.db
+	if tx.tx == nil {
+		u.db.mu.Lock()
+		defer u.db.mu.Unlock()
+		tx = &db{tables: u.db.tables, tx: &txn{}}
+	}
+
+	mark := len(tx.tx.undo)
+	committed := false
+	defer func() {
+		if !committed {
+			tx.tx.rollbackTo(mark)
+		}
+	}()
+
+	if err := fn(tx.store()); err != nil {
+		return err
+	}
+	committed = true
+	return nil
+}
+
+// NewStore returns the repositories of a new, empty in-memory database, each
+// method traced in a span of its own.
+func NewStore(clk clock.Clock) *repository.Store {
+	return tracing.Store(newDB(clk).store())
+}
+
+// NewSeededStore returns the repositories of a new in-memory database holding
+// data. Like fixtures.Insert, bets are recorded as they are and do not touch
+// balances.
+func NewSeededStore(clk clock.Clock, data fixtures.Data) *repository.Store {
+	d := newDB(clk)
+	now := clk.Now()
+
+	for _, p := range data.Players {
+		d.players = append(d.players, models.Player{
+			ID:             uint(len(d.players) + 1),
+			Name:           p.Name,
+			Email:          p.Email,
+			PasswordHash:   p.PasswordHash,
+			AccountBalance: p.Balance,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		})
+	}
+	for _, t := range data.Tournaments {
+		d.tournaments = append(d.tournaments, models.Tournament{
+			ID:                  uint(len(d.tournaments) + 1),
+			Name:                t.Name,
+			PrizePool:           t.PrizePool,
+			PoolMode:            models.PoolModeFixed,
+			GuaranteedPrizePool: t.PrizePool,
+			StartDate:           t.StartDate,
+			EndDate:             t.EndDate,
+			CreatedAt:           now,
+			UpdatedAt:           now,
+		})
+	}
+	for _, b := range data.Bets {
+		d.bets = append(d.bets, models.TournamentBet{
+			ID:           uint(len(d.bets) + 1),
+			PlayerID:     uint(b.Player + 1),
+			TournamentID: uint(b.Tournament + 1),
+			BetAmount:    b.Amount,
+			CreatedAt:    b.PlacedAt,
+		})
+	}
+
+	return tracing.Store(d.store())
+}
+
+func newDB(clk clock.Clock) *db {
+	return &db{tables: &tables{
+		clock:           clk,
+		ratings:         make(map[uint]*repository.RatingState),
+		ratedTournament: make(map[uint]bool),
+	}}
+}
+
+func (d *db) store() *repository.Store {
+	return &repository.Store{
+		Players:     &PlayerRepository{db: d},
+		Tournaments: &TournamentRepository{db: d},
+		Bets:        &TournamentBetRepository{db: d},
+		Seasons:     &SeasonRepository{db: d},
+		Ratings:     &RatingRepository{db: d},
+		Limits:      &PlayerLimitRepository{db: d, coolingOff: repository.DefaultLimitCoolingOff},
+		Exclusions:  &PlayerExclusionRepository{db: d},
+		Snapshots:   &RankingSnapshotRepository{db: d, interval: repository.DefaultSnapshotInterval},
+		UnitOfWork:  unitOfWork{db: d},
+	}
+}
+
+func (d *db) player(id uint) *models.Player {
+	if id == 0 || int(id) > len(d.players) {
+		return nil
+	}
+	return &d.players[id-1]
+}
+
+func (d *db) tournament(id uint) *models.Tournament {
+	if id == 0 || int(id) > len(d.tournaments) {
+		return nil
+	}
+	return &d.tournaments[id-1]
+}
+
+func (d *db) season(id uint) *models.Season {
+	if id == 0 || int(id) > len(d.seasons) {
+		return nil
+	}
+	return &d.seasons[id-1]
+}
+
+// activeExclusion returns the player's exclusion in force at now, preferring
+// a permanent one and then the one ending last.
+func (d *db) activeExclusion(playerID uint, now time.Time) *models.PlayerExclusion {
+	var found *models.PlayerExclusion
+	for i := range d.exclusions {
+		e := &d.exclusions[i]
+		if e.PlayerID != playerID || !e.Active(now) {
+			continue
+		}
+		switch {
+		case found == nil:
+			found = e
+		case found.EndsAt == nil:
+		case e.EndsAt == nil || e.EndsAt.After(*found.EndsAt):
+			found = e
+		}
+	}
+	return found
+}
+
+// visible reports whether the player appears in the rankings at now: not
+// deleted and not self-excluded.
+func (d *db) visible(p *models.Player, now time.Time) bool {
+	return p.DeletedAt == nil && d.activeExclusion(p.ID, now) == nil
+}
+
+// rankings is the player_rankings view: visible players densely ranked by
+// balance, best first.
+func (d *db) rankings(now time.Time) []models.PlayerRanking {
+	var rankings []models.PlayerRanking
+	for i := range d.players {
+		p := &d.players[i]
+		if d.visible(p, now) {
+			rankings = append(rankings, models.PlayerRanking{
+				PlayerID:       p.ID,
+				PlayerName:     p.Name,
+				AccountBalance: p.AccountBalance,
+			})
+		}
+	}
+
+	slices.SortStableFunc(rankings, func(a, b models.PlayerRanking) int {
+		return cmp.Compare(b.AccountBalance, a.AccountBalance)
+	})
+	for i := range rankings {
+		rankings[i].Rank = 1
+		if i > 0 {
+			rankings[i].Rank = rankings[i-1].Rank
+			if rankings[i].AccountBalance != rankings[i-1].AccountBalance {
+				rankings[i].Rank++
+			}
+		}
+	}
+	return rankings
+}
+
+// standings returns every participant's total bet in the tournament, highest
+// first, ties by player ID.
+func (d *db) standings(tournamentID uint) []models.TournamentStanding {
+	totals := make(map[uint]float64)
+	for _, b := range d.bets {
+		if b.TournamentID == tournamentID {
+			totals[b.PlayerID] += b.BetAmount
+		}
+	}
+	return d.sortedStandings(totals)
+}
+
+// allStandings returns the standings of every tournament with bets.
+func (d *db) allStandings() map[uint][]models.TournamentStanding {
+	totals := make(map[uint]map[uint]float64)
+	for _, b := range d.bets {
+		if totals[b.TournamentID] == nil {
+			totals[b.TournamentID] = make(map[uint]float64)
+		}
+		totals[b.TournamentID][b.PlayerID] += b.BetAmount
+	}
+
+	standings := make(map[uint][]models.TournamentStanding, len(totals))
+	for id, t := range totals {
+		standings[id] = d.sortedStandings(t)
+	}
+	return standings
+}
+
+func (d *db) sortedStandings(totals map[uint]float64) []models.TournamentStanding {
+	standings := make([]models.TournamentStanding, 0, len(totals))
+	for playerID, total := range totals {
+		standings = append(standings, models.TournamentStanding{
+			PlayerID:   playerID,
+			PlayerName: d.player(playerID).Name,
+			TotalBet:   round2(total),
+		})
+	}
+	slices.SortFunc(standings, func(a, b models.TournamentStanding) int {
+		if c := cmp.Compare(b.TotalBet, a.TotalBet); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.PlayerID, b.PlayerID)
+	})
+	return standings
+}
+
+// placed is a player's dense placement.
+type placed struct {
+	playerID  uint
+	placement int
+}
+
+// placements ranks standings sorted by total bet densely, the rule prizes
+// are settled by.
+func placements(standings []models.TournamentStanding) []placed {
+	ranked := make([]placed, len(standings))
+	for i, s := range standings {
+		ranked[i] = placed{playerID: s.PlayerID, placement: 1}
+		if i > 0 {
+			ranked[i].placement = ranked[i-1].placement
+			if s.TotalBet != standings[i-1].TotalBet {
+				ranked[i].placement++
+			}
+		}
+	}
+	return ranked
+}
+
+// round2 rounds an amount to cents, as the DECIMAL(15, 2) columns do.
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"igaming/internal/models"
+	"igaming/internal/repository"
+)
+
+type TournamentBetRepository struct {
+	db *db
+}
+
+// Create records the bet as placed at bet.CreatedAt.
+func (r *TournamentBetRepository) Create(ctx context.Context, bet *models.TournamentBet) error {
+	r.db.lock()
+	defer r.db.unlock()
+
+	if r.db.player(bet.PlayerID) == nil {
+		return fmt.Errorf("player with ID %d does not exist: %w", bet.PlayerID, repository.ErrPlayerNotFound)
+	}
+	if r.db.tournament(bet.TournamentID) == nil {
+		return fmt.Errorf("tournament with ID %d does not exist: %w", bet.TournamentID, repository.ErrTournamentNotFound)
+	}
+
+	bet.ID = uint(len(r.db.bets) + 1)
+	truncateOnRollback(r.db, &r.db.bets)
+	r.db.bets = append(r.db.bets, *bet)
+	return nil
+}
+
+// GetPlayerStake returns how many bets the player has placed in the
+// tournament and their total.
+func (r *TournamentBetRepository) GetPlayerStake(ctx context.Context, tournamentID, playerID uint) (count int, stake float64, err error) {
+	r.db.rlock()
+	defer r.db.runlock()
+
+	for _, b := range r.db.bets {
+		if b.TournamentID == tournamentID && b.PlayerID == playerID {
+			count++
+			stake += b.BetAmount
+		}
+	}
+	return count, round2(stake), nil
+}
+
+// CountParticipants returns the number of players with a bet in the
+// tournament.
+func (r *TournamentBetRepository) CountParticipants(ctx context.Context, tournamentID uint) (int, error) {
+	r.db.rlock()
+	defer r.db.runlock()
+
+	seen := make(map[uint]bool)
+	for _, b := range r.db.bets {
+		if b.TournamentID == tournamentID {
+			seen[b.PlayerID] = true
+		}
+	}
+	return len(seen), nil
+}
+
+func (r *TournamentBetRepository) GetAll(ctx context.Context) ([]models.TournamentBet, error) {
+	r.db.rlock()
+	defer r.db.runlock()
+
+	var bets []models.TournamentBet
+	bets = append(bets, r.db.bets...)
+	return bets, nil
+}
@@ -0,0 +1,155 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"igaming/internal/models"
+	"igaming/internal/repository"
+)
+
+type TournamentRepository struct {
+	db *db
+}
+
+func (r *TournamentRepository) Create(ctx context.Context, t *models.Tournament) error {
+	r.db.lock()
+	defer r.db.unlock()
+
+	if t.SeasonID != nil && r.db.season(*t.SeasonID) == nil {
+		return fmt.Errorf("season with ID %d does not exist: %w", *t.SeasonID, repository.ErrSeasonNotFound)
+	}
+	if !t.EndDate.After(t.StartDate) {
+		return fmt.Errorf("database operation failed: end date must be after start date")
+	}
+
+	now := r.db.clock.Now()
+	t.ID = uint(len(r.db.tournaments) + 1)
+	t.RakeCollected = 0
+	t.CreatedAt = now
+	t.PrizesDistributed = false
+	t.UpdatedAt = now
+	truncateOnRollback(r.db, &r.db.tournaments)
+	r.db.tournaments = append(r.db.tournaments, *t)
+	return nil
+}
+
+func (r *TournamentRepository) GetAllTournaments(ctx context.Context) ([]models.Tournament, error) {
+	r.db.rlock()
+	defer r.db.runlock()
+
+	var tournaments []models.Tournament
+	tournaments = append(tournaments, r.db.tournaments...)
+	return tournaments, nil
+}
+
+func (r *TournamentRepository) GetTournamentByID(ctx context.Context, id uint) (*models.Tournament, error) {
+	r.db.rlock()
+	defer r.db.runlock()
+
+	t := r.db.tournament(id)
+	if t == nil {
+		return nil, fmt.Errorf("tournament with ID %d not found: %w", id, repository.ErrTournamentNotFound)
+	}
+	tournament := *t
+	return &tournament, nil
+}
+
+// GetForUpdate returns the tournament. A unit of work holds the write lock,
+// so the row stays locked until it ends.
+func (r *TournamentRepository) GetForUpdate(ctx context.Context, id uint) (*models.Tournament, error) {
+	r.db.rlock()
+	defer r.db.runlock()
+
+	t := r.db.tournament(id)
+	if t == nil {
+		return nil, fmt.Errorf("tournament with ID %d does not exist: %w", id, repository.ErrTournamentNotFound)
+	}
+	tournament := *t
+	return &tournament, nil
+}
+
+// AddToPrizePool grows the prize pool and the rake collected.
+func (r *TournamentRepository) AddToPrizePool(ctx context.Context, id uint, amount, rake float64) error {
+	r.db.lock()
+	defer r.db.unlock()
+
+	t := r.db.tournament(id)
+	if t == nil {
+		return fmt.Errorf("tournament with ID %d does not exist: %w", id, repository.ErrTournamentNotFound)
+	}
+
+	restoreOnRollback(r.db, &r.db.tournaments, int(id-1))
+	t.PrizePool = round2(t.PrizePool + amount)
+	t.RakeCollected = round2(t.RakeCollected + rake)
+	t.UpdatedAt = r.db.clock.Now()
+	return nil
+}
+
+// CreateResults records the placements and prizes of a settlement.
+func (r *TournamentRepository) CreateResults(ctx context.Context, results []models.TournamentResult) error {
+	r.db.lock()
+	defer r.db.unlock()
+
+	truncateOnRollback(r.db, &r.db.results)
+	for i := range results {
+		results[i].ID = uint(len(r.db.results) + 1)
+		r.db.results = append(r.db.results, results[i])
+	}
+	return nil
+}
+
+// MarkPrizesDistributed marks the tournament as settled.
+func (r *TournamentRepository) MarkPrizesDistributed(ctx context.Context, id uint) error {
+	r.db.lock()
+	defer r.db.unlock()
+
+	t := r.db.tournament(id)
+	if t == nil {
+		return fmt.Errorf("tournament with ID %d does not exist: %w", id, repository.ErrTournamentNotFound)
+	}
+
+	restoreOnRollback(r.db, &r.db.tournaments, int(id-1))
+	t.PrizesDistributed = true
+	t.UpdatedAt = r.db.clock.Now()
+	return nil
+}
+
+func (r *TournamentRepository) Exists(ctx context.Context, id uint) (bool, error) {
+	r.db.rlock()
+	defer r.db.runlock()
+
+	return r.db.tournament(id) != nil, nil
+}
+
+// GetStandings returns every participant's total bet in the tournament,
+// highest first.
+func (r *TournamentRepository) GetStandings(ctx context.Context, tournamentID uint) ([]models.TournamentStanding, error) {
+	r.db.rlock()
+	defer r.db.runlock()
+
+	return r.db.standings(tournamentID), nil
+}
+
+// GetAllStandings returns the standings of every tournament with bets, keyed
+// by tournament ID, in the same order as GetStandings.
+func (r *TournamentRepository) GetAllStandings(ctx context.Context) (map[uint][]models.TournamentStanding, error) {
+	r.db.rlock()
+	defer r.db.runlock()
+
+	return r.db.allStandings(), nil
+}
+
+// GetResults returns the settled placements of the tournament.
+func (r *TournamentRepository) GetResults(ctx context.Context, tournamentID uint) ([]models.TournamentResult, error) {
+	r.db.rlock()
+	defer r.db.runlock()
+
+	// Results are recorded in placement order, ties by player ID.
+	results := []models.TournamentResult{}
+	for _, res := range r.db.results {
+		if res.TournamentID == tournamentID {
+			results = append(results, res)
+		}
+	}
+	return results, nil
+}
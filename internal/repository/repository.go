@@ -0,0 +1,169 @@
+// Package repository defines the storage interfaces the services and the
+// ranking service depend on, the sentinel errors every implementation
+// returns and the helpers they share. The implementations live in the
+// sqlstore and memory subpackages.
+package repository
+
+import (
+	"context"
+	"igaming/internal/models"
+	"time"
+)
+
+type PlayerRepository interface {
+	Create(ctx context.Context, player *models.Player) error
+	GetAllPlayers(ctx context.Context) ([]models.Player, error)
+	GetPlayerByID(ctx context.Context, id uint) (*models.Player, error)
+	// GetForUpdate returns the player and, in a unit of work, locks the row
+	// until the unit of work ends.
+	GetForUpdate(ctx context.Context, id uint) (*models.Player, error)
+	// AdjustBalance adds delta, which may be negative, to the balance.
+	AdjustBalance(ctx context.Context, id uint, delta float64) error
+	// TotalBalance returns the sum of every player's balance.
+	TotalBalance(ctx context.Context) (float64, error)
+	// GetRankings returns the players visible in the rankings (not deleted
+	// or self-excluded) with their dense rank by balance.
+	GetRankings(ctx context.Context) ([]models.PlayerRanking, error)
+}
+
+type TournamentRepository interface {
+	Create(ctx context.Context, tournament *models.Tournament) error
+	GetAllTournaments(ctx context.Context) ([]models.Tournament, error)
+	GetTournamentByID(ctx context.Context, id uint) (*models.Tournament, error)
+	// GetForUpdate returns the tournament and, in a unit of work, locks the
+	// row until the unit of work ends.
+	GetForUpdate(ctx context.Context, id uint) (*models.Tournament, error)
+	Exists(ctx context.Context, id uint) (bool, error)
+	// AddToPrizePool grows the prize pool and the rake collected.
+	AddToPrizePool(ctx context.Context, id uint, amount, rake float64) error
+	// CreateResults records the placements and prizes of a settlement.
+	CreateResults(ctx context.Context, results []models.TournamentResult) error
+	// MarkPrizesDistributed marks the tournament as settled.
+	MarkPrizesDistributed(ctx context.Context, id uint) error
+	// GetStandings returns every participant's total bet in the tournament,
+	// highest first.
+	GetStandings(ctx context.Context, tournamentID uint) ([]models.TournamentStanding, error)
+	// GetAllStandings returns the standings of every tournament with bets,
+	// keyed by tournament ID, in the same order as GetStandings.
+	GetAllStandings(ctx context.Context) (map[uint][]models.TournamentStanding, error)
+	// GetResults returns the settled placements of the tournament.
+	GetResults(ctx context.Context, tournamentID uint) ([]models.TournamentResult, error)
+}
+
+type TournamentBetRepository interface {
+	// Create records the bet as placed at bet.CreatedAt. It does not check
+	// any betting rule or move money.
+	Create(ctx context.Context, bet *models.TournamentBet) error
+	GetAll(ctx context.Context) ([]models.TournamentBet, error)
+	// GetPlayerStake returns how many bets the player has placed in the
+	// tournament and their total.
+	GetPlayerStake(ctx context.Context, tournamentID, playerID uint) (count int, stake float64, err error)
+	// CountParticipants returns the number of players with a bet in the
+	// tournament.
+	CountParticipants(ctx context.Context, tournamentID uint) (int, error)
+}
+
+type SeasonRepository interface {
+	// Create inserts the season together with its points table.
+	Create(ctx context.Context, season *models.Season) error
+	// GetAll returns every season without its points table.
+	GetAll(ctx context.Context) ([]models.Season, error)
+	// GetByID returns the season with its points table.
+	GetByID(ctx context.Context, id uint) (*models.Season, error)
+	// GetForUpdate returns the season without its points table and, in a
+	// unit of work, locks the row until the unit of work ends.
+	GetForUpdate(ctx context.Context, id uint) (*models.Season, error)
+	// SetPoints replaces the season's points table until its prizes are paid.
+	SetPoints(ctx context.Context, seasonID uint, points []models.SeasonPoints) error
+	// GetStandings returns the season standings ordered by placement.
+	GetStandings(ctx context.Context, seasonID uint) ([]models.SeasonStanding, error)
+	// CreateResults records the placements and prizes of a settlement.
+	CreateResults(ctx context.Context, results []models.SeasonResult) error
+	// MarkPrizesDistributed marks the season as settled.
+	MarkPrizesDistributed(ctx context.Context, id uint) error
+	// GetResults returns the prizes paid for the season, best placement first.
+	GetResults(ctx context.Context, seasonID uint) ([]models.SeasonResult, error)
+}
+
+type RatingRepository interface {
+	// ApplyPending rates every settled tournament that has not been rated
+	// yet, oldest settlement first, and returns how many it rated.
+	ApplyPending(ctx context.Context) (int, error)
+	// Recompute throws away all ratings and rates every settled tournament
+	// again. It returns the number of tournaments and players rated.
+	Recompute(ctx context.Context) (tournaments, players int, err error)
+	// GetRating returns the player's rating, the initial rating when they
+	// have not played a rated tournament.
+	GetRating(ctx context.Context, playerID uint) (*models.PlayerRating, error)
+	// GetHistory returns the player's most recent rating changes, newest first.
+	GetHistory(ctx context.Context, playerID uint, limit int) ([]models.RatingChange, error)
+	// GetLeaderboard returns a page of rated players by rating, best first,
+	// together with the number of ranked players.
+	GetLeaderboard(ctx context.Context, offset, limit int) ([]models.PlayerRating, int, error)
+}
+
+type PlayerLimitRepository interface {
+	// GetLimits returns the player's limits with their usage in the
+	// current period.
+	GetLimits(ctx context.Context, playerID uint) ([]models.PlayerLimit, error)
+	// SetLimits applies the requested limit changes.
+	SetLimits(ctx context.Context, playerID uint, changes []models.PlayerLimit) error
+}
+
+type PlayerExclusionRepository interface {
+	// Create starts a new self-exclusion for the player from now.
+	Create(ctx context.Context, exclusion *models.PlayerExclusion) error
+	// GetByPlayer returns all exclusions of the player, newest first.
+	GetByPlayer(ctx context.Context, playerID uint) ([]models.PlayerExclusion, error)
+	// GetActive returns every exclusion in force right now.
+	GetActive(ctx context.Context) ([]models.PlayerExclusion, error)
+	// CheckNotExcluded returns ErrPlayerExcluded while the player has an
+	// active exclusion.
+	CheckNotExcluded(ctx context.Context, playerID uint) error
+}
+
+type RankingSnapshotRepository interface {
+	// Take snapshots the current rankings. The snapshot time is truncated
+	// to the snapshot interval, so taking it again within the same interval
+	// only adds players missing from it.
+	Take(ctx context.Context) error
+	// RanksAt returns every player's rank in the latest snapshot taken at
+	// or before at. The map is empty when there is no such snapshot.
+	RanksAt(ctx context.Context, at time.Time) (map[uint]int, error)
+	// GetHistory returns the player's snapshots taken between from and to
+	// (inclusive), oldest first.
+	GetHistory(ctx context.Context, playerID uint, from, to time.Time) ([]models.RankingSnapshot, error)
+	// Prune deletes the snapshots taken before before and thins those taken
+	// before hourlyBefore out to the first of each day (see
+	// SnapshotsToPrune).
+	Prune(ctx context.Context, hourlyBefore, before time.Time) error
+}
+
+// UnitOfWork runs operations that span several repositories atomically.
+type UnitOfWork interface {
+	// Do calls fn with repositories that all run in one transaction. The
+	// transaction commits when fn returns nil and rolls back otherwise.
+	// A unit of work started on tx joins it and only rolls back its own
+	// changes when it fails.
+	Do(ctx context.Context, fn func(tx *Store) error) error
+}
+
+// Store bundles the repositories of one storage backend.
+type Store struct {
+	Players     PlayerRepository
+	Tournaments TournamentRepository
+	Bets        TournamentBetRepository
+	Seasons     SeasonRepository
+	Ratings     RatingRepository
+	Limits      PlayerLimitRepository
+	Exclusions  PlayerExclusionRepository
+	Snapshots   RankingSnapshotRepository
+	UnitOfWork  UnitOfWork
+}
+
+// DefaultLimitCoolingOff is how long a player has to wait before a raised or
+// removed responsible gambling limit takes effect.
+const DefaultLimitCoolingOff = 24 * time.Hour
+
+// DefaultSnapshotInterval is how often the rankings are snapshotted.
+const DefaultSnapshotInterval = time.Hour
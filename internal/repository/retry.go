@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"igaming/internal/repository/dberr"
+	"math/rand/v2"
+	"time"
+)
+
+// Defaults for running aborted transactions again. With them a transaction
+// is given up after at most 150ms of backoff, half that on average.
+const (
+	DefaultRetryAttempts  = 5
+	DefaultRetryBaseDelay = 10 * time.Millisecond
+	DefaultRetryMaxDelay  = 500 * time.Millisecond
+)
+
+// RetryObserver is told about transactions run again, e.g. to count them.
+type RetryObserver interface {
+	// TransactionRetried is called before a transaction is run again after
+	// the database aborted it with an error of kind.
+	TransactionRetried(kind dberr.Kind)
+	// TransactionRetriesExhausted is called when a transaction is given up
+	// after its last attempt was aborted with an error of kind.
+	TransactionRetriesExhausted(kind dberr.Kind)
+}
+
+// Retrier runs transactions again when the database aborted them because of
+// concurrent ones: a deadlock, such as a bet and a settlement locking the
+// same player and tournament rows, or a serialization failure. Only a whole
+// transaction can be run again, so a unit of work nested in another one is
+// never retried on its own.
+type Retrier struct {
+	// MaxAttempts is how often a transaction is run at most, the first time
+	// included.
+	MaxAttempts int
+	// The delay before a retry is picked at random up to BaseDelay, doubled
+	// for each retry up to MaxDelay, so transactions that collided do not
+	// collide again.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// Observer is told about every retry; nil for none
+	Observer RetryObserver
+}
+
+// NewRetrier returns a Retrier with the default attempts and delays.
+func NewRetrier(observer RetryObserver) *Retrier {
+	return &Retrier{
+		MaxAttempts: DefaultRetryAttempts,
+		BaseDelay:   DefaultRetryBaseDelay,
+		MaxDelay:    DefaultRetryMaxDelay,
+		Observer:    observer,
+	}
+}
+
+// Run calls fn, which runs one transaction from begin to commit, until it
+// succeeds, fails with an error dberr.Retryable rejects, runs out of
+// attempts, or ctx is done. fn must start from scratch each time, as the
+// work of an aborted transaction was rolled back. A nil Retrier calls fn
+// once.
+func (r *Retrier) Run(ctx context.Context, fn func() error) error {
+	if r == nil {
+		return fn()
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || !dberr.Retryable(err) {
+			return err
+		}
+
+		kind := dberr.Classify(err)
+		if attempt >= r.MaxAttempts {
+			if r.Observer != nil {
+				r.Observer.TransactionRetriesExhausted(kind)
+			}
+			return fmt.Errorf("transaction aborted %d times: %w", attempt, err)
+		}
+
+		timer := time.NewTimer(r.delay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("transaction aborted, not retried: %w", err)
+		case <-timer.C:
+		}
+
+		if r.Observer != nil {
+			r.Observer.TransactionRetried(kind)
+		}
+	}
+}
+
+// delay returns how long to wait before the retry following attempt.
+func (r *Retrier) delay(attempt int) time.Duration {
+	limit := r.MaxDelay
+	if shift := attempt - 1; shift < 31 && r.BaseDelay<<shift < limit {
+		limit = r.BaseDelay << shift
+	}
+	if limit <= 0 {
+		return 0
+	}
+	return rand.N(limit + 1)
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"igaming/internal/repository/dberr"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// observer records the calls of a Retrier.
+type observer struct {
+	retried   []dberr.Kind
+	exhausted []dberr.Kind
+}
+
+func (o *observer) TransactionRetried(kind dberr.Kind) { o.retried = append(o.retried, kind) }
+func (o *observer) TransactionRetriesExhausted(kind dberr.Kind) {
+	o.exhausted = append(o.exhausted, kind)
+}
+
+var (
+	deadlock      = &mysql.MySQLError{Number: 1213}
+	serialization = &pgconn.PgError{Code: "40001"}
+	lockTimeout   = &mysql.MySQLError{Number: 1205}
+)
+
+// failing returns an fn that fails with errs in turn, then succeeds, and
+// the number of times it was called.
+func failing(errs ...error) (func() error, *int) {
+	calls := new(int)
+	return func() error {
+		*calls++
+		if *calls <= len(errs) {
+			return errs[*calls-1]
+		}
+		return nil
+	}, calls
+}
+
+func newTestRetrier(o *observer) *Retrier {
+	return &Retrier{MaxAttempts: 3, BaseDelay: time.Microsecond, MaxDelay: time.Millisecond, Observer: o}
+}
+
+func TestRetrierRetriesAbortedTransactions(t *testing.T) {
+	o := &observer{}
+	fn, calls := failing(deadlock, serialization)
+
+	if err := newTestRetrier(o).Run(context.Background(), fn); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if *calls != 3 {
+		t.Errorf("fn called %d times, want 3", *calls)
+	}
+	if want := []dberr.Kind{dberr.Deadlock, dberr.SerializationFailure}; !equal(o.retried, want) {
+		t.Errorf("retried %v, want %v", o.retried, want)
+	}
+	if len(o.exhausted) != 0 {
+		t.Errorf("exhausted %v, want none", o.exhausted)
+	}
+}
+
+func TestRetrierGivesUpAfterMaxAttempts(t *testing.T) {
+	o := &observer{}
+	fn, calls := failing(deadlock, deadlock, deadlock, deadlock)
+
+	err := newTestRetrier(o).Run(context.Background(), fn)
+	if !errors.Is(err, deadlock) || !strings.Contains(err.Error(), "aborted 3 times") {
+		t.Fatalf("Run error %v, want the deadlock after 3 attempts", err)
+	}
+	if *calls != 3 {
+		t.Errorf("fn called %d times, want 3", *calls)
+	}
+	if want := []dberr.Kind{dberr.Deadlock, dberr.Deadlock}; !equal(o.retried, want) {
+		t.Errorf("retried %v, want %v", o.retried, want)
+	}
+	if want := []dberr.Kind{dberr.Deadlock}; !equal(o.exhausted, want) {
+		t.Errorf("exhausted %v, want %v", o.exhausted, want)
+	}
+}
+
+func TestRetrierReturnsOtherErrorsAtOnce(t *testing.T) {
+	tests := map[string]error{
+		"lock timeout":     lockTimeout,
+		"unique violation": &pgconn.PgError{Code: "23505"},
+		"other":            ErrInsufficientFunds,
+	}
+	for name, want := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := &observer{}
+			fn, calls := failing(want, want)
+
+			if err := newTestRetrier(o).Run(context.Background(), fn); err != want {
+				t.Fatalf("Run error %v, want %v unwrapped", err, want)
+			}
+			if *calls != 1 {
+				t.Errorf("fn called %d times, want 1", *calls)
+			}
+			if len(o.retried) != 0 || len(o.exhausted) != 0 {
+				t.Errorf("observer told of retried %v, exhausted %v; want nothing", o.retried, o.exhausted)
+			}
+		})
+	}
+}
+
+func TestRetrierStopsWhenContextIsDone(t *testing.T) {
+	o := &observer{}
+	fn, calls := failing(deadlock)
+	r := &Retrier{MaxAttempts: 3, BaseDelay: time.Hour, MaxDelay: time.Hour, Observer: o}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := r.Run(ctx, fn)
+	if !errors.Is(err, deadlock) || !strings.Contains(err.Error(), "not retried") {
+		t.Fatalf("Run error %v, want the deadlock, not retried", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Run returned after %v, want it to stop waiting when ctx is done", elapsed)
+	}
+	if *calls != 1 {
+		t.Errorf("fn called %d times, want 1", *calls)
+	}
+	if len(o.retried) != 0 || len(o.exhausted) != 0 {
+		t.Errorf("observer told of retried %v, exhausted %v; want nothing", o.retried, o.exhausted)
+	}
+}
+
+func TestNilRetrierRunsOnce(t *testing.T) {
+	var r *Retrier
+	fn, calls := failing(deadlock)
+
+	if err := r.Run(context.Background(), fn); err != deadlock {
+		t.Fatalf("Run error %v, want the deadlock", err)
+	}
+	if *calls != 1 {
+		t.Errorf("fn called %d times, want 1", *calls)
+	}
+}
+
+func TestRetrierDelay(t *testing.T) {
+	r := NewRetrier(nil)
+	for attempt, limit := range map[int]time.Duration{
+		1:  10 * time.Millisecond,
+		2:  20 * time.Millisecond,
+		4:  80 * time.Millisecond,
+		6:  DefaultRetryMaxDelay,
+		40: DefaultRetryMaxDelay,
+	} {
+		for range 100 {
+			if d := r.delay(attempt); d < 0 || d > limit {
+				t.Fatalf("delay(%d) = %v, want between 0 and %v", attempt, d, limit)
+			}
+		}
+	}
+}
+
+func equal(a, b []dberr.Kind) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
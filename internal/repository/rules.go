@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"fmt"
+	"igaming/internal/models"
+	"igaming/internal/rating"
+	"time"
+)
+
+// LimitUsage returns how much of a limit of the given type has been used.
+func LimitUsage(limitType string, wagered, won float64) float64 {
+	if limitType == models.LimitTypeLoss {
+		return max(wagered-won, 0)
+	}
+	return wagered
+}
+
+// ExcludedError returns the ErrPlayerExcluded error for the player's active
+// exclusion e.
+func ExcludedError(e *models.PlayerExclusion) error {
+	if e.EndsAt == nil {
+		return fmt.Errorf("%w: player %d is permanently excluded", ErrPlayerExcluded, e.PlayerID)
+	}
+	return fmt.Errorf("%w: player %d is excluded until %s",
+		ErrPlayerExcluded, e.PlayerID, e.EndsAt.Format(time.RFC3339))
+}
+
+// RatingState is a player's rating while tournaments are being rated.
+type RatingState struct {
+	Rating    float64
+	Played    int
+	UpdatedAt time.Time
+}
+
+// RatedPlacement is a participant's dense placement by total bet in a
+// settled tournament, the rule prizes are settled by.
+type RatedPlacement struct {
+	PlayerID  uint
+	Placement int
+}
+
+// RateTournament applies one settled tournament to the states, adding
+// players seen for the first time, and returns the rating changes.
+func RateTournament(states map[uint]*RatingState, tournamentID uint, field []RatedPlacement, settledAt time.Time) []models.RatingChange {
+	participants := make([]rating.Participant, len(field))
+	for i, p := range field {
+		s, ok := states[p.PlayerID]
+		if !ok {
+			s = &RatingState{Rating: rating.Initial}
+			states[p.PlayerID] = s
+		}
+		participants[i] = rating.Participant{PlayerID: p.PlayerID, Placement: p.Placement, Rating: s.Rating}
+	}
+
+	after := rating.Update(participants)
+
+	changes := make([]models.RatingChange, len(field))
+	for i, p := range participants {
+		s := states[p.PlayerID]
+		s.Rating = after[i]
+		s.Played++
+		s.UpdatedAt = settledAt
+
+		changes[i] = models.RatingChange{
+			PlayerID:     p.PlayerID,
+			TournamentID: tournamentID,
+			Placement:    p.Placement,
+			FieldSize:    len(field),
+			RatingBefore: p.Rating,
+			RatingAfter:  after[i],
+			CreatedAt:    settledAt,
+		}
+	}
+	return changes
+}
+
+// SnapshotsToPrune returns which of the snapshot times a prune removes:
+// those before before, and of those before hourlyBefore all but the first of
+// each UTC day. A zero before removes none for age alone. times must be
+// sorted, oldest first.
+func SnapshotsToPrune(times []time.Time, hourlyBefore, before time.Time) []time.Time {
+	var prune []time.Time
+	var day time.Time
+	for _, t := range times {
+		switch d := t.UTC().Truncate(24 * time.Hour); {
+		case t.Before(before):
+			prune = append(prune, t)
+		case t.Before(hourlyBefore) && d.Equal(day):
+			prune = append(prune, t)
+		default:
+			day = d
+		}
+	}
+	return prune
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSnapshotsToPrune(t *testing.T) {
+	at := func(day, hour int) time.Time {
+		return time.Date(2025, time.March, day, hour, 0, 0, 0, time.UTC)
+	}
+	times := []time.Time{
+		at(1, 0), at(1, 1), at(1, 23),
+		at(2, 5), at(2, 6),
+		at(9, 0), at(9, 1),
+		at(10, 12), at(10, 13),
+	}
+
+	tests := []struct {
+		name                 string
+		hourlyBefore, before time.Time
+		want                 []time.Time
+	}{
+		{
+			name:         "daily before the hourly window",
+			hourlyBefore: at(10, 0),
+			want:         []time.Time{at(1, 1), at(1, 23), at(2, 6), at(9, 1)},
+		},
+		{
+			name:         "daily and then none",
+			hourlyBefore: at(10, 0),
+			before:       at(2, 0),
+			want:         []time.Time{at(1, 0), at(1, 1), at(1, 23), at(2, 6), at(9, 1)},
+		},
+		{
+			name:         "window splits a day",
+			hourlyBefore: at(9, 1),
+			want:         []time.Time{at(1, 1), at(1, 23), at(2, 6)},
+		},
+		{
+			name:         "the day's first snapshot was pruned for age",
+			hourlyBefore: at(10, 0),
+			before:       at(2, 6),
+			want:         []time.Time{at(1, 0), at(1, 1), at(1, 23), at(2, 5), at(9, 1)},
+		},
+		{
+			name: "nothing old enough",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := SnapshotsToPrune(times, tc.hourlyBefore, tc.before); !slices.Equal(got, tc.want) {
+				t.Errorf("pruned %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+// Package sqlstore implements the repositories on the SQL databases: MySQL,
+// PostgreSQL, and SQLite for development and small installs that keep their
+// data in a single file. Queries are written once, with ? placeholders, and
+// rebound to the dialect's own when run; the few places where the dialects
+// differ go through the helpers in dialect.go.
+//
+// SQLite has one writer at a time and no row locks. Transactions are begun
+// with BEGIN IMMEDIATE (see config.OpenDB), which takes the database write
+// lock up front, so reading rows in a transaction locks them as firmly as
+// SELECT ... FOR UPDATE does on the other databases. Timestamps are stored as
+// Unix microseconds.
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"igaming/internal/clock"
+	"igaming/internal/dialect"
+	"igaming/internal/repository"
+	"igaming/internal/tracing"
+	"sync"
+)
+
+// dbtx is implemented by both the database and its transactions so query
+// helpers can be shared between plain reads and transactional code.
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	// dialect is the flavour of SQL the queries are run as.
+	dialect() dialect.Dialect
+}
+
+// conn is what the repositories run their statements on: the database, or
+// the transaction of a unit of work.
+type conn interface {
+	dbtx
+	// inTx runs fn in a transaction committed when fn succeeds and rolled
+	// back when it fails.
+	inTx(ctx context.Context, fn func(tx conn) error) error
+}
+
+// database is the conn of repositories outside a unit of work. Transactions
+// begun on it are run again when the database aborts them because of a
+// deadlock or serialization failure.
+type database struct {
+	db      *sql.DB
+	d       dialect.Dialect
+	retrier *repository.Retrier
+}
+
+func (d database) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return d.db.ExecContext(ctx, d.d.Rebind(query), args...)
+}
+
+func (d database) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return d.db.QueryContext(ctx, d.d.Rebind(query), args...)
+}
+
+func (d database) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return d.db.QueryRowContext(ctx, d.d.Rebind(query), args...)
+}
+
+func (d database) dialect() dialect.Dialect {
+	return d.d
+}
+
+func (d database) inTx(ctx context.Context, fn func(tx conn) error) error {
+	return d.retrier.Run(ctx, func() error {
+		tx, err := d.db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer tx.Rollback()
+
+		if err := fn(txConn{tx: tx, d: d.d, savepoints: new(int)}); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("transaction commit failed: %w", err)
+		}
+		return nil
+	})
+}
+
+// txConn is the conn of repositories in a unit of work. Transactions the
+// repositories begin on it are savepoints, so a repository method that
+// fails rolls back its own statements without ending the unit of work.
+// They are never run again on their own: an abort ends the whole
+// transaction, which the unit of work's database retries.
+type txConn struct {
+	tx         *sql.Tx
+	d          dialect.Dialect
+	savepoints *int
+}
+
+func (c txConn) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return c.tx.ExecContext(ctx, c.d.Rebind(query), args...)
+}
+
+func (c txConn) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return c.tx.QueryContext(ctx, c.d.Rebind(query), args...)
+}
+
+func (c txConn) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return c.tx.QueryRowContext(ctx, c.d.Rebind(query), args...)
+}
+
+func (c txConn) dialect() dialect.Dialect {
+	return c.d
+}
+
+func (c txConn) inTx(ctx context.Context, fn func(tx conn) error) error {
+	*c.savepoints++
+	name := fmt.Sprintf("sp_%d", *c.savepoints)
+	if _, err := c.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("failed to create savepoint: %w", err)
+	}
+
+	if err := fn(c); err != nil {
+		// Rolled back even when ctx is done, as the unit of work may go on.
+		if _, rbErr := c.tx.ExecContext(context.WithoutCancel(ctx), "ROLLBACK TO SAVEPOINT "+name); rbErr != nil {
+			return errors.Join(err, fmt.Errorf("failed to roll back savepoint: %w", rbErr))
+		}
+		return err
+	}
+
+	if _, err := c.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("failed to release savepoint: %w", err)
+	}
+	return nil
+}
+
+// unitOfWork runs operations in a transaction begun on db, or in a
+// savepoint when db is itself a unit of work.
+type unitOfWork struct {
+	db       conn
+	clock    clock.Clock
+	ratingMu *sync.Mutex
+}
+
+func (u unitOfWork) Do(ctx context.Context, fn func(tx *repository.Store) error) error {
+	return u.db.inTx(ctx, func(tx conn) error {
+		return fn(newStore(tx, u.clock, u.ratingMu))
+	})
+}
+
+// NewStore returns the repositories backed by db, a database of dialect d,
+// each method traced in a span of its own. Transactions aborted by the
+// database are run again by retrier; nil runs each once.
+func NewStore(db *sql.DB, d dialect.Dialect, clk clock.Clock, retrier *repository.Retrier) *repository.Store {
+	return tracing.Store(newStore(database{db: db, d: d, retrier: retrier}, clk, new(sync.Mutex)))
+}
+
+// newStore returns the repositories running on db. ratingMu serializes
+// rating updates across the store and its units of work.
+func newStore(db conn, clk clock.Clock, ratingMu *sync.Mutex) *repository.Store {
+	return &repository.Store{
+		Players:     newPlayerRepository(db),
+		Tournaments: newTournamentRepository(db),
+		Bets:        newTournamentBetRepository(db),
+		Seasons:     newSeasonRepository(db),
+		Ratings:     newRatingRepository(db, ratingMu),
+		Limits:      newPlayerLimitRepository(db, clk, repository.DefaultLimitCoolingOff),
+		Exclusions:  newPlayerExclusionRepository(db, clk),
+		Snapshots:   newRankingSnapshotRepository(db, clk, repository.DefaultSnapshotInterval),
+		UnitOfWork:  unitOfWork{db: db, clock: clk, ratingMu: ratingMu},
+	}
+}
@@ -0,0 +1,99 @@
+package sqlstore
+
+import (
+	"context"
+	"fmt"
+	"igaming/internal/dialect"
+	"strings"
+	"time"
+)
+
+// forUpdate returns the clause that locks the rows a SELECT reads until the
+// end of the transaction. SQLite needs none: its transactions hold the
+// database write lock from the start.
+func forUpdate(d dialect.Dialect) string {
+	if d == dialect.SQLite {
+		return ""
+	}
+	return " FOR UPDATE"
+}
+
+// money rounds the amount computed by expr to cents on SQLite, which keeps
+// amounts as REAL. The other databases add up DECIMAL columns exactly.
+func money(d dialect.Dialect, expr string) string {
+	if d == dialect.SQLite {
+		return "ROUND(" + expr + ", 2)"
+	}
+	return expr
+}
+
+// timestamp scans a nullable timestamp computed by a query, such as
+// MAX(created_at). The SQLite driver converts only columns declared as
+// TIMESTAMP, so computed ones arrive as the stored Unix microseconds.
+type timestamp struct {
+	t **time.Time
+}
+
+func (ts timestamp) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*ts.t = nil
+	case int64:
+		t := time.UnixMicro(v).UTC()
+		*ts.t = &t
+	case time.Time:
+		*ts.t = &v
+	default:
+		return fmt.Errorf("cannot scan %T into a timestamp", src)
+	}
+	return nil
+}
+
+// insert runs an INSERT and returns the ID of the row it created: from
+// LastInsertId on MySQL, from a RETURNING clause elsewhere.
+func insert(ctx context.Context, q dbtx, query string, args ...any) (uint, error) {
+	if q.dialect() != dialect.MySQL {
+		var id uint
+		err := q.QueryRowContext(ctx, query+" RETURNING id", args...).Scan(&id)
+		return id, err
+	}
+
+	result, err := q.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last insert ID: %w", err)
+	}
+	return uint(id), nil
+}
+
+// upsert returns the clause that turns an INSERT into an update of cols
+// when a row with the same key already exists. key lists the columns of the
+// unique key, which MySQL finds by itself.
+func upsert(d dialect.Dialect, key string, cols ...string) string {
+	sets := make([]string, len(cols))
+	if d == dialect.MySQL {
+		for i, c := range cols {
+			sets[i] = c + " = VALUES(" + c + ")"
+		}
+		return "ON DUPLICATE KEY UPDATE " + strings.Join(sets, ", ")
+	}
+
+	for i, c := range cols {
+		sets[i] = c + " = EXCLUDED." + c
+	}
+	return "ON CONFLICT (" + key + ") DO UPDATE SET " + strings.Join(sets, ", ")
+}
+
+// placeholders returns "?, ?, ..." with n placeholders.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// rowPlaceholders returns "(?, ?), (?, ?), ..." for n rows of width columns.
+func rowPlaceholders(n, width int) string {
+	row := "(" + placeholders(width) + ")"
+	return strings.TrimSuffix(strings.Repeat(row+", ", n), ", ")
+}
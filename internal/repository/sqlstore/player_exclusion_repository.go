@@ -0,0 +1,138 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"igaming/internal/clock"
+	"igaming/internal/models"
+	"igaming/internal/repository"
+	"time"
+)
+
+type PlayerExclusionRepository struct {
+	db    conn
+	clock clock.Clock
+}
+
+func newPlayerExclusionRepository(db conn, clk clock.Clock) *PlayerExclusionRepository {
+	return &PlayerExclusionRepository{db: db, clock: clk}
+}
+
+// Create starts a new self-exclusion for the player from now.
+func (r *PlayerExclusionRepository) Create(ctx context.Context, exclusion *models.PlayerExclusion) error {
+	now := r.clock.Now()
+	endsAt, ok := models.ExclusionEnd(exclusion.Duration, now)
+	if !ok {
+		return fmt.Errorf("unknown exclusion duration %q", exclusion.Duration)
+	}
+
+	var exists bool
+	err := r.db.QueryRowContext(ctx,
+		"SELECT EXISTS(SELECT 1 FROM players WHERE id = ?)",
+		exclusion.PlayerID,
+	).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check player: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("player with ID %d does not exist: %w", exclusion.PlayerID, repository.ErrPlayerNotFound)
+	}
+
+	id, err := insert(ctx, r.db,
+		`INSERT INTO player_exclusions (player_id, duration, starts_at, ends_at) 
+		 VALUES (?, ?, ?, ?)`,
+		exclusion.PlayerID,
+		exclusion.Duration,
+		now,
+		endsAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create exclusion: %w", err)
+	}
+
+	exclusion.ID = id
+	exclusion.StartsAt = now
+	exclusion.EndsAt = endsAt
+	return nil
+}
+
+// GetByPlayer returns all exclusions of the player, newest first.
+func (r *PlayerExclusionRepository) GetByPlayer(ctx context.Context, playerID uint) ([]models.PlayerExclusion, error) {
+	return queryExclusions(ctx, r.db,
+		`SELECT id, player_id, duration, starts_at, ends_at 
+		 FROM player_exclusions WHERE player_id = ? ORDER BY starts_at DESC, id DESC`,
+		playerID,
+	)
+}
+
+// GetActive returns every exclusion in force right now.
+func (r *PlayerExclusionRepository) GetActive(ctx context.Context) ([]models.PlayerExclusion, error) {
+	now := r.clock.Now()
+	return queryExclusions(ctx, r.db,
+		`SELECT id, player_id, duration, starts_at, ends_at 
+		 FROM player_exclusions 
+		 WHERE starts_at <= ? AND (ends_at IS NULL OR ends_at > ?) 
+		 ORDER BY starts_at DESC, id DESC`,
+		now, now,
+	)
+}
+
+// CheckNotExcluded returns ErrPlayerExcluded while the player has an active
+// exclusion. Anything that lets a player log in, deposit or bet should call
+// it (bets check it inside their own transaction).
+func (r *PlayerExclusionRepository) CheckNotExcluded(ctx context.Context, playerID uint) error {
+	return checkNotExcluded(ctx, r.db, playerID, r.clock.Now())
+}
+
+func checkNotExcluded(ctx context.Context, q dbtx, playerID uint, now time.Time) error {
+	var e models.PlayerExclusion
+	err := q.QueryRowContext(ctx,
+		`SELECT id, player_id, duration, starts_at, ends_at 
+		 FROM player_exclusions 
+		 WHERE player_id = ? AND starts_at <= ? AND (ends_at IS NULL OR ends_at > ?) 
+		 ORDER BY ends_at IS NULL DESC, ends_at DESC 
+		 LIMIT 1`,
+		playerID, now, now,
+	).Scan(&e.ID, &e.PlayerID, &e.Duration, &e.StartsAt, &e.EndsAt)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check exclusions: %w", err)
+	}
+
+	return repository.ExcludedError(&e)
+}
+
+func queryExclusions(ctx context.Context, q dbtx, query string, args ...any) ([]models.PlayerExclusion, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query exclusions: %w", err)
+	}
+	defer rows.Close()
+
+	exclusions := []models.PlayerExclusion{}
+	for rows.Next() {
+		var e models.PlayerExclusion
+		err := rows.Scan(
+			&e.ID,
+			&e.PlayerID,
+			&e.Duration,
+			&e.StartsAt,
+			&e.EndsAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan exclusion row: %w", err)
+		}
+		exclusions = append(exclusions, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return exclusions, nil
+}
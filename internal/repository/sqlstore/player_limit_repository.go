@@ -0,0 +1,189 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"igaming/internal/clock"
+	"igaming/internal/models"
+	"igaming/internal/repository"
+	"time"
+)
+
+type PlayerLimitRepository struct {
+	db         conn
+	clock      clock.Clock
+	coolingOff time.Duration
+}
+
+func newPlayerLimitRepository(db conn, clk clock.Clock, coolingOff time.Duration) *PlayerLimitRepository {
+	return &PlayerLimitRepository{
+		db:         db,
+		clock:      clk,
+		coolingOff: coolingOff,
+	}
+}
+
+// GetLimits returns the player's limits with their usage in the current period.
+func (r *PlayerLimitRepository) GetLimits(ctx context.Context, playerID uint) ([]models.PlayerLimit, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx,
+		"SELECT EXISTS(SELECT 1 FROM players WHERE id = ?)",
+		playerID,
+	).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check player: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("player with ID %d does not exist: %w", playerID, repository.ErrPlayerNotFound)
+	}
+
+	now := r.clock.Now()
+	limits, err := loadPlayerLimits(ctx, r.db, playerID, now, false)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range limits {
+		wagered, won, err := playerActivity(ctx, r.db, playerID, models.LimitPeriodStart(limits[i].Period, now))
+		if err != nil {
+			return nil, err
+		}
+		limits[i].Used = repository.LimitUsage(limits[i].Type, wagered, won)
+	}
+
+	return limits, nil
+}
+
+// SetLimits applies the requested limit changes. The player row is locked so
+// the changes are serialized with bets placed by the same player.
+func (r *PlayerLimitRepository) SetLimits(ctx context.Context, playerID uint, changes []models.PlayerLimit) error {
+	return r.db.inTx(ctx, func(tx conn) error {
+		var id uint
+		err := tx.QueryRowContext(ctx,
+			"SELECT id FROM players WHERE id = ?"+forUpdate(tx.dialect()),
+			playerID,
+		).Scan(&id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("player with ID %d does not exist: %w", playerID, repository.ErrPlayerNotFound)
+			}
+			return fmt.Errorf("failed to lock player: %w", err)
+		}
+
+		now := r.clock.Now()
+		existing, err := loadPlayerLimits(ctx, tx, playerID, now, true)
+		if err != nil {
+			return err
+		}
+
+		for _, change := range changes {
+			limit := models.PlayerLimit{PlayerID: playerID, Type: change.Type, Period: change.Period}
+			for _, l := range existing {
+				if l.Type == change.Type && l.Period == change.Period {
+					limit = l
+					break
+				}
+			}
+
+			limit.Change(change.Amount, now, r.coolingOff)
+
+			if err := savePlayerLimit(ctx, tx, &limit); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func savePlayerLimit(ctx context.Context, q dbtx, l *models.PlayerLimit) error {
+	if l.Empty() {
+		_, err := q.ExecContext(ctx,
+			"DELETE FROM player_limits WHERE player_id = ? AND limit_type = ? AND period = ?",
+			l.PlayerID, l.Type, l.Period,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to delete limit: %w", err)
+		}
+		return nil
+	}
+
+	_, err := q.ExecContext(ctx,
+		`INSERT INTO player_limits 
+		 (player_id, limit_type, period, amount, pending_amount, pending_effective_at) 
+		 VALUES (?, ?, ?, ?, ?, ?) 
+		 `+upsert(q.dialect(), "player_id, limit_type, period", "amount", "pending_amount", "pending_effective_at"),
+		l.PlayerID, l.Type, l.Period, l.Amount, l.PendingAmount, l.PendingEffectiveAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save limit: %w", err)
+	}
+	return nil
+}
+
+// loadPlayerLimits returns the player's limits resolved at now, so limits
+// whose cooling-off period is over are reported with their new amount.
+func loadPlayerLimits(ctx context.Context, q dbtx, playerID uint, now time.Time, lock bool) ([]models.PlayerLimit, error) {
+	query := `SELECT player_id, limit_type, period, amount, pending_amount, pending_effective_at 
+		FROM player_limits WHERE player_id = ? 
+		ORDER BY limit_type, CASE period WHEN 'daily' THEN 1 WHEN 'weekly' THEN 2 ELSE 3 END`
+	if lock {
+		query += forUpdate(q.dialect())
+	}
+
+	rows, err := q.QueryContext(ctx, query, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query limits: %w", err)
+	}
+	defer rows.Close()
+
+	limits := []models.PlayerLimit{}
+	for rows.Next() {
+		var l models.PlayerLimit
+		err := rows.Scan(
+			&l.PlayerID,
+			&l.Type,
+			&l.Period,
+			&l.Amount,
+			&l.PendingAmount,
+			&l.PendingEffectiveAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan limit row: %w", err)
+		}
+		l.Resolve(now)
+		limits = append(limits, l)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return limits, nil
+}
+
+// playerActivity returns how much the player has bet and won in prizes since
+// the given time.
+func playerActivity(ctx context.Context, q dbtx, playerID uint, since time.Time) (wagered, won float64, err error) {
+	err = q.QueryRowContext(ctx,
+		`SELECT COALESCE(`+money(q.dialect(), "SUM(bet_amount)")+`, 0) FROM tournament_bets 
+		 WHERE player_id = ? AND created_at >= ?`,
+		playerID, since,
+	).Scan(&wagered)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to sum wagers: %w", err)
+	}
+
+	err = q.QueryRowContext(ctx,
+		`SELECT COALESCE(`+money(q.dialect(), "SUM(prize_amount)")+`, 0) FROM tournament_results 
+		 WHERE player_id = ? AND created_at >= ?`,
+		playerID, since,
+	).Scan(&won)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to sum prizes: %w", err)
+	}
+
+	return wagered, won, nil
+}
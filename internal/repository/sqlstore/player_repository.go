@@ -1,4 +1,4 @@
-package repository
+package sqlstore
 
 import (
 	"context"
@@ -6,13 +6,15 @@ import (
 	"errors"
 	"fmt"
 	"igaming/internal/models"
+	"igaming/internal/repository"
+	"igaming/internal/repository/dberr"
 )
 
 type PlayerRepository struct {
-	db *sql.DB
+	db conn
 }
 
-func NewPlayerRepository(db *sql.DB) *PlayerRepository {
+func newPlayerRepository(db conn) *PlayerRepository {
 	return &PlayerRepository{db: db}
 }
 
@@ -21,8 +23,9 @@ func (r *PlayerRepository) Create(ctx context.Context, player *models.Player) er
 	(name, email, password_hash, account_balance) 
 	VALUES (?, ?, ?, ?)`
 
-	result, err := r.db.ExecContext(
+	id, err := insert(
 		ctx,
+		r.db,
 		query,
 		player.Name,
 		player.Email,
@@ -31,15 +34,13 @@ func (r *PlayerRepository) Create(ctx context.Context, player *models.Player) er
 	)
 
 	if err != nil {
+		if dberr.Is(err, dberr.UniqueViolation) {
+			return fmt.Errorf("failed to create player: %s: %w", player.Email, repository.ErrEmailTaken)
+		}
 		return fmt.Errorf("failed to create player: %w", err)
 	}
 
-	id, err := result.LastInsertId()
-	if err != nil {
-		return fmt.Errorf("failed to get last insert ID: %w", err)
-	}
-
-	player.ID = uint(id)
+	player.ID = id
 	return nil
 }
 
@@ -101,7 +102,7 @@ func (r *PlayerRepository) GetPlayerByID(ctx context.Context, id uint) (*models.
 
     if err != nil {
         if errors.Is(err, sql.ErrNoRows) {
-            return nil, fmt.Errorf("player with ID %d not found", id)
+            return nil, fmt.Errorf("player with ID %d not found: %w", id, repository.ErrPlayerNotFound)
         }
         return nil, fmt.Errorf("failed to get player: %w", err)
     }
@@ -109,6 +110,60 @@ func (r *PlayerRepository) GetPlayerByID(ctx context.Context, id uint) (*models.
     return &player, nil
 }
 
+// GetForUpdate returns the player with the row locked until the end of the
+// transaction (see forUpdate).
+func (r *PlayerRepository) GetForUpdate(ctx context.Context, id uint) (*models.Player, error) {
+	var player models.Player
+	err := r.db.QueryRowContext(ctx,
+		`SELECT id, name, email, account_balance, created_at, updated_at, deleted_at 
+		 FROM players WHERE id = ?`+forUpdate(r.db.dialect()),
+		id,
+	).Scan(
+		&player.ID,
+		&player.Name,
+		&player.Email,
+		&player.AccountBalance,
+		&player.CreatedAt,
+		&player.UpdatedAt,
+		&player.DeletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("player with ID %d does not exist: %w", id, repository.ErrPlayerNotFound)
+		}
+		return nil, fmt.Errorf("failed to lock player: %w", err)
+	}
+
+	return &player, nil
+}
+
+// AdjustBalance adds delta, which may be negative, to the player's balance.
+// Callers lock the player with GetForUpdate first.
+func (r *PlayerRepository) AdjustBalance(ctx context.Context, id uint, delta float64) error {
+	_, err := r.db.ExecContext(ctx,
+		"UPDATE players SET account_balance = "+money(r.db.dialect(), "account_balance + ?")+" WHERE id = ?",
+		delta,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update balance: %w", err)
+	}
+
+	return nil
+}
+
+// TotalBalance returns the sum of every player's balance, what the platform
+// owes its players.
+func (r *PlayerRepository) TotalBalance(ctx context.Context) (float64, error) {
+	var total float64
+	err := r.db.QueryRowContext(ctx, "SELECT "+money(r.db.dialect(), "COALESCE(SUM(account_balance), 0)")+" FROM players").Scan(&total)
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum balances: %w", err)
+	}
+
+	return total, nil
+}
+
 func (r *PlayerRepository) GetRankings(ctx context.Context) ([]models.PlayerRanking, error) {
     query := `SELECT * FROM player_rankings`
 
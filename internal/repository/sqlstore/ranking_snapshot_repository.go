@@ -0,0 +1,178 @@
+package sqlstore
+
+import (
+	"context"
+	"fmt"
+	"igaming/internal/clock"
+	"igaming/internal/dialect"
+	"igaming/internal/models"
+	"igaming/internal/repository"
+	"time"
+)
+
+type RankingSnapshotRepository struct {
+	db       conn
+	clock    clock.Clock
+	interval time.Duration
+}
+
+func newRankingSnapshotRepository(db conn, clk clock.Clock, interval time.Duration) *RankingSnapshotRepository {
+	return &RankingSnapshotRepository{db: db, clock: clk, interval: interval}
+}
+
+// takeSnapshot copies player_rankings into ranking_snapshots, skipping the
+// rows of a snapshot already taken.
+var takeSnapshot = map[dialect.Dialect]string{
+	dialect.MySQL: `INSERT IGNORE INTO ranking_snapshots (taken_at, player_id, player_rank, account_balance) 
+		 SELECT ?, player_id, player_rank, account_balance FROM player_rankings`,
+	dialect.Postgres: `INSERT INTO ranking_snapshots (taken_at, player_id, player_rank, account_balance) 
+		 SELECT ?::timestamptz, player_id, player_rank, account_balance FROM player_rankings 
+		 ON CONFLICT DO NOTHING`,
+	// WHERE true tells the upsert clause from a join constraint.
+	dialect.SQLite: `INSERT INTO ranking_snapshots (taken_at, player_id, player_rank, account_balance) 
+		 SELECT ?, player_id, player_rank, account_balance FROM player_rankings 
+		 WHERE true 
+		 ON CONFLICT DO NOTHING`,
+}
+
+// Take copies player_rankings into ranking_snapshots. The snapshot time is
+// truncated to the snapshot interval, so taking it again within the same
+// interval (e.g. after a restart) is a no-op.
+func (r *RankingSnapshotRepository) Take(ctx context.Context) error {
+	takenAt := r.clock.Now().Truncate(r.interval)
+
+	_, err := r.db.ExecContext(ctx, takeSnapshot[r.db.dialect()], takenAt)
+	if err != nil {
+		return fmt.Errorf("failed to take ranking snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// RanksAt returns every player's rank in the latest snapshot taken at or
+// before at. The map is empty when there is no such snapshot.
+func (r *RankingSnapshotRepository) RanksAt(ctx context.Context, at time.Time) (map[uint]int, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT player_id, player_rank FROM ranking_snapshots 
+		 WHERE taken_at = (SELECT MAX(taken_at) FROM ranking_snapshots WHERE taken_at <= ?)`,
+		at,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ranking snapshot: %w", err)
+	}
+	defer rows.Close()
+
+	ranks := make(map[uint]int)
+	for rows.Next() {
+		var playerID uint
+		var rank int
+		if err := rows.Scan(&playerID, &rank); err != nil {
+			return nil, fmt.Errorf("failed to scan ranking snapshot: %w", err)
+		}
+		ranks[playerID] = rank
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return ranks, nil
+}
+
+// GetHistory returns the player's snapshots taken between from and to
+// (inclusive), oldest first.
+func (r *RankingSnapshotRepository) GetHistory(ctx context.Context, playerID uint, from, to time.Time) ([]models.RankingSnapshot, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx,
+		"SELECT EXISTS(SELECT 1 FROM players WHERE id = ?)",
+		playerID,
+	).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check player: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("player with ID %d does not exist: %w", playerID, repository.ErrPlayerNotFound)
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT player_id, taken_at, player_rank, account_balance 
+		 FROM ranking_snapshots 
+		 WHERE player_id = ? AND taken_at BETWEEN ? AND ? 
+		 ORDER BY taken_at`,
+		playerID,
+		from,
+		to,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ranking history: %w", err)
+	}
+	defer rows.Close()
+
+	history := []models.RankingSnapshot{}
+	for rows.Next() {
+		var s models.RankingSnapshot
+		if err := rows.Scan(&s.PlayerID, &s.TakenAt, &s.Rank, &s.AccountBalance); err != nil {
+			return nil, fmt.Errorf("failed to scan ranking snapshot: %w", err)
+		}
+		history = append(history, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return history, nil
+}
+
+// pruneBatch is how many snapshot times one DELETE removes at most.
+const pruneBatch = 500
+
+// Prune deletes the snapshots taken before before and thins those taken
+// before hourlyBefore out to the first of each day.
+func (r *RankingSnapshotRepository) Prune(ctx context.Context, hourlyBefore, before time.Time) error {
+	cutoff := hourlyBefore
+	if before.After(cutoff) {
+		cutoff = before
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT DISTINCT taken_at FROM ranking_snapshots WHERE taken_at < ? ORDER BY taken_at",
+		cutoff,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to query snapshot times: %w", err)
+	}
+	defer rows.Close()
+
+	var times []time.Time
+	for rows.Next() {
+		var t time.Time
+		if err := rows.Scan(&t); err != nil {
+			return fmt.Errorf("failed to scan snapshot time: %w", err)
+		}
+		times = append(times, t)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	prune := repository.SnapshotsToPrune(times, hourlyBefore, before)
+	for len(prune) > 0 {
+		batch := prune[:min(len(prune), pruneBatch)]
+		prune = prune[len(batch):]
+
+		args := make([]any, len(batch))
+		for i, t := range batch {
+			args[i] = t
+		}
+		_, err := r.db.ExecContext(ctx,
+			"DELETE FROM ranking_snapshots WHERE taken_at IN ("+placeholders(len(batch))+")",
+			args...,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to prune ranking snapshots: %w", err)
+		}
+	}
+
+	return nil
+}
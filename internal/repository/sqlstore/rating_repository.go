@@ -0,0 +1,347 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"igaming/internal/models"
+	"igaming/internal/rating"
+	"igaming/internal/repository"
+	"sync"
+	"time"
+)
+
+// ratingBatchSize is how many rows a multi-row insert writes at once.
+const ratingBatchSize = 500
+
+// RatingRepository keeps the skill ratings in step with settled tournaments.
+// A tournament is rated once, in the order tournaments were settled; its
+// rating_history rows mark it as done.
+type RatingRepository struct {
+	db conn
+	// serializes rating updates so tournaments are applied in order
+	mu *sync.Mutex
+}
+
+func newRatingRepository(db conn, mu *sync.Mutex) *RatingRepository {
+	return &RatingRepository{db: db, mu: mu}
+}
+
+// settledPlacements lists every participant of the settled tournaments with
+// their dense placement by total bet, the rule prizes are settled by, in
+// settlement order. It is formatted with the total bet expression (see
+// money) and a filter appended to the WHERE clause.
+const settledPlacements = `SELECT s.tournament_id, s.player_id, s.placement, st.settled_at
+	FROM (
+		SELECT tournament_id, player_id,
+		       DENSE_RANK() OVER (PARTITION BY tournament_id ORDER BY %s DESC) AS placement
+		  FROM tournament_bets
+		 GROUP BY tournament_id, player_id
+	) s
+	JOIN (
+		SELECT tournament_id, MIN(created_at) AS settled_at
+		  FROM tournament_results
+		 GROUP BY tournament_id
+	) st ON st.tournament_id = s.tournament_id
+	JOIN tournaments t ON t.id = s.tournament_id
+	WHERE t.prizes_distributed = TRUE %s
+	ORDER BY st.settled_at, s.tournament_id, s.placement, s.player_id`
+
+// ApplyPending rates every settled tournament that has not been rated yet,
+// oldest settlement first, and returns how many it rated.
+func (r *RatingRepository) ApplyPending(ctx context.Context) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(settledPlacements, money(r.db.dialect(), "SUM(bet_amount)"),
+		"AND NOT EXISTS (SELECT 1 FROM rating_history h WHERE h.tournament_id = t.id)"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to query unrated tournaments: %w", err)
+	}
+	order, fields, settled, err := scanPlacements(rows)
+	if err != nil {
+		return 0, err
+	}
+
+	for n, id := range order {
+		if err := r.applyTournament(ctx, id, fields[id], settled[id]); err != nil {
+			return n, err
+		}
+	}
+	return len(order), nil
+}
+
+func (r *RatingRepository) applyTournament(ctx context.Context, tournamentID uint, field []repository.RatedPlacement, settledAt time.Time) error {
+	return r.db.inTx(ctx, func(tx conn) error {
+		ids := make([]any, len(field))
+		for i, p := range field {
+			ids[i] = p.PlayerID
+		}
+
+		rows, err := tx.QueryContext(ctx,
+			`SELECT player_id, rating, tournaments_played, updated_at FROM player_ratings
+			 WHERE player_id IN (`+placeholders(len(ids))+`)`+forUpdate(tx.dialect()),
+			ids...,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to load ratings: %w", err)
+		}
+		states := make(map[uint]*repository.RatingState, len(field))
+		for rows.Next() {
+			var id uint
+			var s repository.RatingState
+			if err := rows.Scan(&id, &s.Rating, &s.Played, &s.UpdatedAt); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan rating: %w", err)
+			}
+			states[id] = &s
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("rows error: %w", err)
+		}
+
+		changes := repository.RateTournament(states, tournamentID, field, settledAt)
+
+		if err := saveRatings(ctx, tx, states, ids); err != nil {
+			return err
+		}
+		return insertRatingHistory(ctx, tx, changes)
+	})
+}
+
+// Recompute throws away all ratings and rates every settled tournament again
+// from scratch. It returns the number of tournaments and players rated.
+func (r *RatingRepository) Recompute(ctx context.Context) (tournaments, players int, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err = r.db.inTx(ctx, func(tx conn) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM rating_history"); err != nil {
+			return fmt.Errorf("failed to clear rating history: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, "DELETE FROM player_ratings"); err != nil {
+			return fmt.Errorf("failed to clear ratings: %w", err)
+		}
+
+		rows, err := tx.QueryContext(ctx, fmt.Sprintf(settledPlacements, money(tx.dialect(), "SUM(bet_amount)"), ""))
+		if err != nil {
+			return fmt.Errorf("failed to query settled tournaments: %w", err)
+		}
+		order, fields, settled, err := scanPlacements(rows)
+		if err != nil {
+			return err
+		}
+
+		states := make(map[uint]*repository.RatingState)
+		var changes []models.RatingChange
+		for _, id := range order {
+			changes = append(changes, repository.RateTournament(states, id, fields[id], settled[id])...)
+		}
+
+		ids := make([]any, 0, len(states))
+		for id := range states {
+			ids = append(ids, id)
+		}
+		if err := saveRatings(ctx, tx, states, ids); err != nil {
+			return err
+		}
+		if err := insertRatingHistory(ctx, tx, changes); err != nil {
+			return err
+		}
+
+		tournaments, players = len(order), len(states)
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return tournaments, players, nil
+}
+
+func scanPlacements(rows *sql.Rows) (order []uint, fields map[uint][]repository.RatedPlacement, settled map[uint]time.Time, err error) {
+	defer rows.Close()
+
+	fields = make(map[uint][]repository.RatedPlacement)
+	settled = make(map[uint]time.Time)
+	for rows.Next() {
+		var tournamentID uint
+		var p repository.RatedPlacement
+		var settledAt *time.Time
+		if err := rows.Scan(&tournamentID, &p.PlayerID, &p.Placement, timestamp{&settledAt}); err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to scan placement: %w", err)
+		}
+		if _, ok := fields[tournamentID]; !ok {
+			order = append(order, tournamentID)
+			settled[tournamentID] = *settledAt
+		}
+		fields[tournamentID] = append(fields[tournamentID], p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, nil, fmt.Errorf("rows error: %w", err)
+	}
+	return order, fields, settled, nil
+}
+
+func saveRatings(ctx context.Context, q dbtx, states map[uint]*repository.RatingState, ids []any) error {
+	for start := 0; start < len(ids); start += ratingBatchSize {
+		batch := ids[start:min(start+ratingBatchSize, len(ids))]
+
+		args := make([]any, 0, len(batch)*4)
+		for _, id := range batch {
+			s := states[id.(uint)]
+			args = append(args, id, s.Rating, s.Played, s.UpdatedAt)
+		}
+
+		_, err := q.ExecContext(ctx,
+			`INSERT INTO player_ratings (player_id, rating, tournaments_played, updated_at)
+			 VALUES `+rowPlaceholders(len(batch), 4)+`
+			 `+upsert(q.dialect(), "player_id", "rating", "tournaments_played", "updated_at"),
+			args...,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save ratings: %w", err)
+		}
+	}
+	return nil
+}
+
+func insertRatingHistory(ctx context.Context, q dbtx, changes []models.RatingChange) error {
+	for start := 0; start < len(changes); start += ratingBatchSize {
+		batch := changes[start:min(start+ratingBatchSize, len(changes))]
+
+		args := make([]any, 0, len(batch)*7)
+		for _, c := range batch {
+			args = append(args, c.PlayerID, c.TournamentID, c.Placement, c.FieldSize,
+				c.RatingBefore, c.RatingAfter, c.CreatedAt)
+		}
+
+		_, err := q.ExecContext(ctx,
+			`INSERT INTO rating_history
+			 (player_id, tournament_id, placement, field_size, rating_before, rating_after, created_at)
+			 VALUES `+rowPlaceholders(len(batch), 7),
+			args...,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save rating history: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetRating returns the player's rating, the initial rating when they have
+// not played a rated tournament.
+func (r *RatingRepository) GetRating(ctx context.Context, playerID uint) (*models.PlayerRating, error) {
+	var pr models.PlayerRating
+	var value sql.NullFloat64
+	var played sql.NullInt64
+	var rank sql.NullInt64
+	err := r.db.QueryRowContext(ctx,
+		`SELECT p.id, p.name, r.rating, r.tournaments_played, r.updated_at, rr.rating_rank
+		 FROM players p
+		 LEFT JOIN player_ratings r ON r.player_id = p.id
+		 LEFT JOIN player_rating_rankings rr ON rr.player_id = p.id
+		 WHERE p.id = ?`,
+		playerID,
+	).Scan(&pr.PlayerID, &pr.PlayerName, &value, &played, &pr.UpdatedAt, &rank)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("player with ID %d not found: %w", playerID, repository.ErrPlayerNotFound)
+		}
+		return nil, fmt.Errorf("failed to get rating: %w", err)
+	}
+
+	pr.Rating = rating.Initial
+	if value.Valid {
+		pr.Rating = value.Float64
+	}
+	pr.TournamentsPlayed = int(played.Int64)
+	if rank.Valid {
+		n := int(rank.Int64)
+		pr.Rank = &n
+	}
+	return &pr, nil
+}
+
+// GetHistory returns the player's most recent rating changes, newest first.
+func (r *RatingRepository) GetHistory(ctx context.Context, playerID uint, limit int) ([]models.RatingChange, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT player_id, tournament_id, placement, field_size, rating_before, rating_after, created_at
+		 FROM rating_history
+		 WHERE player_id = ?
+		 ORDER BY created_at DESC, id DESC
+		 LIMIT ?`,
+		playerID,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rating history: %w", err)
+	}
+	defer rows.Close()
+
+	history := []models.RatingChange{}
+	for rows.Next() {
+		var c models.RatingChange
+		err := rows.Scan(
+			&c.PlayerID,
+			&c.TournamentID,
+			&c.Placement,
+			&c.FieldSize,
+			&c.RatingBefore,
+			&c.RatingAfter,
+			&c.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan rating change: %w", err)
+		}
+		history = append(history, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return history, nil
+}
+
+// GetLeaderboard returns a page of rated players by rating, best first,
+// together with the number of ranked players.
+func (r *RatingRepository) GetLeaderboard(ctx context.Context, offset, limit int) ([]models.PlayerRating, int, error) {
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM player_rating_rankings").Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count rated players: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT rr.player_id, rr.player_name, rr.rating, rr.tournaments_played, r.updated_at, rr.rating_rank
+		 FROM player_rating_rankings rr
+		 JOIN player_ratings r ON r.player_id = rr.player_id
+		 ORDER BY rr.rating_rank, rr.player_id
+		 LIMIT ? OFFSET ?`,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query ratings: %w", err)
+	}
+	defer rows.Close()
+
+	ratings := []models.PlayerRating{}
+	for rows.Next() {
+		var pr models.PlayerRating
+		var rank int
+		if err := rows.Scan(&pr.PlayerID, &pr.PlayerName, &pr.Rating, &pr.TournamentsPlayed, &pr.UpdatedAt, &rank); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan rating: %w", err)
+		}
+		pr.Rank = &rank
+		ratings = append(ratings, pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("rows error: %w", err)
+	}
+
+	return ratings, total, nil
+}
@@ -0,0 +1,310 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"igaming/internal/models"
+	"igaming/internal/repository"
+)
+
+type SeasonRepository struct {
+	db conn
+}
+
+func newSeasonRepository(db conn) *SeasonRepository {
+	return &SeasonRepository{db: db}
+}
+
+// Create inserts the season together with its points table.
+func (r *SeasonRepository) Create(ctx context.Context, season *models.Season) error {
+	var id uint
+	err := r.db.inTx(ctx, func(tx conn) error {
+		var err error
+		id, err = insert(ctx, tx,
+			`INSERT INTO seasons (name, start_date, end_date, prize_pool) VALUES (?, ?, ?, ?)`,
+			season.Name,
+			season.StartDate,
+			season.EndDate,
+			season.PrizePool,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create season: %w", err)
+		}
+
+		return insertSeasonPoints(ctx, tx, id, season.PointsTable)
+	})
+	if err != nil {
+		return err
+	}
+
+	season.ID = id
+	return nil
+}
+
+// GetAll returns every season without its points table.
+func (r *SeasonRepository) GetAll(ctx context.Context) ([]models.Season, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, name, start_date, end_date, prize_pool, prizes_distributed, created_at, updated_at 
+		 FROM seasons ORDER BY start_date DESC, id DESC`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query seasons: %w", err)
+	}
+	defer rows.Close()
+
+	seasons := []models.Season{}
+	for rows.Next() {
+		var s models.Season
+		err := rows.Scan(
+			&s.ID,
+			&s.Name,
+			&s.StartDate,
+			&s.EndDate,
+			&s.PrizePool,
+			&s.PrizesDistributed,
+			&s.CreatedAt,
+			&s.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan season row: %w", err)
+		}
+		seasons = append(seasons, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return seasons, nil
+}
+
+// GetByID returns the season with its points table.
+func (r *SeasonRepository) GetByID(ctx context.Context, id uint) (*models.Season, error) {
+	var s models.Season
+	err := r.db.QueryRowContext(ctx,
+		`SELECT id, name, start_date, end_date, prize_pool, prizes_distributed, created_at, updated_at 
+		 FROM seasons WHERE id = ?`,
+		id,
+	).Scan(
+		&s.ID,
+		&s.Name,
+		&s.StartDate,
+		&s.EndDate,
+		&s.PrizePool,
+		&s.PrizesDistributed,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("season with ID %d not found: %w", id, repository.ErrSeasonNotFound)
+		}
+		return nil, fmt.Errorf("failed to get season: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT placement, points FROM season_points WHERE season_id = ? ORDER BY placement",
+		id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query points table: %w", err)
+	}
+	defer rows.Close()
+
+	s.PointsTable = []models.SeasonPoints{}
+	for rows.Next() {
+		var p models.SeasonPoints
+		if err := rows.Scan(&p.Placement, &p.Points); err != nil {
+			return nil, fmt.Errorf("failed to scan points row: %w", err)
+		}
+		s.PointsTable = append(s.PointsTable, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return &s, nil
+}
+
+// GetForUpdate returns the season without its points table, with the row
+// locked until the end of the transaction (see forUpdate).
+func (r *SeasonRepository) GetForUpdate(ctx context.Context, id uint) (*models.Season, error) {
+	var s models.Season
+	err := r.db.QueryRowContext(ctx,
+		`SELECT id, name, start_date, end_date, prize_pool, prizes_distributed, created_at, updated_at 
+		 FROM seasons WHERE id = ?`+forUpdate(r.db.dialect()),
+		id,
+	).Scan(
+		&s.ID,
+		&s.Name,
+		&s.StartDate,
+		&s.EndDate,
+		&s.PrizePool,
+		&s.PrizesDistributed,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("season with ID %d not found: %w", id, repository.ErrSeasonNotFound)
+		}
+		return nil, fmt.Errorf("failed to lock season: %w", err)
+	}
+
+	return &s, nil
+}
+
+// SetPoints replaces the season's points table. Standings are computed from
+// the table on every read, so the change applies to tournaments already
+// played as well.
+func (r *SeasonRepository) SetPoints(ctx context.Context, seasonID uint, points []models.SeasonPoints) error {
+	return r.db.inTx(ctx, func(tx conn) error {
+		var distributed bool
+		err := tx.QueryRowContext(ctx,
+			"SELECT prizes_distributed FROM seasons WHERE id = ?"+forUpdate(tx.dialect()),
+			seasonID,
+		).Scan(&distributed)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("season with ID %d does not exist: %w", seasonID, repository.ErrSeasonNotFound)
+			}
+			return fmt.Errorf("failed to get season: %w", err)
+		}
+		if distributed {
+			return fmt.Errorf("%w: the points table of season %d is final", repository.ErrPrizesAlreadyDistributed, seasonID)
+		}
+
+		if _, err := tx.ExecContext(ctx, "DELETE FROM season_points WHERE season_id = ?", seasonID); err != nil {
+			return fmt.Errorf("failed to clear points table: %w", err)
+		}
+
+		return insertSeasonPoints(ctx, tx, seasonID, points)
+	})
+}
+
+func insertSeasonPoints(ctx context.Context, q dbtx, seasonID uint, points []models.SeasonPoints) error {
+	for _, p := range points {
+		_, err := q.ExecContext(ctx,
+			"INSERT INTO season_points (season_id, placement, points) VALUES (?, ?, ?)",
+			seasonID,
+			p.Placement,
+			p.Points,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save points for placement %d: %w", p.Placement, err)
+		}
+	}
+	return nil
+}
+
+// GetStandings returns the season standings ordered by placement.
+func (r *SeasonRepository) GetStandings(ctx context.Context, seasonID uint) ([]models.SeasonStanding, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT s.placement, s.player_id, p.name, s.points, s.wins, s.tournaments_placed, s.achieved_at 
+		 FROM season_standings s 
+		 JOIN players p ON p.id = s.player_id 
+		 WHERE s.season_id = ? 
+		 ORDER BY s.placement, s.player_id`,
+		seasonID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query season standings: %w", err)
+	}
+	defer rows.Close()
+
+	standings := []models.SeasonStanding{}
+	for rows.Next() {
+		var s models.SeasonStanding
+		err := rows.Scan(
+			&s.Placement,
+			&s.PlayerID,
+			&s.PlayerName,
+			&s.Points,
+			&s.Wins,
+			&s.TournamentsPlaced,
+			timestamp{&s.AchievedAt},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan season standing: %w", err)
+		}
+		standings = append(standings, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return standings, nil
+}
+
+// CreateResults records the placements and prizes of a settlement.
+func (r *SeasonRepository) CreateResults(ctx context.Context, results []models.SeasonResult) error {
+	for i := range results {
+		res := &results[i]
+		id, err := insert(ctx, r.db,
+			`INSERT INTO season_results (season_id, player_id, placement, prize_amount, created_at) 
+			 VALUES (?, ?, ?, ?, ?)`,
+			res.SeasonID,
+			res.PlayerID,
+			res.Placement,
+			res.PrizeAmount,
+			res.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save season result of player %d: %w", res.PlayerID, err)
+		}
+		res.ID = id
+	}
+	return nil
+}
+
+// MarkPrizesDistributed marks the season as settled.
+func (r *SeasonRepository) MarkPrizesDistributed(ctx context.Context, id uint) error {
+	_, err := r.db.ExecContext(ctx,
+		"UPDATE seasons SET prizes_distributed = TRUE WHERE id = ?",
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark season prizes distributed: %w", err)
+	}
+	return nil
+}
+
+// GetResults returns the prizes paid for the season, best placement first.
+func (r *SeasonRepository) GetResults(ctx context.Context, seasonID uint) ([]models.SeasonResult, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, season_id, player_id, placement, prize_amount, created_at 
+		 FROM season_results WHERE season_id = ? ORDER BY placement, player_id`,
+		seasonID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query season results: %w", err)
+	}
+	defer rows.Close()
+
+	results := []models.SeasonResult{}
+	for rows.Next() {
+		var res models.SeasonResult
+		err := rows.Scan(
+			&res.ID,
+			&res.SeasonID,
+			&res.PlayerID,
+			&res.Placement,
+			&res.PrizeAmount,
+			&res.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan season result: %w", err)
+		}
+		results = append(results, res)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return results, nil
+}
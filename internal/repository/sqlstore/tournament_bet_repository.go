@@ -0,0 +1,99 @@
+package sqlstore
+
+import (
+	"context"
+	"fmt"
+	"igaming/internal/models"
+)
+
+type TournamentBetRepository struct {
+	db conn
+}
+
+func newTournamentBetRepository(db conn) *TournamentBetRepository {
+	return &TournamentBetRepository{db: db}
+}
+
+// Create records the bet as placed at bet.CreatedAt.
+func (r *TournamentBetRepository) Create(ctx context.Context, bet *models.TournamentBet) error {
+    id, err := insert(ctx, r.db,
+        `INSERT INTO tournament_bets (player_id, tournament_id, bet_amount, rake_amount, created_at) 
+         VALUES (?, ?, ?, ?, ?)`,
+        bet.PlayerID, 
+        bet.TournamentID, 
+        bet.BetAmount,
+        bet.RakeAmount,
+        bet.CreatedAt,
+    )
+    if err != nil {
+        return fmt.Errorf("failed to create bet: %w", err)
+    }
+
+    bet.ID = id
+    
+    return nil
+}
+
+// GetPlayerStake returns how many bets the player has placed in the
+// tournament and their total.
+func (r *TournamentBetRepository) GetPlayerStake(ctx context.Context, tournamentID, playerID uint) (count int, stake float64, err error) {
+	err = r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*), COALESCE(`+money(r.db.dialect(), "SUM(bet_amount)")+`, 0) 
+		 FROM tournament_bets WHERE tournament_id = ? AND player_id = ?`,
+		tournamentID,
+		playerID,
+	).Scan(&count, &stake)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get player stake: %w", err)
+	}
+	return count, stake, nil
+}
+
+// CountParticipants returns the number of players with a bet in the
+// tournament.
+func (r *TournamentBetRepository) CountParticipants(ctx context.Context, tournamentID uint) (int, error) {
+	var n int
+	err := r.db.QueryRowContext(ctx,
+		"SELECT COUNT(DISTINCT player_id) FROM tournament_bets WHERE tournament_id = ?",
+		tournamentID,
+	).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count participants: %w", err)
+	}
+	return n, nil
+}
+
+func (r *TournamentBetRepository) GetAll(ctx context.Context) ([]models.TournamentBet, error) {
+	query := `SELECT 
+		id, player_id, tournament_id, bet_amount, rake_amount, created_at 
+		FROM tournament_bets`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query bets: %w", err)
+	}
+	defer rows.Close()
+
+	var bets []models.TournamentBet
+	for rows.Next() {
+		var bet models.TournamentBet
+		err := rows.Scan(
+			&bet.ID,
+			&bet.PlayerID,
+			&bet.TournamentID,
+			&bet.BetAmount,
+			&bet.RakeAmount,
+			&bet.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan bet row: %w", err)
+		}
+		bets = append(bets, bet)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return bets, nil
+}
@@ -0,0 +1,320 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"igaming/internal/logging"
+	"igaming/internal/models"
+	"igaming/internal/repository"
+)
+
+type TournamentRepository struct {
+	db conn
+}
+
+func newTournamentRepository(db conn) *TournamentRepository {
+	return &TournamentRepository{db: db}
+}
+
+func (r *TournamentRepository) Create(ctx context.Context, tournament *models.Tournament) error {
+    if tournament.SeasonID != nil {
+        var exists bool
+        err := r.db.QueryRowContext(ctx,
+            "SELECT EXISTS(SELECT 1 FROM seasons WHERE id = ?)",
+            *tournament.SeasonID,
+        ).Scan(&exists)
+        if err != nil {
+            return fmt.Errorf("failed to check season: %w", err)
+        }
+        if !exists {
+            return fmt.Errorf("season with ID %d does not exist: %w", *tournament.SeasonID, repository.ErrSeasonNotFound)
+        }
+    }
+
+    query := `INSERT INTO tournaments 
+    (name, prize_pool, pool_mode, guaranteed_prize_pool, rake_percentage, start_date, end_date, 
+     min_bet, max_bet, max_stake_per_player, max_participants, entry_fee, 
+     betting_opens_at, betting_closes_at, late_registration_minutes, season_id) 
+    VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+    id, err := insert(
+        ctx, 
+        r.db,
+        query, 
+        tournament.Name, 
+        tournament.PrizePool, 
+        tournament.PoolMode, 
+        tournament.GuaranteedPrizePool, 
+        tournament.RakePercentage, 
+        tournament.StartDate, 
+        tournament.EndDate,
+        tournament.MinBet,
+        tournament.MaxBet,
+        tournament.MaxStakePerPlayer,
+        tournament.MaxParticipants,
+        tournament.EntryFee,
+        tournament.BettingOpensAt,
+        tournament.BettingClosesAt,
+        tournament.LateRegistrationMinutes,
+        tournament.SeasonID,
+    )
+
+    if err != nil {
+        logging.FromContext(ctx).Error("failed to insert tournament", "error", err)
+        return fmt.Errorf("database operation failed: %w", err)
+    }
+
+    tournament.ID = id
+    return nil
+}
+
+// tournamentColumns are the tournament columns scanTournament reads.
+const tournamentColumns = `id, name, prize_pool, pool_mode, guaranteed_prize_pool, rake_percentage, rake_collected, 
+    start_date, end_date, 
+    min_bet, max_bet, max_stake_per_player, max_participants, entry_fee, 
+    betting_opens_at, betting_closes_at, late_registration_minutes, season_id, prizes_distributed, 
+    created_at, updated_at`
+
+// scanTournament reads a row of tournamentColumns.
+func scanTournament(row interface{ Scan(dest ...any) error }) (models.Tournament, error) {
+    var t models.Tournament
+    err := row.Scan(
+        &t.ID,
+        &t.Name,
+        &t.PrizePool,
+        &t.PoolMode,
+        &t.GuaranteedPrizePool,
+        &t.RakePercentage,
+        &t.RakeCollected,
+        &t.StartDate,
+        &t.EndDate,
+        &t.MinBet,
+        &t.MaxBet,
+        &t.MaxStakePerPlayer,
+        &t.MaxParticipants,
+        &t.EntryFee,
+        &t.BettingOpensAt,
+        &t.BettingClosesAt,
+        &t.LateRegistrationMinutes,
+        &t.SeasonID,
+        &t.PrizesDistributed,
+        &t.CreatedAt,
+        &t.UpdatedAt,
+    )
+    return t, err
+}
+
+func (r *TournamentRepository) GetAllTournaments(ctx context.Context) ([]models.Tournament, error) {
+    query := "SELECT " + tournamentColumns + " FROM tournaments"
+    
+    rows, err := r.db.QueryContext(ctx, query)
+    if err != nil {
+        return nil, fmt.Errorf("failed to query tournaments: %w", err)
+    }
+    defer rows.Close()
+
+    var tournaments []models.Tournament
+    for rows.Next() {
+        t, err := scanTournament(rows)
+        if err != nil {
+            return nil, fmt.Errorf("failed to scan tournament row: %w", err)
+        }
+        tournaments = append(tournaments, t)
+    }
+
+    if err = rows.Err(); err != nil {
+        return nil, fmt.Errorf("rows error: %w", err)
+    }
+
+    return tournaments, nil
+}
+
+func (r *TournamentRepository) GetTournamentByID(ctx context.Context, id uint) (*models.Tournament, error) {
+    query := "SELECT " + tournamentColumns + " FROM tournaments WHERE id = ?"
+
+    tournament, err := scanTournament(r.db.QueryRowContext(ctx, query, id))
+    if err != nil {
+        if errors.Is(err, sql.ErrNoRows) {
+            return nil, fmt.Errorf("tournament with ID %d not found: %w", id, repository.ErrTournamentNotFound)
+        }
+        return nil, fmt.Errorf("failed to get tournament: %w", err)
+    }
+    
+    return &tournament, nil
+}
+
+// GetForUpdate returns the tournament with the row locked until the end of
+// the transaction (see forUpdate). Locking the tournament serializes bets on it, so stakes
+// and participant counts read afterwards cannot change underneath the caller.
+func (r *TournamentRepository) GetForUpdate(ctx context.Context, id uint) (*models.Tournament, error) {
+	query := "SELECT " + tournamentColumns + " FROM tournaments WHERE id = ?" + forUpdate(r.db.dialect())
+
+	tournament, err := scanTournament(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("tournament with ID %d does not exist: %w", id, repository.ErrTournamentNotFound)
+		}
+		return nil, fmt.Errorf("failed to lock tournament: %w", err)
+	}
+
+	return &tournament, nil
+}
+
+// AddToPrizePool grows the prize pool and the rake collected.
+func (r *TournamentRepository) AddToPrizePool(ctx context.Context, id uint, amount, rake float64) error {
+	d := r.db.dialect()
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE tournaments 
+		 SET prize_pool = `+money(d, "prize_pool + ?")+`, rake_collected = `+money(d, "rake_collected + ?")+` 
+		 WHERE id = ?`,
+		amount,
+		rake,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update prize pool: %w", err)
+	}
+	return nil
+}
+
+// CreateResults records the placements and prizes of a settlement.
+func (r *TournamentRepository) CreateResults(ctx context.Context, results []models.TournamentResult) error {
+	for i := range results {
+		res := &results[i]
+		id, err := insert(ctx, r.db,
+			`INSERT INTO tournament_results (tournament_id, player_id, placement, prize_amount, created_at) 
+			 VALUES (?, ?, ?, ?, ?)`,
+			res.TournamentID,
+			res.PlayerID,
+			res.Placement,
+			res.PrizeAmount,
+			res.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save result of player %d: %w", res.PlayerID, err)
+		}
+		res.ID = id
+	}
+	return nil
+}
+
+// MarkPrizesDistributed marks the tournament as settled.
+func (r *TournamentRepository) MarkPrizesDistributed(ctx context.Context, id uint) error {
+	_, err := r.db.ExecContext(ctx,
+		"UPDATE tournaments SET prizes_distributed = TRUE WHERE id = ?",
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark prizes distributed: %w", err)
+	}
+	return nil
+}
+
+func (r *TournamentRepository) Exists(ctx context.Context, id uint) (bool, error) {
+    var exists bool
+    query := "SELECT EXISTS(SELECT 1 FROM tournaments WHERE id = ?)"
+    err := r.db.QueryRowContext(ctx, query, id).Scan(&exists)
+    return exists, err
+}
+// GetStandings returns every participant's total bet in the tournament,
+// highest first.
+func (r *TournamentRepository) GetStandings(ctx context.Context, tournamentID uint) ([]models.TournamentStanding, error) {
+	query := `SELECT b.player_id, p.name, ` + money(r.db.dialect(), "SUM(b.bet_amount)") + ` AS total_bet 
+		FROM tournament_bets b 
+		JOIN players p ON p.id = b.player_id 
+		WHERE b.tournament_id = ? 
+		GROUP BY b.player_id, p.name 
+		ORDER BY total_bet DESC, b.player_id`
+
+	rows, err := r.db.QueryContext(ctx, query, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query standings: %w", err)
+	}
+	defer rows.Close()
+
+	standings := []models.TournamentStanding{}
+	for rows.Next() {
+		var s models.TournamentStanding
+		if err := rows.Scan(&s.PlayerID, &s.PlayerName, &s.TotalBet); err != nil {
+			return nil, fmt.Errorf("failed to scan standing row: %w", err)
+		}
+		standings = append(standings, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return standings, nil
+}
+
+// GetResults returns the settled placements of the tournament.
+// GetAllStandings returns the standings of every tournament with bets, keyed
+// by tournament ID, in the same order as GetStandings.
+func (r *TournamentRepository) GetAllStandings(ctx context.Context) (map[uint][]models.TournamentStanding, error) {
+	query := `SELECT b.tournament_id, b.player_id, p.name, ` + money(r.db.dialect(), "SUM(b.bet_amount)") + ` AS total_bet 
+		FROM tournament_bets b 
+		JOIN players p ON p.id = b.player_id 
+		GROUP BY b.tournament_id, b.player_id, p.name 
+		ORDER BY b.tournament_id, total_bet DESC, b.player_id`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query standings: %w", err)
+	}
+	defer rows.Close()
+
+	standings := make(map[uint][]models.TournamentStanding)
+	for rows.Next() {
+		var tournamentID uint
+		var s models.TournamentStanding
+		if err := rows.Scan(&tournamentID, &s.PlayerID, &s.PlayerName, &s.TotalBet); err != nil {
+			return nil, fmt.Errorf("failed to scan standing row: %w", err)
+		}
+		standings[tournamentID] = append(standings[tournamentID], s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return standings, nil
+}
+
+func (r *TournamentRepository) GetResults(ctx context.Context, tournamentID uint) ([]models.TournamentResult, error) {
+	query := `SELECT id, tournament_id, player_id, placement, prize_amount, created_at 
+		FROM tournament_results 
+		WHERE tournament_id = ? 
+		ORDER BY placement, player_id`
+
+	rows, err := r.db.QueryContext(ctx, query, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query results: %w", err)
+	}
+	defer rows.Close()
+
+	results := []models.TournamentResult{}
+	for rows.Next() {
+		var res models.TournamentResult
+		err := rows.Scan(
+			&res.ID,
+			&res.TournamentID,
+			&res.PlayerID,
+			&res.Placement,
+			&res.PrizeAmount,
+			&res.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan result row: %w", err)
+		}
+		results = append(results, res)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return results, nil
+}
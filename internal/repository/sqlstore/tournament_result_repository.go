@@ -1,5 +1,5 @@
 // NOT IN USE
-package repository
+package sqlstore
 
 import (
 	"context"
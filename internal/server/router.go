@@ -1,9 +1,18 @@
 package server
 
 import (
-	"database/sql"
+	"igaming/internal/clock"
+	"igaming/internal/config"
+	"igaming/internal/events"
 	"igaming/internal/handlers"
+	"igaming/internal/health"
+	"igaming/internal/logging"
+	"igaming/internal/metrics"
+	"igaming/internal/ranking"
 	"igaming/internal/repository"
+	"igaming/internal/service"
+	"igaming/internal/tracing"
+	"log/slog"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -13,20 +22,49 @@ import (
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
-func NewRouter(db *sql.DB) http.Handler {
+func NewRouter(store *repository.Store, checker *health.Checker, features config.FeatureConfig, hub *events.Hub, rankings *ranking.Service, clk clock.Clock, logger *slog.Logger, m *metrics.Metrics) http.Handler {
 	router := chi.NewRouter()
+	router.Use(tracing.Middleware)
+	router.Use(logging.Middleware(logger))
+	router.Use(m.Middleware)
 
-	router.Get("/swagger/*", httpSwagger.Handler(httpSwagger.URL("/swagger/doc.json")))
+	router.Handle("/metrics", m.Handler())
 
-    tournamentRepo := repository.NewTournamentRepository(db)
-    tournamentHandler := handlers.NewTournamentHandler(tournamentRepo)
+	if features.Swagger {
+		router.Get("/swagger/*", httpSwagger.Handler(httpSwagger.URL("/swagger/doc.json")))
+	}
 
-	playerRepo := repository.NewPlayerRepository(db)
-    playerHandler := handlers.NewPlayerHandler(playerRepo)
-	rankingHandler := handlers.NewRankingHandler(playerRepo)
+	healthHandler := handlers.NewHealthHandler(checker)
+	router.Get("/health", healthHandler.Ready)
+	router.Get("/health/live", healthHandler.Live)
+	router.Get("/health/ready", healthHandler.Ready)
 
-	betRepo := repository.NewTournamentBetRepository(db, playerRepo, tournamentRepo)
-	betHandler := handlers.NewTournamentBetHandler(betRepo)
+	publisher := events.NewTournamentPublisher(hub, store.Tournaments, rankings)
+
+	playerService := service.NewPlayerService(store.Players, rankings)
+	tournamentService := service.NewTournamentService(store.Tournaments)
+	bettingService := service.NewBettingService(store, publisher, rankings, m, clk)
+	settlementService := service.NewSettlementService(store, publisher, rankings, m, clk)
+	seasonService := service.NewSeasonService(store.Seasons)
+	ratingService := service.NewRatingService(store.Ratings)
+	limitService := service.NewLimitService(store.Limits)
+	exclusionService := service.NewExclusionService(store.Exclusions, rankings, clk)
+
+    tournamentHandler := handlers.NewTournamentHandler(tournamentService, settlementService)
+	leaderboardHandler := handlers.NewLeaderboardHandler(store.Tournaments, rankings)
+	streamHandler := handlers.NewStreamHandler(hub, store.Tournaments)
+
+	seasonHandler := handlers.NewSeasonHandler(seasonService, settlementService)
+
+    playerHandler := handlers.NewPlayerHandler(playerService)
+	rankingHandler := handlers.NewRankingHandler(rankings, store.Snapshots, clk)
+	ratingHandler := handlers.NewRatingHandler(ratingService)
+
+	limitHandler := handlers.NewPlayerLimitHandler(limitService)
+
+	exclusionHandler := handlers.NewPlayerExclusionHandler(exclusionService)
+
+	betHandler := handlers.NewTournamentBetHandler(bettingService)
 
 	// ______>
 	
@@ -34,14 +72,38 @@ func NewRouter(db *sql.DB) http.Handler {
 	router.Post("/tournaments", tournamentHandler.CreateTournament)
 
 	router.Post("/tournaments/prizes/{id}", tournamentHandler.DistributePrizes)
+	router.Get("/tournaments/{id}/leaderboard", leaderboardHandler.GetLeaderboard)
+	if features.Streams {
+		router.Get("/tournaments/{id}/stream", streamHandler.StreamTournament)
+	}
+
+	router.Get("/seasons", seasonHandler.GetSeasons)
+	router.Post("/seasons", seasonHandler.CreateSeason)
+	router.Get("/seasons/{id}", seasonHandler.GetSeason)
+	router.Put("/seasons/{id}/points", seasonHandler.SetPoints)
+	router.Get("/seasons/{id}/standings", seasonHandler.GetStandings)
+	router.Post("/seasons/{id}/prizes", seasonHandler.DistributePrizes)
 
 	router.Get("/players", playerHandler.GetPlayers)
 	router.Post("/players", playerHandler.CreatePlayer)
+	router.Get("/players/{id}/limits", limitHandler.GetLimits)
+	router.Put("/players/{id}/limits", limitHandler.SetLimits)
+	router.Get("/players/{id}/exclusions", exclusionHandler.GetPlayerExclusions)
+	router.Post("/players/{id}/exclusions", exclusionHandler.CreateExclusion)
+	router.Get("/players/{id}/rank", rankingHandler.GetPlayerRank)
+	router.Get("/players/{id}/rankings/history", rankingHandler.GetRankHistory)
+	router.Get("/players/{id}/rating", ratingHandler.GetPlayerRating)
 
 	router.Get("/bets", betHandler.GetBets)
 	router.Post("/bets", betHandler.CreateBet)
 
 	router.Get("/rankings", rankingHandler.GetPlayerRankings)
+	router.Get("/rankings/climbers", rankingHandler.GetClimbers)
+	router.Get("/ratings", ratingHandler.GetRatings)
+
+	router.Get("/admin/exclusions", exclusionHandler.GetActiveExclusions)
+	router.Get("/admin/rankings/consistency", rankingHandler.CheckConsistency)
+	router.Post("/admin/ratings/recompute", ratingHandler.RecomputeRatings)
 
 	
 
@@ -0,0 +1,1455 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"igaming/internal/clock"
+	"igaming/internal/config"
+	"igaming/internal/dialect"
+	"igaming/internal/events"
+	"igaming/internal/fixtures"
+	"igaming/internal/handlers"
+	"igaming/internal/handlers/dtos"
+	"igaming/internal/health"
+	"igaming/internal/jobs"
+	"igaming/internal/logging"
+	"igaming/internal/metrics"
+	"igaming/internal/migrations"
+	"igaming/internal/models"
+	"igaming/internal/ranking"
+	"igaming/internal/repository"
+	"igaming/internal/repository/memory"
+	"igaming/internal/repository/sqlstore"
+	"igaming/internal/server"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// unknownID is an ID no row has on any backend, however often the database
+// backends have been tested against.
+const unknownID = math.MaxInt32
+
+// api is the service wired up the way cmd/main.go does it, on top of an
+// empty store.
+type api struct {
+	server   *httptest.Server
+	clock    *clock.Manual
+	logs     *logBuffer
+	store    *repository.Store
+	rankings *ranking.Service
+}
+
+// logBuffer collects the JSON log lines written while the test runs.
+type logBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// lines returns the decoded log lines with the given message.
+func (b *logBuffer) lines(t *testing.T, msg string) []map[string]any {
+	t.Helper()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var lines []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(b.buf.String()), "\n") {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("log line is not JSON: %v; line: %s", err, line)
+		}
+		if entry["msg"] == msg {
+			lines = append(lines, entry)
+		}
+	}
+	return lines
+}
+
+func (b *logBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func newAPI(t *testing.T) *api {
+	t.Helper()
+
+	// The services and the store share one manual clock, so every rule
+	// that depends on "now" sees the same time.
+	clk := clock.NewManual(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC))
+	store := newStore(t, clk)
+
+	rankings := ranking.NewService(store.Players, store.Tournaments, store.Snapshots, clk)
+	if err := rankings.Rebuild(context.Background()); err != nil {
+		t.Fatalf("rebuild rankings: %v", err)
+	}
+
+	hub := events.NewHub(events.DefaultHistorySize, events.DefaultBufferSize, events.DefaultIdleTTL)
+	t.Cleanup(hub.Close)
+
+	logs := &logBuffer{}
+	logger := logging.New(logs, slog.LevelInfo)
+
+	m := metrics.New()
+	m.RegisterLiability(store.Players.TotalBalance, metrics.DefaultScrapeTimeout)
+
+	checker := health.NewChecker(nil, "", jobs.NewScheduler(), health.DefaultTimeout)
+	router := server.NewRouter(store, checker, config.FeatureConfig{}, hub, rankings, clk, logger, m)
+
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+
+	return &api{server: srv, clock: clk, logs: logs, store: store, rankings: rankings}
+}
+
+// newStore returns an empty store on the backend named by
+// TEST_STORAGE_BACKEND: memory by default, sqlite on a new file, or mysql or
+// postgres reached through the usual DB_* variables. A database is migrated
+// and emptied first, so point the tests at one kept for them.
+func newStore(t *testing.T, clk clock.Clock) *repository.Store {
+	t.Helper()
+
+	backend := os.Getenv("TEST_STORAGE_BACKEND")
+	if backend == "" || backend == config.StorageMemory {
+		return memory.NewStore(clk)
+	}
+
+	t.Setenv("STORAGE_BACKEND", backend)
+	if backend == config.StorageSQLite {
+		t.Setenv("DB_PATH", filepath.Join(t.TempDir(), "igaming.db"))
+	}
+	cfg, err := config.LoadConfig("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := config.OpenDB(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	ctx := context.Background()
+	d := dialect.Dialect(backend)
+	migrator, err := migrations.NewMigrator(db, d, cfg.Migrations.LockTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	if err := fixtures.Reset(ctx, db); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+
+	return sqlstore.NewStore(db, d, clk, repository.NewRetrier(nil))
+}
+
+// do sends the request, fails the test unless the response has the wanted
+// status, and decodes the response body into out when it is not nil.
+func (a *api) do(t *testing.T, method, path string, body any, want int, out any) {
+	t.Helper()
+
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("%s %s: encode request: %v", method, path, err)
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, a.server.URL+path, reqBody)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("%s %s: read response: %v", method, path, err)
+	}
+	if resp.StatusCode != want {
+		t.Fatalf("%s %s: status %d, want %d; body: %s", method, path, resp.StatusCode, want, respBody)
+	}
+	if out != nil {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			t.Fatalf("%s %s: decode response: %v; body: %s", method, path, err, respBody)
+		}
+	}
+}
+
+func (a *api) createPlayer(t *testing.T, name string, balance float64) dtos.PlayerResponse {
+	t.Helper()
+
+	var p dtos.PlayerResponse
+	a.do(t, http.MethodPost, "/players", dtos.CreatePlayerRequest{
+		Name:           name,
+		Email:          name + "@example.com",
+		Password:       "password123",
+		AccountBalance: balance,
+	}, http.StatusCreated, &p)
+	return p
+}
+
+// tournamentRequest describes a fixed pool tournament that is running now,
+// for tests to add rules to.
+func (a *api) tournamentRequest(name string, prizePool float64) dtos.CreateTournamentRequest {
+	now := a.clock.Now()
+	return dtos.CreateTournamentRequest{
+		Name:      name,
+		PrizePool: prizePool,
+		StartDate: now.Add(-time.Hour),
+		EndDate:   now.Add(24 * time.Hour),
+	}
+}
+
+// createTournament creates a fixed pool tournament that is running now.
+func (a *api) createTournament(t *testing.T, name string, prizePool float64) dtos.TournamentResponse {
+	t.Helper()
+
+	return a.postTournament(t, a.tournamentRequest(name, prizePool))
+}
+
+func (a *api) postTournament(t *testing.T, req dtos.CreateTournamentRequest) dtos.TournamentResponse {
+	t.Helper()
+
+	var tr dtos.TournamentResponse
+	a.do(t, http.MethodPost, "/tournaments", req, http.StatusCreated, &tr)
+	return tr
+}
+
+func (a *api) placeBet(t *testing.T, playerID, tournamentID uint, amount float64) dtos.TournamentBetResponse {
+	t.Helper()
+
+	var bet dtos.TournamentBetResponse
+	a.do(t, http.MethodPost, "/bets", dtos.CreateTournamentBetRequest{
+		PlayerID:     playerID,
+		TournamentID: tournamentID,
+		BetAmount:    amount,
+	}, http.StatusCreated, &bet)
+	return bet
+}
+
+// betError places a bet the API must refuse with the given status and error
+// code.
+func (a *api) betError(t *testing.T, playerID, tournamentID uint, amount float64, status int, code string) {
+	t.Helper()
+
+	var errResp handlers.DetailedErrorResponse
+	a.do(t, http.MethodPost, "/bets", dtos.CreateTournamentBetRequest{
+		PlayerID:     playerID,
+		TournamentID: tournamentID,
+		BetAmount:    amount,
+	}, status, &errResp)
+	if errResp.Code != code {
+		t.Errorf("bet of %.2f by player %d on tournament %d: code = %q, want %q", amount, playerID, tournamentID, errResp.Code, code)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// metrics scrapes /metrics and returns the samples by name and labels, as in
+// `igaming_bets_placed_total{tournament_id="1"}`.
+func (a *api) metrics(t *testing.T) map[string]float64 {
+	t.Helper()
+
+	resp, err := a.server.Client().Get(a.server.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("GET /metrics: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("GET /metrics: read response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET /metrics: status %d; body: %s", resp.StatusCode, body)
+	}
+
+	samples := make(map[string]float64)
+	for _, line := range strings.Split(string(body), "\n") {
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		i := strings.LastIndexByte(line, ' ')
+		v, err := strconv.ParseFloat(line[i+1:], 64)
+		if err != nil {
+			t.Fatalf("GET /metrics: bad sample %q: %v", line, err)
+		}
+		samples[line[:i]] = v
+	}
+	return samples
+}
+
+// balances returns every player's account balance by player ID.
+func (a *api) balances(t *testing.T) map[uint]float64 {
+	t.Helper()
+
+	var players []dtos.PlayerResponse
+	a.do(t, http.MethodGet, "/players", nil, http.StatusOK, &players)
+
+	balances := make(map[uint]float64, len(players))
+	for _, p := range players {
+		balances[p.ID] = p.AccountBalance
+	}
+	return balances
+}
+
+func (a *api) wantBalance(t *testing.T, playerID uint, want float64) {
+	t.Helper()
+
+	if got := a.balances(t)[playerID]; got != want {
+		t.Errorf("player %d balance = %.2f, want %.2f", playerID, got, want)
+	}
+}
+
+func TestHealth(t *testing.T) {
+	a := newAPI(t)
+
+	a.do(t, http.MethodGet, "/health", nil, http.StatusOK, nil)
+	a.do(t, http.MethodGet, "/health/live", nil, http.StatusOK, nil)
+	a.do(t, http.MethodGet, "/health/ready", nil, http.StatusOK, nil)
+}
+
+func TestRequestLogging(t *testing.T) {
+	a := newAPI(t)
+	player := a.createPlayer(t, "logged", 100)
+
+	get := func(requestID string) *http.Response {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/players/%d/limits", a.server.URL, player.ID), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if requestID != "" {
+			req.Header.Set(logging.RequestIDHeader, requestID)
+		}
+		resp, err := a.server.Client().Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		return resp
+	}
+
+	resp := get("test-request-1")
+	if got := resp.Header.Get(logging.RequestIDHeader); got != "test-request-1" {
+		t.Errorf("%s = %q, want the one sent", logging.RequestIDHeader, got)
+	}
+	resp = get("")
+	generated := resp.Header.Get(logging.RequestIDHeader)
+	if len(generated) != 32 {
+		t.Errorf("generated %s = %q, want 32 hex digits", logging.RequestIDHeader, generated)
+	}
+
+	var found bool
+	for _, line := range a.logs.lines(t, "request") {
+		if line["request_id"] != "test-request-1" {
+			continue
+		}
+		found = true
+		if line["route"] != "/players/{id}/limits" {
+			t.Errorf("route = %v, want /players/{id}/limits", line["route"])
+		}
+		if line["status"] != float64(http.StatusOK) {
+			t.Errorf("status = %v, want %d", line["status"], http.StatusOK)
+		}
+		if line["player_id"] != float64(player.ID) {
+			t.Errorf("player_id = %v, want %d", line["player_id"], player.ID)
+		}
+		for _, key := range []string{"duration_ms", "bytes"} {
+			if _, ok := line[key]; !ok {
+				t.Errorf("request log has no %s: %v", key, line)
+			}
+		}
+	}
+	if !found {
+		t.Fatalf("no request log line for test-request-1 in:\n%s", a.logs)
+	}
+
+	if logs := a.logs.String(); strings.Contains(logs, player.Email) || strings.Contains(logs, "password123") {
+		t.Errorf("logs contain the player's email or password:\n%s", logs)
+	}
+}
+
+func TestTracing(t *testing.T) {
+	// The global tracer provider can only be replaced once, so this is the
+	// only test that records spans.
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+
+	a := newAPI(t)
+	player := a.createPlayer(t, "traced", 100)
+	tournament := a.createTournament(t, "traced", 1000)
+
+	const (
+		traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+		spanID  = "00f067aa0ba902b7"
+	)
+	body, err := json.Marshal(dtos.CreateTournamentBetRequest{PlayerID: player.ID, TournamentID: tournament.ID, BetAmount: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := http.NewRequest(http.MethodPost, a.server.URL+"/bets", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("traceparent", "00-"+traceID+"-"+spanID+"-01")
+	resp, err := a.server.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("POST /bets status %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+
+	// Spans are named after the route, the service and repository methods
+	// and, on the SQL backends, the statements.
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	var statements []sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if span.SpanContext().TraceID().String() != traceID {
+			continue
+		}
+		switch name := span.Name(); {
+		case name == "POST /bets", strings.Contains(name, "Service."),
+			strings.Contains(name, "Repository."), name == "UnitOfWork.Do":
+			spans[name] = span
+		default:
+			statements = append(statements, span)
+		}
+	}
+	server := spans["POST /bets"]
+	if server == nil {
+		t.Fatalf("no span named after the route in the caller's trace; spans: %d", len(recorder.Ended()))
+	}
+	if got := server.Parent().SpanID().String(); got != spanID {
+		t.Errorf("request span parent = %s, want the caller's span %s", got, spanID)
+	}
+	attrs := make(map[string]string)
+	for _, kv := range server.Attributes() {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+	if attrs["http.route"] != "/bets" || attrs["http.response.status_code"] != "201" {
+		t.Errorf("request span attributes = %v", attrs)
+	}
+
+	parents := map[string]string{
+		"BettingService.PlaceBet":                    "POST /bets",
+		"UnitOfWork.Do":                              "BettingService.PlaceBet",
+		"TournamentRepository.GetForUpdate":          "BettingService.PlaceBet",
+		"PlayerRepository.GetForUpdate":              "BettingService.PlaceBet",
+		"PlayerExclusionRepository.CheckNotExcluded": "BettingService.PlaceBet",
+		"PlayerLimitRepository.GetLimits":            "BettingService.PlaceBet",
+		"TournamentBetRepository.Create":             "BettingService.PlaceBet",
+	}
+	for name, parent := range parents {
+		span, ok := spans[name]
+		if !ok {
+			t.Errorf("no %s span", name)
+			continue
+		}
+		if span.Parent().SpanID() != spans[parent].SpanContext().SpanID() {
+			t.Errorf("span %s is not a child of %s", name, parent)
+		}
+	}
+
+	// The memory backend runs no SQL.
+	if backend := os.Getenv("TEST_STORAGE_BACKEND"); backend == "" || backend == config.StorageMemory {
+		return
+	}
+	if len(statements) == 0 {
+		t.Fatal("no SQL statement spans under the request")
+	}
+	callers := make(map[trace.SpanID]bool)
+	for name, span := range spans {
+		if strings.Contains(name, "Repository.") || name == "UnitOfWork.Do" {
+			callers[span.SpanContext().SpanID()] = true
+		}
+	}
+	for _, span := range statements {
+		if !callers[span.Parent().SpanID()] {
+			t.Errorf("span %q is not a child of a repository method or unit of work", span.Name())
+		}
+		for _, kv := range span.Attributes() {
+			if kv.Key == "db.statement" || kv.Key == "db.query.text" {
+				t.Errorf("span %q records the statement: %s", span.Name(), kv.Value.Emit())
+			}
+		}
+	}
+}
+
+func TestPlayers(t *testing.T) {
+	a := newAPI(t)
+
+	alice := a.createPlayer(t, "alice", 500)
+	if alice.ID == 0 || alice.Name != "alice" || alice.AccountBalance != 500 {
+		t.Fatalf("created player = %+v", alice)
+	}
+	bob := a.createPlayer(t, "bob", 250)
+
+	var players []dtos.PlayerResponse
+	a.do(t, http.MethodGet, "/players", nil, http.StatusOK, &players)
+	if len(players) != 2 {
+		t.Fatalf("got %d players, want 2", len(players))
+	}
+
+	balances := a.balances(t)
+	if balances[alice.ID] != 500 || balances[bob.ID] != 250 {
+		t.Errorf("balances = %v", balances)
+	}
+
+	var errResp handlers.ErrorResponse
+	a.do(t, http.MethodPost, "/players", "not an object", http.StatusBadRequest, &errResp)
+	if errResp.Error == "" {
+		t.Error("invalid request returned no error message")
+	}
+
+	a.do(t, http.MethodPost, "/players", dtos.CreatePlayerRequest{
+		Name:     "alice again",
+		Email:    alice.Email,
+		Password: "password123",
+	}, http.StatusConflict, nil)
+}
+
+func TestTournaments(t *testing.T) {
+	a := newAPI(t)
+
+	created := a.createTournament(t, "Spring Open", 1000)
+	if created.ID == 0 || created.PrizePool != 1000 || created.PoolMode != models.PoolModeFixed {
+		t.Fatalf("created tournament = %+v", created)
+	}
+
+	var tournaments []models.Tournament
+	a.do(t, http.MethodGet, "/tournaments", nil, http.StatusOK, &tournaments)
+	if len(tournaments) != 1 || tournaments[0].ID != created.ID {
+		t.Fatalf("tournaments = %+v", tournaments)
+	}
+
+	now := a.clock.Now()
+	invalid := []struct {
+		name string
+		req  dtos.CreateTournamentRequest
+	}{
+		{"missing name", dtos.CreateTournamentRequest{PrizePool: 100, StartDate: now, EndDate: now.Add(time.Hour)}},
+		{"no prize pool", dtos.CreateTournamentRequest{Name: "Empty", StartDate: now, EndDate: now.Add(time.Hour)}},
+		{"ends before start", dtos.CreateTournamentRequest{Name: "Backwards", PrizePool: 100, StartDate: now, EndDate: now.Add(-time.Hour)}},
+		{"unknown pool mode", dtos.CreateTournamentRequest{Name: "Odd", PrizePool: 100, PoolMode: "weird", StartDate: now, EndDate: now.Add(time.Hour)}},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			a.do(t, http.MethodPost, "/tournaments", tc.req, http.StatusBadRequest, nil)
+		})
+	}
+}
+
+func TestBets(t *testing.T) {
+	a := newAPI(t)
+
+	player := a.createPlayer(t, "carol", 100)
+	tournament := a.createTournament(t, "Summer Cup", 500)
+
+	bet := a.placeBet(t, player.ID, tournament.ID, 40)
+	if bet.ID == 0 || bet.PlayerID != player.ID || bet.TournamentID != tournament.ID || bet.BetAmount != 40 {
+		t.Fatalf("placed bet = %+v", bet)
+	}
+	a.wantBalance(t, player.ID, 60)
+
+	errorCases := []struct {
+		name   string
+		req    dtos.CreateTournamentBetRequest
+		status int
+		code   string
+	}{
+		{"insufficient funds", dtos.CreateTournamentBetRequest{PlayerID: player.ID, TournamentID: tournament.ID, BetAmount: 60.01}, http.StatusBadRequest, "INSUFFICIENT_FUNDS"},
+		{"unknown player", dtos.CreateTournamentBetRequest{PlayerID: unknownID, TournamentID: tournament.ID, BetAmount: 10}, http.StatusNotFound, "PLAYER_NOT_FOUND"},
+		{"unknown tournament", dtos.CreateTournamentBetRequest{PlayerID: player.ID, TournamentID: unknownID, BetAmount: 10}, http.StatusNotFound, "TOURNAMENT_NOT_FOUND"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var errResp handlers.DetailedErrorResponse
+			a.do(t, http.MethodPost, "/bets", tc.req, tc.status, &errResp)
+			if errResp.Code != tc.code {
+				t.Errorf("code = %q, want %q", errResp.Code, tc.code)
+			}
+		})
+	}
+
+	// Rejected bets leave the balance alone, and the whole balance can
+	// still be bet.
+	a.wantBalance(t, player.ID, 60)
+	a.placeBet(t, player.ID, tournament.ID, 60)
+	a.wantBalance(t, player.ID, 0)
+
+	var bets []dtos.TournamentBetResponse
+	a.do(t, http.MethodGet, "/bets", nil, http.StatusOK, &bets)
+	if len(bets) != 2 {
+		t.Fatalf("got %d bets, want 2", len(bets))
+	}
+	total := 0.0
+	for _, b := range bets {
+		total += b.BetAmount
+	}
+	if total != 100 {
+		t.Errorf("total bet = %.2f, want 100", total)
+	}
+}
+
+func TestBetRules(t *testing.T) {
+	a := newAPI(t)
+
+	paul := a.createPlayer(t, "paul", 1000)
+	quinn := a.createPlayer(t, "quinn", 1000)
+
+	t.Run("entry fee", func(t *testing.T) {
+		req := a.tournamentRequest("Entry Cup", 100)
+		req.EntryFee = ptr(50.0)
+		tr := a.postTournament(t, req)
+
+		a.betError(t, paul.ID, tr.ID, 40, http.StatusBadRequest, "ENTRY_FEE_MISMATCH")
+		a.placeBet(t, paul.ID, tr.ID, 50)
+		a.betError(t, paul.ID, tr.ID, 50, http.StatusConflict, "ALREADY_ENTERED")
+	})
+
+	t.Run("bet size", func(t *testing.T) {
+		req := a.tournamentRequest("Sized Cup", 100)
+		req.MinBet = ptr(10.0)
+		req.MaxBet = ptr(100.0)
+		tr := a.postTournament(t, req)
+
+		a.betError(t, paul.ID, tr.ID, 9.99, http.StatusBadRequest, "BET_BELOW_MINIMUM")
+		a.betError(t, paul.ID, tr.ID, 100.01, http.StatusBadRequest, "BET_ABOVE_MAXIMUM")
+		a.placeBet(t, paul.ID, tr.ID, 10)
+		a.placeBet(t, paul.ID, tr.ID, 100)
+	})
+
+	t.Run("stake", func(t *testing.T) {
+		req := a.tournamentRequest("Staked Cup", 100)
+		req.MaxStakePerPlayer = ptr(150.0)
+		tr := a.postTournament(t, req)
+
+		a.placeBet(t, paul.ID, tr.ID, 100)
+		a.betError(t, paul.ID, tr.ID, 50.01, http.StatusBadRequest, "STAKE_LIMIT_EXCEEDED")
+		a.placeBet(t, paul.ID, tr.ID, 50)
+		// The stake is per player.
+		a.placeBet(t, quinn.ID, tr.ID, 150)
+	})
+
+	t.Run("participants", func(t *testing.T) {
+		req := a.tournamentRequest("Heads Up", 100)
+		req.MaxParticipants = ptr(1)
+		tr := a.postTournament(t, req)
+
+		a.placeBet(t, paul.ID, tr.ID, 10)
+		a.betError(t, quinn.ID, tr.ID, 10, http.StatusConflict, "PARTICIPANT_LIMIT_REACHED")
+		// Players already in keep betting.
+		a.placeBet(t, paul.ID, tr.ID, 10)
+	})
+
+	a.wantBalance(t, paul.ID, 1000-50-110-150-20)
+	a.wantBalance(t, quinn.ID, 1000-150)
+}
+
+func TestBettingWindow(t *testing.T) {
+	a := newAPI(t)
+
+	rita := a.createPlayer(t, "rita", 1000)
+	sam := a.createPlayer(t, "sam", 1000)
+
+	now := a.clock.Now()
+	req := a.tournamentRequest("Opens Later", 100)
+	req.BettingOpensAt = ptr(now.Add(time.Hour))
+	opensLater := a.postTournament(t, req)
+
+	req = a.tournamentRequest("Closes Early", 100)
+	req.BettingClosesAt = ptr(now.Add(2 * time.Hour))
+	closesEarly := a.postTournament(t, req)
+
+	// Started an hour ago, so new players can join for another half hour.
+	req = a.tournamentRequest("Late Registration", 100)
+	req.LateRegistrationMinutes = ptr(90)
+	lateRegistration := a.postTournament(t, req)
+
+	a.betError(t, rita.ID, opensLater.ID, 10, http.StatusConflict, "BETTING_WINDOW_CLOSED")
+	a.placeBet(t, rita.ID, closesEarly.ID, 10)
+	a.placeBet(t, rita.ID, lateRegistration.ID, 10)
+
+	a.clock.Advance(time.Hour)
+	a.placeBet(t, rita.ID, opensLater.ID, 10)
+	a.placeBet(t, rita.ID, lateRegistration.ID, 10)
+	a.betError(t, sam.ID, lateRegistration.ID, 10, http.StatusConflict, "BETTING_WINDOW_CLOSED")
+
+	a.clock.Advance(time.Hour)
+	a.betError(t, rita.ID, closesEarly.ID, 10, http.StatusConflict, "BETTING_WINDOW_CLOSED")
+
+	// Without a closing time betting closes at the end date.
+	a.clock.Set(opensLater.EndDate)
+	a.betError(t, rita.ID, opensLater.ID, 10, http.StatusConflict, "BETTING_WINDOW_CLOSED")
+
+	a.wantBalance(t, rita.ID, 960)
+	a.wantBalance(t, sam.ID, 1000)
+}
+
+func TestPlayerLimits(t *testing.T) {
+	a := newAPI(t)
+
+	tina := a.createPlayer(t, "tina", 1000)
+	req := a.tournamentRequest("Limited Cup", 100)
+	req.EndDate = a.clock.Now().Add(72 * time.Hour)
+	tournament := a.postTournament(t, req)
+	path := fmt.Sprintf("/players/%d/limits", tina.ID)
+
+	dailyWager := func(amount *float64) dtos.SetPlayerLimitsRequest {
+		return dtos.SetPlayerLimitsRequest{Limits: []dtos.PlayerLimitChange{
+			{Type: models.LimitTypeWager, Period: models.LimitPeriodDaily, Amount: amount},
+		}}
+	}
+	// limit sends the request and returns the daily wager limit it responds
+	// with. Each response is decoded afresh, as omitted fields would keep
+	// the values of the last one.
+	limit := func(method string, body any) dtos.PlayerLimitResponse {
+		t.Helper()
+		var limits []dtos.PlayerLimitResponse
+		a.do(t, method, path, body, http.StatusOK, &limits)
+		if len(limits) != 1 {
+			t.Fatalf("limits = %+v, want the daily wager limit", limits)
+		}
+		return limits[0]
+	}
+
+	var limits []dtos.PlayerLimitResponse
+	a.do(t, http.MethodGet, path, nil, http.StatusOK, &limits)
+	if len(limits) != 0 {
+		t.Fatalf("new player limits = %+v", limits)
+	}
+
+	if l := limit(http.MethodPut, dailyWager(ptr(50.0))); *l.Amount != 50 || l.Used != 0 || *l.Remaining != 50 || l.PendingAmount != nil {
+		t.Errorf("new limit = %+v", l)
+	}
+
+	a.placeBet(t, tina.ID, tournament.ID, 40)
+	a.betError(t, tina.ID, tournament.ID, 10.01, http.StatusForbidden, "RESPONSIBLE_GAMBLING_LIMIT")
+	a.placeBet(t, tina.ID, tournament.ID, 10)
+
+	// Raising the limit waits out the cooling-off period.
+	effectiveAt := a.clock.Now().Add(repository.DefaultLimitCoolingOff)
+	if l := limit(http.MethodPut, dailyWager(ptr(100.0))); *l.Amount != 50 || l.Used != 50 || *l.Remaining != 0 ||
+		l.PendingAmount == nil || *l.PendingAmount != 100 || l.PendingEffectiveAt == nil || !l.PendingEffectiveAt.Equal(effectiveAt) {
+		t.Errorf("raised limit = %+v", l)
+	}
+	a.betError(t, tina.ID, tournament.ID, 1, http.StatusForbidden, "RESPONSIBLE_GAMBLING_LIMIT")
+
+	// A day later the raise is in force and the new day's usage starts at
+	// nothing.
+	a.clock.Advance(25 * time.Hour)
+	if l := limit(http.MethodGet, nil); *l.Amount != 100 || l.Used != 0 || l.PendingAmount != nil || l.PendingEffectiveAt != nil {
+		t.Errorf("limit after the cooling-off period = %+v", l)
+	}
+	a.placeBet(t, tina.ID, tournament.ID, 100)
+	a.betError(t, tina.ID, tournament.ID, 1, http.StatusForbidden, "RESPONSIBLE_GAMBLING_LIMIT")
+
+	// Lowering applies straight away, removing waits like raising.
+	if l := limit(http.MethodPut, dailyWager(ptr(80.0))); *l.Amount != 80 || l.PendingEffectiveAt != nil {
+		t.Errorf("lowered limit = %+v", l)
+	}
+	if l := limit(http.MethodPut, dailyWager(nil)); *l.Amount != 80 || l.PendingAmount != nil || l.PendingEffectiveAt == nil {
+		t.Errorf("removed limit = %+v", l)
+	}
+	a.wantBalance(t, tina.ID, 850)
+
+	invalid := map[string][]dtos.PlayerLimitChange{
+		"no limits":        {},
+		"unknown type":     {{Type: "deposit", Period: models.LimitPeriodDaily, Amount: ptr(10.0)}},
+		"unknown period":   {{Type: models.LimitTypeLoss, Period: "yearly", Amount: ptr(10.0)}},
+		"zero amount":      {{Type: models.LimitTypeLoss, Period: models.LimitPeriodDaily, Amount: ptr(0.0)}},
+		"duplicate limits": {{Type: models.LimitTypeLoss, Period: models.LimitPeriodWeekly, Amount: ptr(10.0)}, {Type: models.LimitTypeLoss, Period: models.LimitPeriodWeekly}},
+	}
+	for name, changes := range invalid {
+		t.Run(name, func(t *testing.T) {
+			a.do(t, http.MethodPut, path, dtos.SetPlayerLimitsRequest{Limits: changes}, http.StatusBadRequest, nil)
+		})
+	}
+
+	a.do(t, http.MethodPut, fmt.Sprintf("/players/%d/limits", unknownID), dailyWager(ptr(10.0)), http.StatusNotFound, nil)
+	a.do(t, http.MethodGet, fmt.Sprintf("/players/%d/limits", unknownID), nil, http.StatusNotFound, nil)
+	a.do(t, http.MethodPut, "/players/abc/limits", dailyWager(ptr(10.0)), http.StatusBadRequest, nil)
+}
+
+func TestExclusions(t *testing.T) {
+	a := newAPI(t)
+
+	uma := a.createPlayer(t, "uma", 500)
+	vera := a.createPlayer(t, "vera", 400)
+	walt := a.createPlayer(t, "walt", 300)
+	tournament := a.createTournament(t, "Excluded Cup", 100)
+	now := a.clock.Now()
+
+	var exclusion dtos.PlayerExclusionResponse
+	a.do(t, http.MethodPost, fmt.Sprintf("/players/%d/exclusions", uma.ID),
+		dtos.CreatePlayerExclusionRequest{Duration: models.ExclusionDuration24Hours}, http.StatusCreated, &exclusion)
+	if exclusion.PlayerID != uma.ID || !exclusion.Active || exclusion.EndsAt == nil || !exclusion.EndsAt.Equal(now.Add(24*time.Hour)) {
+		t.Errorf("24h exclusion = %+v", exclusion)
+	}
+	a.do(t, http.MethodPost, fmt.Sprintf("/players/%d/exclusions", vera.ID),
+		dtos.CreatePlayerExclusionRequest{Duration: models.ExclusionDurationPermanent}, http.StatusCreated, &exclusion)
+	if exclusion.PlayerID != vera.ID || !exclusion.Active || exclusion.EndsAt != nil {
+		t.Errorf("permanent exclusion = %+v", exclusion)
+	}
+
+	// Excluded players cannot bet and are not ranked.
+	a.betError(t, uma.ID, tournament.ID, 10, http.StatusForbidden, "PLAYER_SELF_EXCLUDED")
+	a.betError(t, vera.ID, tournament.ID, 10, http.StatusForbidden, "PLAYER_SELF_EXCLUDED")
+	a.do(t, http.MethodGet, fmt.Sprintf("/players/%d/rank", uma.ID), nil, http.StatusNotFound, nil)
+	var rankings []models.PlayerRanking
+	a.do(t, http.MethodGet, "/rankings", nil, http.StatusOK, &rankings)
+	if len(rankings) != 1 || rankings[0].PlayerID != walt.ID {
+		t.Errorf("rankings while excluded = %+v", rankings)
+	}
+
+	var active []dtos.PlayerExclusionResponse
+	a.do(t, http.MethodGet, "/admin/exclusions", nil, http.StatusOK, &active)
+	if len(active) != 2 {
+		t.Errorf("active exclusions = %+v, want uma's and vera's", active)
+	}
+
+	// The 24 hour exclusion runs out, the permanent one never does.
+	a.clock.Advance(25 * time.Hour)
+
+	var history []dtos.PlayerExclusionResponse
+	a.do(t, http.MethodGet, fmt.Sprintf("/players/%d/exclusions", uma.ID), nil, http.StatusOK, &history)
+	if len(history) != 1 || history[0].Active {
+		t.Errorf("uma's exclusions after 25 hours = %+v", history)
+	}
+	a.do(t, http.MethodGet, "/admin/exclusions", nil, http.StatusOK, &active)
+	if len(active) != 1 || active[0].PlayerID != vera.ID {
+		t.Errorf("active exclusions after 25 hours = %+v, want vera's", active)
+	}
+
+	next := a.createTournament(t, "Back Again", 100)
+	a.placeBet(t, uma.ID, next.ID, 10)
+	a.betError(t, vera.ID, next.ID, 10, http.StatusForbidden, "PLAYER_SELF_EXCLUDED")
+
+	a.do(t, http.MethodPost, fmt.Sprintf("/players/%d/exclusions", walt.ID),
+		dtos.CreatePlayerExclusionRequest{Duration: "1y"}, http.StatusBadRequest, nil)
+	a.do(t, http.MethodPost, fmt.Sprintf("/players/%d/exclusions", unknownID),
+		dtos.CreatePlayerExclusionRequest{Duration: models.ExclusionDuration7Days}, http.StatusNotFound, nil)
+	a.do(t, http.MethodPost, "/players/abc/exclusions",
+		dtos.CreatePlayerExclusionRequest{Duration: models.ExclusionDuration7Days}, http.StatusBadRequest, nil)
+	a.do(t, http.MethodGet, "/players/abc/exclusions", nil, http.StatusBadRequest, nil)
+}
+
+func TestRankings(t *testing.T) {
+	a := newAPI(t)
+
+	dave := a.createPlayer(t, "dave", 300)
+	erin := a.createPlayer(t, "erin", 500)
+	frank := a.createPlayer(t, "frank", 300)
+
+	var rankings []models.PlayerRanking
+	a.do(t, http.MethodGet, "/rankings", nil, http.StatusOK, &rankings)
+	want := map[uint]int{erin.ID: 1, dave.ID: 2, frank.ID: 2}
+	if len(rankings) != len(want) {
+		t.Fatalf("got %d rankings, want %d", len(rankings), len(want))
+	}
+	for _, r := range rankings {
+		if r.Rank != want[r.PlayerID] {
+			t.Errorf("player %d rank = %d, want %d", r.PlayerID, r.Rank, want[r.PlayerID])
+		}
+	}
+
+	a.do(t, http.MethodGet, "/rankings?limit=1", nil, http.StatusOK, &rankings)
+	if len(rankings) != 1 || rankings[0].PlayerID != erin.ID {
+		t.Errorf("top ranking = %+v", rankings)
+	}
+
+	// A bet moves the player down without waiting for a rebuild.
+	tournament := a.createTournament(t, "Ranking Cup", 100)
+	a.placeBet(t, erin.ID, tournament.ID, 250)
+
+	var rank models.PlayerRanking
+	a.do(t, http.MethodGet, fmt.Sprintf("/players/%d/rank", erin.ID), nil, http.StatusOK, &rank)
+	if rank.Rank != 2 || rank.AccountBalance != 250 {
+		t.Errorf("rank after bet = %+v", rank)
+	}
+
+	a.do(t, http.MethodGet, fmt.Sprintf("/players/%d/rank", unknownID), nil, http.StatusNotFound, nil)
+	a.do(t, http.MethodGet, "/players/abc/rank", nil, http.StatusBadRequest, nil)
+	a.do(t, http.MethodGet, "/rankings?limit=-1", nil, http.StatusBadRequest, nil)
+}
+
+func TestRankingSnapshotPrune(t *testing.T) {
+	a := newAPI(t)
+	ctx := context.Background()
+	player := a.createPlayer(t, "olga", 100)
+
+	now := a.clock.Now()
+	day := func(month time.Month, d, hour int) time.Time {
+		return time.Date(2025, month, d, hour, 0, 0, 0, time.UTC)
+	}
+	taken := []time.Time{
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		day(time.February, 1, 0), day(time.February, 1, 1), day(time.February, 1, 13),
+		day(time.February, 2, 5), day(time.February, 2, 6),
+		day(time.February, 25, 10), day(time.February, 25, 11),
+	}
+	for _, at := range taken {
+		a.clock.Set(at)
+		if err := a.store.Snapshots.Take(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	a.clock.Set(now)
+
+	// Hourly snapshots are kept for 8 days, daily ones for a year.
+	want := []time.Time{day(time.February, 1, 0), day(time.February, 2, 5), day(time.February, 25, 10), day(time.February, 25, 11)}
+	for range 2 {
+		if err := a.store.Snapshots.Prune(ctx, now.Add(-8*24*time.Hour), now.AddDate(-1, 0, 0)); err != nil {
+			t.Fatalf("prune: %v", err)
+		}
+
+		history, err := a.store.Snapshots.GetHistory(ctx, player.ID, taken[0], now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []time.Time
+		for _, s := range history {
+			got = append(got, s.TakenAt.UTC())
+		}
+		if !slices.EqualFunc(got, want, time.Time.Equal) {
+			t.Fatalf("snapshots after pruning = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestRankHistoryAndClimbers(t *testing.T) {
+	a := newAPI(t)
+	ctx := context.Background()
+
+	amber := a.createPlayer(t, "amber", 100)
+	basil := a.createPlayer(t, "basil", 200)
+	cyril := a.createPlayer(t, "cyril", 300)
+
+	snapshot := func() {
+		t.Helper()
+		if err := a.store.Snapshots.Take(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Eight days ago cyril leads, two days ago basil has dropped to last,
+	// and now cyril drops to share second place with him.
+	now := a.clock.Now()
+	a.clock.Set(now.AddDate(0, 0, -8))
+	snapshot()
+	a.clock.Set(now.AddDate(0, 0, -2))
+	a.placeBet(t, basil.ID, a.createTournament(t, "Two Days Ago", 100).ID, 150)
+	snapshot()
+	a.clock.Set(now)
+	a.placeBet(t, cyril.ID, a.createTournament(t, "Today", 100).ID, 250)
+	if err := a.rankings.RefreshHistory(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	var rankings []models.PlayerRanking
+	a.do(t, http.MethodGet, "/rankings", nil, http.StatusOK, &rankings)
+	changes := map[uint][2]int{amber.ID: {1, 2}, basil.ID: {1, 0}, cyril.ID: {-1, -1}}
+	for _, r := range rankings {
+		want := changes[r.PlayerID]
+		if r.RankChangeDay == nil || r.RankChangeWeek == nil || *r.RankChangeDay != want[0] || *r.RankChangeWeek != want[1] {
+			t.Errorf("player %d rank changes = %v, %v; want %v", r.PlayerID, r.RankChangeDay, r.RankChangeWeek, want)
+		}
+	}
+
+	climbers := func(query string) []uint {
+		t.Helper()
+		var climbers []models.PlayerRanking
+		a.do(t, http.MethodGet, "/rankings/climbers"+query, nil, http.StatusOK, &climbers)
+		var ids []uint
+		for _, c := range climbers {
+			ids = append(ids, c.PlayerID)
+		}
+		return ids
+	}
+	if got, want := climbers(""), []uint{amber.ID, basil.ID}; !slices.Equal(got, want) {
+		t.Errorf("day climbers = %v, want %v", got, want)
+	}
+	if got, want := climbers("?period=week"), []uint{amber.ID}; !slices.Equal(got, want) {
+		t.Errorf("week climbers = %v, want %v", got, want)
+	}
+	if got, want := climbers("?limit=1"), []uint{amber.ID}; !slices.Equal(got, want) {
+		t.Errorf("top day climber = %v, want %v", got, want)
+	}
+	for _, query := range []string{"?period=month", "?limit=0", "?limit=101"} {
+		a.do(t, http.MethodGet, "/rankings/climbers"+query, nil, http.StatusBadRequest, nil)
+	}
+
+	history := func(query string) []models.RankingSnapshot {
+		t.Helper()
+		var snapshots []models.RankingSnapshot
+		a.do(t, http.MethodGet, fmt.Sprintf("/players/%d/rankings/history%s", basil.ID, query), nil, http.StatusOK, &snapshots)
+		return snapshots
+	}
+	if got := history(""); len(got) != 2 || got[0].Rank != 2 || got[0].AccountBalance != 200 || got[1].Rank != 3 || got[1].AccountBalance != 50 {
+		t.Errorf("last 30 days = %+v", got)
+	}
+	twoDaysAgo := now.AddDate(0, 0, -2).Format(time.DateOnly)
+	if got := history("?from=" + twoDaysAgo); len(got) != 1 || got[0].Rank != 3 {
+		t.Errorf("history from %s = %+v", twoDaysAgo, got)
+	}
+	// A plain date includes the whole day.
+	if got := history("?to=" + twoDaysAgo); len(got) != 2 {
+		t.Errorf("history up to %s = %+v", twoDaysAgo, got)
+	}
+	if got := history("?from=" + now.AddDate(0, 0, -1).Format(time.RFC3339)); len(got) != 0 {
+		t.Errorf("history since yesterday = %+v", got)
+	}
+
+	for _, query := range []string{
+		"?from=yesterday",
+		"?to=2025-13-01",
+		"?from=2025-03-01&to=2025-02-01",
+		"?from=2024-01-01&to=2025-02-01",
+	} {
+		a.do(t, http.MethodGet, fmt.Sprintf("/players/%d/rankings/history%s", basil.ID, query), nil, http.StatusBadRequest, nil)
+	}
+	a.do(t, http.MethodGet, fmt.Sprintf("/players/%d/rankings/history", unknownID), nil, http.StatusNotFound, nil)
+	a.do(t, http.MethodGet, "/players/abc/rankings/history", nil, http.StatusBadRequest, nil)
+}
+
+func TestRankingConsistency(t *testing.T) {
+	a := newAPI(t)
+	ctx := context.Background()
+
+	dora := a.createPlayer(t, "dora", 500)
+	a.createPlayer(t, "eddy", 300)
+	a.placeBet(t, dora.ID, a.createTournament(t, "Checked Cup", 100).ID, 100)
+
+	var report ranking.Report
+	a.do(t, http.MethodGet, "/admin/rankings/consistency", nil, http.StatusOK, &report)
+	if !report.Consistent || report.BoardsChecked != 2 || report.PlayersChecked != 3 {
+		t.Fatalf("report = %+v, want 2 consistent boards and 3 players", report)
+	}
+
+	// A balance changed behind the service's back shows until the next
+	// rebuild.
+	if err := a.store.Players.AdjustBalance(ctx, dora.ID, 50); err != nil {
+		t.Fatal(err)
+	}
+	a.do(t, http.MethodGet, "/admin/rankings/consistency", nil, http.StatusOK, &report)
+	want := ranking.Mismatch{Board: "global", PlayerID: dora.ID, Reason: ranking.MismatchScore, Expected: 450, Actual: 400, ExpectedRank: 1, ActualRank: 1}
+	if report.Consistent || report.MismatchCount != 1 || len(report.Mismatches) != 1 || report.Mismatches[0] != want {
+		t.Fatalf("report = %+v, want the one mismatch %+v", report, want)
+	}
+
+	if err := a.rankings.Rebuild(ctx); err != nil {
+		t.Fatal(err)
+	}
+	a.do(t, http.MethodGet, "/admin/rankings/consistency", nil, http.StatusOK, &report)
+	if !report.Consistent {
+		t.Fatalf("report after rebuild = %+v", report)
+	}
+}
+
+func TestLeaderboard(t *testing.T) {
+	a := newAPI(t)
+
+	gina := a.createPlayer(t, "gina", 1000)
+	hank := a.createPlayer(t, "hank", 1000)
+	tournament := a.createTournament(t, "Leaderboard Cup", 1000)
+
+	a.placeBet(t, gina.ID, tournament.ID, 100)
+	a.placeBet(t, hank.ID, tournament.ID, 50)
+	a.placeBet(t, hank.ID, tournament.ID, 25)
+
+	var board dtos.LeaderboardResponse
+	a.do(t, http.MethodGet, fmt.Sprintf("/tournaments/%d/leaderboard", tournament.ID), nil, http.StatusOK, &board)
+	if board.TotalParticipants != 2 || len(board.Entries) != 2 {
+		t.Fatalf("leaderboard = %+v", board)
+	}
+
+	first, second := board.Entries[0], board.Entries[1]
+	if first.PlayerID != gina.ID || first.TotalBet != 100 || first.ProjectedPrize != 500 {
+		t.Errorf("first entry = %+v", first)
+	}
+	if second.PlayerID != hank.ID || second.TotalBet != 75 || second.ProjectedPrize != 300 || second.GapToNext != 25 {
+		t.Errorf("second entry = %+v", second)
+	}
+
+	a.do(t, http.MethodGet, fmt.Sprintf("/tournaments/%d/leaderboard", unknownID), nil, http.StatusNotFound, nil)
+}
+
+func TestPrizeDistribution(t *testing.T) {
+	a := newAPI(t)
+
+	ivan := a.createPlayer(t, "ivan", 1000)
+	judy := a.createPlayer(t, "judy", 1000)
+	kate := a.createPlayer(t, "kate", 1000)
+	liam := a.createPlayer(t, "liam", 1000)
+	tournament := a.createTournament(t, "Grand Final", 1000)
+
+	a.placeBet(t, ivan.ID, tournament.ID, 300)
+	a.placeBet(t, judy.ID, tournament.ID, 200)
+	a.placeBet(t, kate.ID, tournament.ID, 200)
+	a.placeBet(t, liam.ID, tournament.ID, 100)
+
+	prizesPath := fmt.Sprintf("/tournaments/prizes/%d", tournament.ID)
+	a.do(t, http.MethodPost, prizesPath, nil, http.StatusAccepted, nil)
+
+	// Placements are dense: Judy and Kate tie for second and split the
+	// second and third tiers, and Liam still places third.
+	want := map[uint]float64{
+		ivan.ID: 700 + 500,
+		judy.ID: 800 + 250,
+		kate.ID: 800 + 250,
+		liam.ID: 900 + 200,
+	}
+	for id, balance := range want {
+		a.wantBalance(t, id, balance)
+	}
+
+	// A second distribution is refused and pays nothing.
+	var errResp handlers.ErrorResponse
+	a.do(t, http.MethodPost, prizesPath, nil, http.StatusConflict, &errResp)
+	if errResp.Error == "" {
+		t.Error("double distribution returned no error message")
+	}
+	for id, balance := range want {
+		a.wantBalance(t, id, balance)
+	}
+
+	// Betting closes with the distribution.
+	var betErr handlers.DetailedErrorResponse
+	a.do(t, http.MethodPost, "/bets", dtos.CreateTournamentBetRequest{
+		PlayerID:     liam.ID,
+		TournamentID: tournament.ID,
+		BetAmount:    10,
+	}, http.StatusConflict, &betErr)
+	if betErr.Code != "BETTING_WINDOW_CLOSED" {
+		t.Errorf("bet after distribution code = %q, want BETTING_WINDOW_CLOSED", betErr.Code)
+	}
+	a.wantBalance(t, liam.ID, 1100)
+
+	// The cached rankings see the prizes straight away.
+	var rank models.PlayerRanking
+	a.do(t, http.MethodGet, fmt.Sprintf("/players/%d/rank", ivan.ID), nil, http.StatusOK, &rank)
+	if rank.Rank != 1 || rank.AccountBalance != 1200 {
+		t.Errorf("winner rank = %+v", rank)
+	}
+
+	// Settling the tournament rates its field.
+	var rating dtos.PlayerRatingResponse
+	a.do(t, http.MethodGet, fmt.Sprintf("/players/%d/rating", ivan.ID), nil, http.StatusOK, &rating)
+	if rating.TournamentsPlayed != 1 || len(rating.History) != 1 {
+		t.Errorf("winner rating = %+v", rating)
+	}
+
+	a.do(t, http.MethodPost, fmt.Sprintf("/tournaments/prizes/%d", unknownID), nil, http.StatusNotFound, nil)
+	a.do(t, http.MethodPost, "/tournaments/prizes/abc", nil, http.StatusBadRequest, nil)
+
+	empty := a.createTournament(t, "No Shows", 100)
+	a.do(t, http.MethodPost, fmt.Sprintf("/tournaments/prizes/%d", empty.ID), nil, http.StatusBadRequest, nil)
+}
+
+func TestRatings(t *testing.T) {
+	a := newAPI(t)
+
+	fern := a.createPlayer(t, "fern", 1000)
+	gus := a.createPlayer(t, "gus", 1000)
+	hugo := a.createPlayer(t, "hugo", 1000)
+	a.createPlayer(t, "unrated", 1000)
+	tournament := a.createTournament(t, "Rated Cup", 300)
+
+	a.placeBet(t, fern.ID, tournament.ID, 300)
+	a.placeBet(t, gus.ID, tournament.ID, 200)
+	a.placeBet(t, hugo.ID, tournament.ID, 100)
+	a.do(t, http.MethodPost, fmt.Sprintf("/tournaments/prizes/%d", tournament.ID), nil, http.StatusAccepted, nil)
+
+	ratings := func(query string) []models.PlayerRating {
+		t.Helper()
+		var ratings []models.PlayerRating
+		a.do(t, http.MethodGet, "/ratings"+query, nil, http.StatusOK, &ratings)
+		return ratings
+	}
+
+	want := []struct {
+		playerID uint
+		rating   float64
+	}{{fern.ID, 1516}, {gus.ID, 1500}, {hugo.ID, 1484}}
+	before := ratings("")
+	if len(before) != len(want) {
+		t.Fatalf("ratings = %+v, want the 3 rated players", before)
+	}
+	for i, w := range want {
+		r := before[i]
+		if r.PlayerID != w.playerID || r.Rating != w.rating || r.TournamentsPlayed != 1 || r.Rank == nil || *r.Rank != i+1 {
+			t.Errorf("rating %d = %+v, want player %d on %v", i+1, r, w.playerID, w.rating)
+		}
+	}
+	if page := ratings("?limit=1&offset=1"); len(page) != 1 || page[0].PlayerID != gus.ID {
+		t.Errorf("second page = %+v", page)
+	}
+	for _, query := range []string{"?limit=0", "?limit=501", "?offset=-1"} {
+		a.do(t, http.MethodGet, "/ratings"+query, nil, http.StatusBadRequest, nil)
+	}
+
+	// Recomputing rates the same tournament again and gets the same result.
+	var recomputed dtos.RecomputeRatingsResponse
+	a.do(t, http.MethodPost, "/admin/ratings/recompute", nil, http.StatusOK, &recomputed)
+	if recomputed.Tournaments != 1 || recomputed.Players != 3 {
+		t.Errorf("recomputed = %+v, want 1 tournament and 3 players", recomputed)
+	}
+	after := ratings("")
+	if !slices.EqualFunc(before, after, func(a, b models.PlayerRating) bool {
+		return a.PlayerID == b.PlayerID && a.Rating == b.Rating && a.TournamentsPlayed == b.TournamentsPlayed
+	}) {
+		t.Errorf("ratings after recomputing = %+v, want %+v", after, before)
+	}
+
+	a.do(t, http.MethodGet, fmt.Sprintf("/players/%d/rating", unknownID), nil, http.StatusNotFound, nil)
+}
+
+func TestSeasons(t *testing.T) {
+	a := newAPI(t)
+
+	now := a.clock.Now()
+	var spring, autumn dtos.SeasonResponse
+	a.do(t, http.MethodPost, "/seasons", dtos.CreateSeasonRequest{
+		Name:      "Spring",
+		StartDate: now.Add(-time.Hour),
+		EndDate:   now.Add(48 * time.Hour),
+		PrizePool: 1000,
+	}, http.StatusCreated, &spring)
+	a.do(t, http.MethodPost, "/seasons", dtos.CreateSeasonRequest{
+		Name:        "Autumn",
+		StartDate:   now.AddDate(0, 6, 0),
+		EndDate:     now.AddDate(0, 9, 0),
+		PointsTable: []dtos.SeasonPointsRequest{{Placement: 1, Points: 5}},
+	}, http.StatusCreated, &autumn)
+
+	var seasons []dtos.SeasonResponse
+	a.do(t, http.MethodGet, "/seasons", nil, http.StatusOK, &seasons)
+	if len(seasons) != 2 || seasons[0].ID != autumn.ID || seasons[1].ID != spring.ID {
+		t.Fatalf("seasons = %+v, want autumn then spring", seasons)
+	}
+
+	var season dtos.SeasonResponse
+	a.do(t, http.MethodGet, fmt.Sprintf("/seasons/%d", spring.ID), nil, http.StatusOK, &season)
+	if season.Name != "Spring" || len(season.PointsTable) != len(models.DefaultSeasonPoints) || season.PointsTable[0].Points != 10 {
+		t.Errorf("spring = %+v, want the default points table", season)
+	}
+	a.do(t, http.MethodGet, fmt.Sprintf("/seasons/%d", unknownID), nil, http.StatusNotFound, nil)
+	a.do(t, http.MethodGet, "/seasons/abc", nil, http.StatusBadRequest, nil)
+	a.do(t, http.MethodPost, "/seasons", dtos.CreateSeasonRequest{Name: "Backwards", StartDate: now, EndDate: now}, http.StatusBadRequest, nil)
+
+	ida := a.createPlayer(t, "ida", 1000)
+	jon := a.createPlayer(t, "jon", 1000)
+	kim := a.createPlayer(t, "kim", 1000)
+
+	// Jon wins one tournament and comes second in the other, ida wins one
+	// and comes third, kim comes second and third.
+	placings := [][]dtos.PlayerResponse{{ida, jon, kim}, {jon, kim, ida}}
+	for i, placing := range placings {
+		req := a.tournamentRequest(fmt.Sprintf("Spring Leg %d", i+1), 100)
+		req.SeasonID = &spring.ID
+		tr := a.postTournament(t, req)
+		for j, p := range placing {
+			a.placeBet(t, p.ID, tr.ID, float64(300-100*j))
+		}
+		a.do(t, http.MethodPost, fmt.Sprintf("/tournaments/prizes/%d", tr.ID), nil, http.StatusAccepted, nil)
+	}
+
+	standingsPath := fmt.Sprintf("/seasons/%d/standings", spring.ID)
+	var standings dtos.SeasonStandingsResponse
+	a.do(t, http.MethodGet, standingsPath, nil, http.StatusOK, &standings)
+	want := []dtos.SeasonStandingResponse{
+		{Placement: 1, PlayerID: jon.ID, Points: 16, Wins: 1, TournamentsPlaced: 2, Prize: 500},
+		{Placement: 2, PlayerID: ida.ID, Points: 14, Wins: 1, TournamentsPlaced: 2, Prize: 300},
+		{Placement: 3, PlayerID: kim.ID, Points: 10, Wins: 0, TournamentsPlaced: 2, Prize: 200},
+	}
+	checkStandings := func(standings dtos.SeasonStandingsResponse, distributed bool) {
+		t.Helper()
+		if standings.SeasonID != spring.ID || standings.PrizePool != 1000 || standings.PrizesDistributed != distributed || len(standings.Standings) != len(want) {
+			t.Fatalf("standings = %+v", standings)
+		}
+		for i, w := range want {
+			got := standings.Standings[i]
+			if got.Placement != w.Placement || got.PlayerID != w.PlayerID || got.Points != w.Points ||
+				got.Wins != w.Wins || got.TournamentsPlaced != w.TournamentsPlaced || got.Prize != w.Prize {
+				t.Errorf("standing %d = %+v, want %+v", i+1, got, w)
+			}
+		}
+	}
+	checkStandings(standings, false)
+
+	prizesPath := fmt.Sprintf("/seasons/%d/prizes", spring.ID)
+	a.do(t, http.MethodPost, prizesPath, nil, http.StatusConflict, nil)
+
+	balances := a.balances(t)
+	a.clock.Set(spring.EndDate)
+	a.do(t, http.MethodPost, prizesPath, nil, http.StatusAccepted, nil)
+	for _, w := range want {
+		a.wantBalance(t, w.PlayerID, balances[w.PlayerID]+w.Prize)
+	}
+
+	// Settled seasons report what was paid and cannot be paid or rescored
+	// again.
+	a.do(t, http.MethodGet, standingsPath, nil, http.StatusOK, &standings)
+	checkStandings(standings, true)
+	a.do(t, http.MethodPost, prizesPath, nil, http.StatusConflict, nil)
+	a.do(t, http.MethodPut, fmt.Sprintf("/seasons/%d/points", spring.ID),
+		dtos.SetSeasonPointsRequest{PointsTable: []dtos.SeasonPointsRequest{{Placement: 1, Points: 1}}}, http.StatusConflict, nil)
+	for _, w := range want {
+		a.wantBalance(t, w.PlayerID, balances[w.PlayerID]+w.Prize)
+	}
+
+	// Changing the points table rescores the tournaments already played.
+	var rescored dtos.SeasonResponse
+	a.do(t, http.MethodPut, fmt.Sprintf("/seasons/%d/points", autumn.ID),
+		dtos.SetSeasonPointsRequest{PointsTable: []dtos.SeasonPointsRequest{{Placement: 1, Points: 3}, {Placement: 2, Points: 2}}}, http.StatusOK, &rescored)
+	if len(rescored.PointsTable) != 2 || rescored.PointsTable[1] != (dtos.SeasonPointsRequest{Placement: 2, Points: 2}) {
+		t.Errorf("autumn points table = %+v", rescored.PointsTable)
+	}
+
+	a.clock.Set(autumn.EndDate)
+	a.do(t, http.MethodPost, fmt.Sprintf("/seasons/%d/prizes", autumn.ID), nil, http.StatusBadRequest, nil)
+	a.do(t, http.MethodPost, fmt.Sprintf("/seasons/%d/prizes", unknownID), nil, http.StatusNotFound, nil)
+	a.do(t, http.MethodGet, fmt.Sprintf("/seasons/%d/standings", unknownID), nil, http.StatusNotFound, nil)
+	a.do(t, http.MethodPut, fmt.Sprintf("/seasons/%d/points", unknownID),
+		dtos.SetSeasonPointsRequest{PointsTable: []dtos.SeasonPointsRequest{{Placement: 1, Points: 1}}}, http.StatusNotFound, nil)
+}
+
+func TestSeasonPointsTable(t *testing.T) {
+	a := newAPI(t)
+
+	now := a.clock.Now()
+	season := dtos.CreateSeasonRequest{Name: "Points Season", StartDate: now, EndDate: now.Add(30 * 24 * time.Hour)}
+	var created dtos.SeasonResponse
+	a.do(t, http.MethodPost, "/seasons", season, http.StatusCreated, &created)
+
+	invalid := map[string][]dtos.SeasonPointsRequest{
+		"empty":               {},
+		"placement zero":      {{Placement: 0, Points: 5}},
+		"negative points":     {{Placement: 1, Points: -1}},
+		"duplicate placement": {{Placement: 1, Points: 10}, {Placement: 1, Points: 8}},
+		// Only the placements paid a prize earn points.
+		"beyond prize tiers": {{Placement: 1, Points: 10}, {Placement: 4, Points: 2}},
+	}
+	for name, points := range invalid {
+		t.Run(name, func(t *testing.T) {
+			req := season
+			req.PointsTable = points
+			if len(points) > 0 {
+				a.do(t, http.MethodPost, "/seasons", req, http.StatusBadRequest, nil)
+			}
+			a.do(t, http.MethodPut, fmt.Sprintf("/seasons/%d/points", created.ID),
+				dtos.SetSeasonPointsRequest{PointsTable: points}, http.StatusBadRequest, nil)
+		})
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	a := newAPI(t)
+
+	mia := a.createPlayer(t, "mia", 500)
+	noah := a.createPlayer(t, "noah", 500)
+	tournament := a.createTournament(t, "Metered Cup", 300)
+
+	a.placeBet(t, mia.ID, tournament.ID, 50)
+	a.placeBet(t, noah.ID, tournament.ID, 25.5)
+	a.placeBet(t, mia.ID, tournament.ID, 10)
+
+	betSamples := map[string]float64{
+		fmt.Sprintf(`igaming_bets_placed_total{tournament_id="%d"}`, tournament.ID):        3,
+		fmt.Sprintf(`igaming_bet_amount_wagered_total{tournament_id="%d"}`, tournament.ID): 85.5,
+	}
+	samples := a.metrics(t)
+	for name, v := range betSamples {
+		if got := samples[name]; math.Abs(got-v) > 0.001 {
+			t.Errorf("%s = %v, want %v", name, got, v)
+		}
+	}
+
+	prizesPath := fmt.Sprintf("/tournaments/prizes/%d", tournament.ID)
+	a.do(t, http.MethodPost, prizesPath, nil, http.StatusAccepted, nil)
+	// Refused by the rules, so not a failed distribution.
+	a.do(t, http.MethodPost, prizesPath, nil, http.StatusConflict, nil)
+
+	var liability float64
+	for _, balance := range a.balances(t) {
+		liability += balance
+	}
+
+	samples = a.metrics(t)
+	want := map[string]float64{
+		`igaming_prize_distributions_total{result="succeeded",scope="tournament"}`:                 1,
+		`igaming_http_requests_total{method="POST",route="/bets",status="201"}`:                    3,
+		`igaming_http_requests_total{method="POST",route="/tournaments/prizes/{id}",status="409"}`: 1,
+		`igaming_http_request_duration_seconds_count{method="POST",route="/bets"}`:                 3,
+		`igaming_balance_liability`: liability,
+	}
+	for name, v := range want {
+		got, ok := samples[name]
+		if !ok {
+			t.Errorf("no %s sample", name)
+		} else if math.Abs(got-v) > 0.001 {
+			t.Errorf("%s = %v, want %v", name, got, v)
+		}
+	}
+
+	if _, ok := samples[`igaming_prize_distributions_total{result="failed",scope="tournament"}`]; ok {
+		t.Error("refused distribution counted as failed")
+	}
+	// A settled tournament takes no more bets, so its series are dropped.
+	for name := range betSamples {
+		if _, ok := samples[name]; ok {
+			t.Errorf("%s still served after the tournament was settled", name)
+		}
+	}
+	for name := range samples {
+		if strings.Contains(name, fmt.Sprintf("/tournaments/prizes/%d", tournament.ID)) {
+			t.Errorf("sample %s is labelled with the path instead of the route", name)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"errors"
+	"igaming/internal/config"
+	"net"
+	"net/http"
+)
+
+// NewHTTPServer builds the HTTP server from its configuration.
+func NewHTTPServer(cfg config.ServerConfig, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              net.JoinHostPort("", cfg.Port),
+		Handler:           handler,
+		ReadTimeout:       cfg.ReadTimeout,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+	}
+}
+
+// ListenAndServe serves HTTP, or HTTPS when TLS is configured, until the
+// server is shut down. Unlike http.Server it returns nil after Shutdown.
+func ListenAndServe(srv *http.Server, cfg config.ServerConfig) error {
+	var err error
+	if cfg.TLS() {
+		err = srv.ListenAndServeTLS(cfg.TLSCertFile, cfg.TLSKeyFile)
+	} else {
+		err = srv.ListenAndServe()
+	}
+
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"igaming/internal/clock"
+	"igaming/internal/events"
+	"igaming/internal/metrics"
+	"igaming/internal/models"
+	"igaming/internal/ranking"
+	"igaming/internal/repository"
+	"igaming/internal/tracing"
+	"math"
+	"time"
+)
+
+// BettingService places bets.
+type BettingService struct {
+	store     *repository.Store
+	publisher *events.TournamentPublisher
+	rankings  *ranking.Service
+	metrics   *metrics.Metrics
+	clock     clock.Clock
+}
+
+func NewBettingService(store *repository.Store, publisher *events.TournamentPublisher, rankings *ranking.Service, m *metrics.Metrics, clk clock.Clock) *BettingService {
+	return &BettingService{store: store, publisher: publisher, rankings: rankings, metrics: m, clock: clk}
+}
+
+// PlaceBet checks the bet against the tournament's rules and the player's
+// limits, takes the stake from the player's balance, grows an accumulating
+// prize pool and records the bet, all in one unit of work. The tournament is
+// locked before the player, the same order settlement locks them in.
+func (s *BettingService) PlaceBet(ctx context.Context, bet *models.TournamentBet) (err error) {
+	ctx, span := tracing.Start(ctx, "BettingService.PlaceBet")
+	defer func() { tracing.End(span, err) }()
+
+	var prizePool float64
+	err = s.store.UnitOfWork.Do(ctx, func(tx *repository.Store) error {
+		t, err := tx.Tournaments.GetForUpdate(ctx, bet.TournamentID)
+		if err != nil {
+			return err
+		}
+
+		player, err := tx.Players.GetForUpdate(ctx, bet.PlayerID)
+		if err != nil {
+			return err
+		}
+
+		betCount, stake, err := tx.Bets.GetPlayerStake(ctx, bet.TournamentID, bet.PlayerID)
+		if err != nil {
+			return err
+		}
+
+		if t.PrizesDistributed {
+			return fmt.Errorf("%w: prizes have already been distributed", repository.ErrBettingWindowClosed)
+		}
+
+		if err := tx.Exclusions.CheckNotExcluded(ctx, bet.PlayerID); err != nil {
+			return err
+		}
+
+		now := s.clock.Now()
+
+		if err := checkBettingWindow(t, betCount == 0, now); err != nil {
+			return err
+		}
+
+		participants := func() (int, error) {
+			return tx.Bets.CountParticipants(ctx, bet.TournamentID)
+		}
+		if err := checkBetLimits(t, bet, betCount, stake, participants); err != nil {
+			return err
+		}
+
+		limits, err := tx.Limits.GetLimits(ctx, bet.PlayerID)
+		if err != nil {
+			return err
+		}
+		for _, l := range limits {
+			if err := checkPlayerLimit(l, l.Used, bet.BetAmount); err != nil {
+				return err
+			}
+		}
+
+		if player.AccountBalance < bet.BetAmount {
+			return fmt.Errorf("%w: player has %.2f, needs %.2f",
+				repository.ErrInsufficientFunds, player.AccountBalance, bet.BetAmount)
+		}
+
+		if err := tx.Players.AdjustBalance(ctx, bet.PlayerID, -bet.BetAmount); err != nil {
+			return err
+		}
+
+		bet.RakeAmount = 0
+		prizePool = t.PrizePool
+		if t.PoolMode == models.PoolModeAccumulating {
+			bet.RakeAmount = math.Round(bet.BetAmount*t.RakePercentage) / 100
+			if err := tx.Tournaments.AddToPrizePool(ctx, t.ID, bet.BetAmount-bet.RakeAmount, bet.RakeAmount); err != nil {
+				return err
+			}
+			prizePool = math.Round((t.PrizePool+bet.BetAmount-bet.RakeAmount)*100) / 100
+		}
+
+		bet.CreatedAt = now
+		return tx.Bets.Create(ctx, bet)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.metrics.BetPlaced(bet.TournamentID, bet.BetAmount)
+	s.rankings.BetPlaced(bet)
+	s.publisher.BetPlaced(bet, prizePool)
+	return nil
+}
+
+// List returns every bet.
+func (s *BettingService) List(ctx context.Context) (_ []models.TournamentBet, err error) {
+	ctx, span := tracing.Start(ctx, "BettingService.List")
+	defer func() { tracing.End(span, err) }()
+
+	return s.store.Bets.GetAll(ctx)
+}
+
+// checkBettingWindow rejects bets placed before betting opens, after it
+// closes, or by new participants once late registration is over.
+func checkBettingWindow(t *models.Tournament, newParticipant bool, now time.Time) error {
+	if t.BettingOpensAt != nil && now.Before(*t.BettingOpensAt) {
+		return fmt.Errorf("%w: betting opens at %s",
+			repository.ErrBettingWindowClosed, t.BettingOpensAt.Format(time.RFC3339))
+	}
+
+	if closes := t.BettingCloseTime(); !now.Before(closes) {
+		return fmt.Errorf("%w: betting closed at %s",
+			repository.ErrBettingWindowClosed, closes.Format(time.RFC3339))
+	}
+
+	if closes := t.RegistrationCloseTime(); newParticipant && closes != nil && !now.Before(*closes) {
+		return fmt.Errorf("%w: registration for new players closed at %s",
+			repository.ErrBettingWindowClosed, closes.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// checkBetLimits enforces the per-tournament bet rules given the player's
+// existing bet count and stake. participants is only called when the
+// participant limit applies.
+func checkBetLimits(t *models.Tournament, bet *models.TournamentBet, betCount int, stake float64, participants func() (int, error)) error {
+	if t.EntryFee != nil {
+		if betCount > 0 {
+			return fmt.Errorf("%w: player %d is already entered in tournament %d",
+				repository.ErrAlreadyEntered, bet.PlayerID, bet.TournamentID)
+		}
+		if bet.BetAmount != *t.EntryFee {
+			return fmt.Errorf("%w: entry fee is %.2f", repository.ErrEntryFeeMismatch, *t.EntryFee)
+		}
+	}
+
+	if t.MinBet != nil && bet.BetAmount < *t.MinBet {
+		return fmt.Errorf("%w: minimum bet is %.2f", repository.ErrBetBelowMinimum, *t.MinBet)
+	}
+
+	if t.MaxBet != nil && bet.BetAmount > *t.MaxBet {
+		return fmt.Errorf("%w: maximum bet is %.2f", repository.ErrBetAboveMaximum, *t.MaxBet)
+	}
+
+	if t.MaxStakePerPlayer != nil && stake+bet.BetAmount > *t.MaxStakePerPlayer {
+		return fmt.Errorf("%w: player has staked %.2f of %.2f",
+			repository.ErrStakeLimitExceeded, stake, *t.MaxStakePerPlayer)
+	}
+
+	if t.MaxParticipants != nil && betCount == 0 {
+		n, err := participants()
+		if err != nil {
+			return fmt.Errorf("failed to count participants: %w", err)
+		}
+		if n >= *t.MaxParticipants {
+			return fmt.Errorf("%w: tournament allows %d participants",
+				repository.ErrParticipantLimitReached, *t.MaxParticipants)
+		}
+	}
+
+	return nil
+}
+
+// checkPlayerLimit rejects a bet of amount when it would take the player over
+// the limit, given how much of it is already used.
+func checkPlayerLimit(l models.PlayerLimit, used, amount float64) error {
+	if l.Amount == nil || used+amount <= *l.Amount {
+		return nil
+	}
+	return fmt.Errorf("%w: %s %s limit is %.2f, %.2f already used",
+		repository.ErrLimitExceeded, l.Period, l.Type, *l.Amount, used)
+}
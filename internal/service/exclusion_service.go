@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"igaming/internal/clock"
+	"igaming/internal/models"
+	"igaming/internal/ranking"
+	"igaming/internal/repository"
+	"igaming/internal/tracing"
+)
+
+// ExclusionService self-excludes players from betting.
+type ExclusionService struct {
+	exclusions repository.PlayerExclusionRepository
+	rankings   *ranking.Service
+	clock      clock.Clock
+}
+
+func NewExclusionService(exclusions repository.PlayerExclusionRepository, rankings *ranking.Service, clk clock.Clock) *ExclusionService {
+	return &ExclusionService{exclusions: exclusions, rankings: rankings, clock: clk}
+}
+
+// Create starts a self-exclusion of the player from now and takes them off
+// the rankings while it is in force. Exclusions cannot be lifted early.
+func (s *ExclusionService) Create(ctx context.Context, exclusion *models.PlayerExclusion) (err error) {
+	ctx, span := tracing.Start(ctx, "ExclusionService.Create")
+	defer func() { tracing.End(span, err) }()
+
+	if _, ok := models.ExclusionEnd(exclusion.Duration, s.clock.Now()); !ok {
+		return invalid("Duration must be one of 24h, 7d, 6m or permanent")
+	}
+
+	if err := s.exclusions.Create(ctx, exclusion); err != nil {
+		return err
+	}
+
+	if exclusion.Active(s.clock.Now()) {
+		s.rankings.PlayerExcluded(exclusion.PlayerID)
+	}
+	return nil
+}
+
+// ListByPlayer returns every exclusion of the player, newest first.
+func (s *ExclusionService) ListByPlayer(ctx context.Context, playerID uint) (_ []models.PlayerExclusion, err error) {
+	ctx, span := tracing.Start(ctx, "ExclusionService.ListByPlayer")
+	defer func() { tracing.End(span, err) }()
+
+	return s.exclusions.GetByPlayer(ctx, playerID)
+}
+
+// ListActive returns every exclusion in force right now.
+func (s *ExclusionService) ListActive(ctx context.Context) (_ []models.PlayerExclusion, err error) {
+	ctx, span := tracing.Start(ctx, "ExclusionService.ListActive")
+	defer func() { tracing.End(span, err) }()
+
+	return s.exclusions.GetActive(ctx)
+}
+
+// Active reports whether the exclusion is in force right now.
+func (s *ExclusionService) Active(exclusion models.PlayerExclusion) bool {
+	return exclusion.Active(s.clock.Now())
+}
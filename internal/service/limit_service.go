@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"igaming/internal/models"
+	"igaming/internal/repository"
+	"igaming/internal/tracing"
+)
+
+// LimitService manages the players' responsible gambling limits.
+type LimitService struct {
+	limits repository.PlayerLimitRepository
+}
+
+func NewLimitService(limits repository.PlayerLimitRepository) *LimitService {
+	return &LimitService{limits: limits}
+}
+
+// Get returns the player's limits with their usage in the current period.
+func (s *LimitService) Get(ctx context.Context, playerID uint) (_ []models.PlayerLimit, err error) {
+	ctx, span := tracing.Start(ctx, "LimitService.Get")
+	defer func() { tracing.End(span, err) }()
+
+	return s.limits.GetLimits(ctx, playerID)
+}
+
+// Set validates the limit changes, applies them and returns the player's
+// limits. Lower limits apply immediately, higher or removed limits only
+// after the cooling-off period.
+func (s *LimitService) Set(ctx context.Context, playerID uint, changes []models.PlayerLimit) (_ []models.PlayerLimit, err error) {
+	ctx, span := tracing.Start(ctx, "LimitService.Set")
+	defer func() { tracing.End(span, err) }()
+
+	if msg := validateLimits(changes); msg != "" {
+		return nil, invalid(msg)
+	}
+
+	if err := s.limits.SetLimits(ctx, playerID, changes); err != nil {
+		return nil, err
+	}
+	return s.limits.GetLimits(ctx, playerID)
+}
+
+// validateLimits returns a message describing why the limit changes are
+// invalid, or an empty string when they can be applied.
+func validateLimits(changes []models.PlayerLimit) string {
+	if len(changes) == 0 {
+		return "At least one limit is required"
+	}
+
+	seen := make(map[string]bool)
+	for _, l := range changes {
+		if !models.ValidLimitType(l.Type) {
+			return "Limit type must be wager or loss"
+		}
+		if !models.ValidLimitPeriod(l.Period) {
+			return "Limit period must be daily, weekly or monthly"
+		}
+		if l.Amount != nil && *l.Amount <= 0 {
+			return "Limit amount must be positive"
+		}
+		key := l.Type + "/" + l.Period
+		if seen[key] {
+			return "Duplicate limit: " + l.Period + " " + l.Type
+		}
+		seen[key] = true
+	}
+	return ""
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"igaming/internal/models"
+	"igaming/internal/ranking"
+	"igaming/internal/repository"
+	"igaming/internal/tracing"
+)
+
+// PlayerService registers players.
+type PlayerService struct {
+	players  repository.PlayerRepository
+	rankings *ranking.Service
+}
+
+func NewPlayerService(players repository.PlayerRepository, rankings *ranking.Service) *PlayerService {
+	return &PlayerService{players: players, rankings: rankings}
+}
+
+// Create registers the player and adds them to the rankings.
+func (s *PlayerService) Create(ctx context.Context, player *models.Player) (err error) {
+	ctx, span := tracing.Start(ctx, "PlayerService.Create")
+	defer func() { tracing.End(span, err) }()
+
+	if player.Name == "" {
+		return invalid("Name is required")
+	}
+	if player.Email == "" {
+		return invalid("Email is required")
+	}
+	if player.AccountBalance < 0 {
+		return invalid("Account balance cannot be negative")
+	}
+
+	if err := s.players.Create(ctx, player); err != nil {
+		return err
+	}
+
+	s.rankings.PlayerCreated(player)
+	return nil
+}
+
+// List returns every player.
+func (s *PlayerService) List(ctx context.Context) (_ []models.Player, err error) {
+	ctx, span := tracing.Start(ctx, "PlayerService.List")
+	defer func() { tracing.End(span, err) }()
+
+	return s.players.GetAllPlayers(ctx)
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"igaming/internal/models"
+	"igaming/internal/repository"
+	"igaming/internal/tracing"
+)
+
+// RatingHistoryLimit is how many of a player's recent rating changes Get
+// returns.
+const RatingHistoryLimit = 20
+
+// RatingService serves the players' skill ratings.
+type RatingService struct {
+	ratings repository.RatingRepository
+}
+
+func NewRatingService(ratings repository.RatingRepository) *RatingService {
+	return &RatingService{ratings: ratings}
+}
+
+// Get returns the player's rating, the initial rating when they have not
+// played a rated tournament, with their most recent rating changes.
+func (s *RatingService) Get(ctx context.Context, playerID uint) (_ *models.PlayerRating, _ []models.RatingChange, err error) {
+	ctx, span := tracing.Start(ctx, "RatingService.Get")
+	defer func() { tracing.End(span, err) }()
+
+	rating, err := s.ratings.GetRating(ctx, playerID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	history, err := s.ratings.GetHistory(ctx, playerID, RatingHistoryLimit)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rating, history, nil
+}
+
+// Leaderboard returns a page of rated players by rating, best first,
+// together with the number of ranked players.
+func (s *RatingService) Leaderboard(ctx context.Context, offset, limit int) (_ []models.PlayerRating, _ int, err error) {
+	ctx, span := tracing.Start(ctx, "RatingService.Leaderboard")
+	defer func() { tracing.End(span, err) }()
+
+	return s.ratings.GetLeaderboard(ctx, offset, limit)
+}
+
+// Recompute throws away all ratings and rates every settled tournament
+// again, in settlement order. It returns the number of tournaments and
+// players rated.
+func (s *RatingService) Recompute(ctx context.Context) (tournaments, players int, err error) {
+	ctx, span := tracing.Start(ctx, "RatingService.Recompute")
+	defer func() { tracing.End(span, err) }()
+
+	return s.ratings.Recompute(ctx)
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"igaming/internal/leaderboard"
+	"igaming/internal/models"
+	"igaming/internal/repository"
+	"igaming/internal/tracing"
+	"strconv"
+)
+
+// SeasonService sets up seasons and their points tables.
+type SeasonService struct {
+	seasons repository.SeasonRepository
+}
+
+func NewSeasonService(seasons repository.SeasonRepository) *SeasonService {
+	return &SeasonService{seasons: seasons}
+}
+
+// SeasonStandings are the standings of a season with each player's prize.
+type SeasonStandings struct {
+	Season    *models.Season
+	Standings []models.SeasonStanding
+	// Prizes holds each player's season prize by player ID: what was paid
+	// once the season is settled, otherwise what settling it would pay now.
+	Prizes map[uint]float64
+}
+
+// Create validates the season and stores it. A season without a points
+// table gets the default one.
+func (s *SeasonService) Create(ctx context.Context, season *models.Season) (err error) {
+	ctx, span := tracing.Start(ctx, "SeasonService.Create")
+	defer func() { tracing.End(span, err) }()
+
+	if season.Name == "" {
+		return invalid("Name is required")
+	}
+
+	if !season.EndDate.After(season.StartDate) {
+		return invalid("End date must be after start date")
+	}
+
+	if season.PrizePool < 0 {
+		return invalid("Prize pool cannot be negative")
+	}
+
+	if season.PointsTable == nil {
+		season.PointsTable = models.DefaultSeasonPoints
+	} else if msg := validatePointsTable(season.PointsTable); msg != "" {
+		return invalid(msg)
+	}
+
+	return s.seasons.Create(ctx, season)
+}
+
+// List returns every season without its points table, latest first.
+func (s *SeasonService) List(ctx context.Context) (_ []models.Season, err error) {
+	ctx, span := tracing.Start(ctx, "SeasonService.List")
+	defer func() { tracing.End(span, err) }()
+
+	return s.seasons.GetAll(ctx)
+}
+
+// Get returns the season with its points table.
+func (s *SeasonService) Get(ctx context.Context, seasonID uint) (_ *models.Season, err error) {
+	ctx, span := tracing.Start(ctx, "SeasonService.Get")
+	defer func() { tracing.End(span, err) }()
+
+	return s.seasons.GetByID(ctx, seasonID)
+}
+
+// SetPoints validates and replaces the season's points table and returns
+// the season. Standings follow the new table, including tournaments already
+// played. The table cannot change once the season prizes have been paid.
+func (s *SeasonService) SetPoints(ctx context.Context, seasonID uint, points []models.SeasonPoints) (_ *models.Season, err error) {
+	ctx, span := tracing.Start(ctx, "SeasonService.SetPoints")
+	defer func() { tracing.End(span, err) }()
+
+	if msg := validatePointsTable(points); msg != "" {
+		return nil, invalid(msg)
+	}
+
+	if err := s.seasons.SetPoints(ctx, seasonID, points); err != nil {
+		return nil, err
+	}
+	return s.seasons.GetByID(ctx, seasonID)
+}
+
+// Standings returns the season standings ordered by placement, with the
+// prize each player was paid or would be paid now.
+func (s *SeasonService) Standings(ctx context.Context, seasonID uint) (_ *SeasonStandings, err error) {
+	ctx, span := tracing.Start(ctx, "SeasonService.Standings")
+	defer func() { tracing.End(span, err) }()
+
+	season, err := s.seasons.GetByID(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	standings, err := s.seasons.GetStandings(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	prizes := make(map[uint]float64)
+	if season.PrizesDistributed {
+		results, err := s.seasons.GetResults(ctx, seasonID)
+		if err != nil {
+			return nil, err
+		}
+		for _, res := range results {
+			prizes[res.PlayerID] = res.PrizeAmount
+		}
+	} else {
+		for _, p := range SeasonPrizes(standings, season.PrizePool) {
+			prizes[p.PlayerID] = p.Prize
+		}
+	}
+
+	return &SeasonStandings{Season: season, Standings: standings, Prizes: prizes}, nil
+}
+
+// validatePointsTable returns a message describing why the points table is
+// invalid, or an empty string when it can be used. Only the placements paid
+// a prize are kept in tournament_results, so no other placement can earn
+// points.
+func validatePointsTable(points []models.SeasonPoints) string {
+	if len(points) == 0 {
+		return "Points table cannot be empty"
+	}
+
+	seen := make(map[int]bool, len(points))
+	for _, p := range points {
+		if p.Placement < 1 {
+			return "Placement must be at least 1"
+		}
+		if p.Placement > len(leaderboard.PrizeTiers) {
+			return "Placement cannot exceed " + strconv.Itoa(len(leaderboard.PrizeTiers)) + ", the number of prize tiers"
+		}
+		if p.Points < 0 {
+			return "Points cannot be negative"
+		}
+		if seen[p.Placement] {
+			return "Placement " + strconv.Itoa(p.Placement) + " is listed more than once"
+		}
+		seen[p.Placement] = true
+	}
+	return ""
+}
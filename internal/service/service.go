@@ -0,0 +1,20 @@
+// Package service owns the business rules of the platform. Each operation
+// validates its input, runs in one unit of work so every repository it
+// touches commits or rolls back together, and only then updates the cached
+// rankings and the live streams. Handlers translate between HTTP and the
+// services and hold no rules of their own.
+package service
+
+// ValidationError is returned for input the rules reject before anything is
+// stored. Its message is meant for the client.
+type ValidationError struct {
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+func invalid(message string) error {
+	return &ValidationError{Message: message}
+}
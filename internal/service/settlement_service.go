@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"igaming/internal/clock"
+	"igaming/internal/events"
+	"igaming/internal/leaderboard"
+	"igaming/internal/logging"
+	"igaming/internal/metrics"
+	"igaming/internal/models"
+	"igaming/internal/ranking"
+	"igaming/internal/repository"
+	"igaming/internal/tracing"
+)
+
+// SettlementService pays out tournament and season prizes.
+type SettlementService struct {
+	store     *repository.Store
+	publisher *events.TournamentPublisher
+	rankings  *ranking.Service
+	metrics   *metrics.Metrics
+	clock     clock.Clock
+}
+
+func NewSettlementService(store *repository.Store, publisher *events.TournamentPublisher, rankings *ranking.Service, m *metrics.Metrics, clk clock.Clock) *SettlementService {
+	return &SettlementService{store: store, publisher: publisher, rankings: rankings, metrics: m, clock: clk}
+}
+
+// settlementRejections are the errors of settlements the rules turn away.
+// They are not distributions that failed, so they are not counted as such.
+var settlementRejections = []error{
+	repository.ErrTournamentNotFound,
+	repository.ErrSeasonNotFound,
+	repository.ErrPrizesAlreadyDistributed,
+	repository.ErrNoBets,
+	repository.ErrSeasonNotEnded,
+	repository.ErrNoSeasonPoints,
+}
+
+// recordDistribution counts a settlement of scope that ended with err,
+// unless the rules rejected it.
+func (s *SettlementService) recordDistribution(scope string, err error) {
+	for _, rejection := range settlementRejections {
+		if errors.Is(err, rejection) {
+			return
+		}
+	}
+	s.metrics.PrizeDistribution(scope, err)
+}
+
+// SettleTournament pays the prize pool out to the top three placements by
+// total bet. Players with equal totals share a placement and split the tiers
+// their group covers, exactly as the leaderboard projects. The results are
+// recorded, the winners credited and the tournament closed in one unit of
+// work.
+func (s *SettlementService) SettleTournament(ctx context.Context, tournamentID uint) (_ []models.TournamentResult, err error) {
+	ctx, span := tracing.Start(ctx, "SettlementService.SettleTournament")
+	defer func() { tracing.End(span, err) }()
+
+	var results []models.TournamentResult
+	err = s.store.UnitOfWork.Do(ctx, func(tx *repository.Store) error {
+		t, err := tx.Tournaments.GetForUpdate(ctx, tournamentID)
+		if err != nil {
+			return err
+		}
+		if t.PrizesDistributed {
+			return fmt.Errorf("tournament %d: %w", tournamentID, repository.ErrPrizesAlreadyDistributed)
+		}
+
+		standings, err := tx.Tournaments.GetStandings(ctx, tournamentID)
+		if err != nil {
+			return err
+		}
+		if len(standings) == 0 {
+			return fmt.Errorf("tournament %d: %w", tournamentID, repository.ErrNoBets)
+		}
+
+		now := s.clock.Now()
+		results = nil
+		for _, e := range leaderboard.Build(standings, t.PrizePool) {
+			if e.Placement <= len(leaderboard.PrizeTiers) {
+				results = append(results, models.TournamentResult{
+					TournamentID: tournamentID,
+					PlayerID:     e.PlayerID,
+					Placement:    e.Placement,
+					PrizeAmount:  e.ProjectedPrize,
+					CreatedAt:    now,
+				})
+			}
+		}
+
+		if err := tx.Tournaments.CreateResults(ctx, results); err != nil {
+			return err
+		}
+		for _, res := range results {
+			if err := credit(ctx, tx, res.PlayerID, res.PrizeAmount); err != nil {
+				return err
+			}
+		}
+		return tx.Tournaments.MarkPrizesDistributed(ctx, tournamentID)
+	})
+	s.recordDistribution(metrics.ScopeTournament, err)
+	if err != nil {
+		return nil, err
+	}
+
+	s.metrics.TournamentSettled(tournamentID)
+	s.rankings.PrizesPaid(results)
+
+	// Tournaments left unrated here are picked up by the rating job.
+	if _, err := s.store.Ratings.ApplyPending(context.WithoutCancel(ctx)); err != nil {
+		logging.FromContext(ctx).Error("failed to rate tournament", "tournament_id", tournamentID, "error", err)
+	}
+
+	s.publisher.PrizesDistributed(context.WithoutCancel(ctx), tournamentID)
+	return results, nil
+}
+
+// SettleSeason pays the season prize pool out to the top three placements
+// of the players who scored, with the same tier split and tie sharing as
+// tournament prizes. A season can only be settled once it has ended.
+func (s *SettlementService) SettleSeason(ctx context.Context, seasonID uint) (_ []models.SeasonResult, err error) {
+	ctx, span := tracing.Start(ctx, "SettlementService.SettleSeason")
+	defer func() { tracing.End(span, err) }()
+
+	var results []models.SeasonResult
+	err = s.store.UnitOfWork.Do(ctx, func(tx *repository.Store) error {
+		season, err := tx.Seasons.GetForUpdate(ctx, seasonID)
+		if err != nil {
+			return err
+		}
+		if season.PrizesDistributed {
+			return fmt.Errorf("season %d: %w", seasonID, repository.ErrPrizesAlreadyDistributed)
+		}
+
+		now := s.clock.Now()
+		if season.EndDate.After(now) {
+			return fmt.Errorf("season %d: %w", seasonID, repository.ErrSeasonNotEnded)
+		}
+
+		standings, err := tx.Seasons.GetStandings(ctx, seasonID)
+		if err != nil {
+			return err
+		}
+
+		results = nil
+		for _, st := range SeasonPrizes(standings, season.PrizePool) {
+			results = append(results, models.SeasonResult{
+				SeasonID:    seasonID,
+				PlayerID:    st.PlayerID,
+				Placement:   st.Placement,
+				PrizeAmount: st.Prize,
+				CreatedAt:   now,
+			})
+		}
+		if len(results) == 0 {
+			return fmt.Errorf("season %d: %w", seasonID, repository.ErrNoSeasonPoints)
+		}
+
+		if err := tx.Seasons.CreateResults(ctx, results); err != nil {
+			return err
+		}
+		for _, res := range results {
+			if err := credit(ctx, tx, res.PlayerID, res.PrizeAmount); err != nil {
+				return err
+			}
+		}
+		return tx.Seasons.MarkPrizesDistributed(ctx, seasonID)
+	})
+	s.recordDistribution(metrics.ScopeSeason, err)
+	if err != nil {
+		return nil, err
+	}
+
+	s.rankings.SeasonPrizesPaid(results)
+	return results, nil
+}
+
+// SeasonPrize is a player's share of the season prize pool.
+type SeasonPrize struct {
+	PlayerID  uint
+	Placement int
+	Prize     float64
+}
+
+// SeasonPrizes returns what settling the season would pay with standings
+// ordered by placement. Only players who scored take part, and only the top
+// three placements among them are paid.
+func SeasonPrizes(standings []models.SeasonStanding, prizePool float64) []SeasonPrize {
+	groups := make(map[int]int)
+	for _, st := range standings {
+		if st.Points > 0 {
+			groups[st.Placement]++
+		}
+	}
+
+	var prizes []SeasonPrize
+	for _, st := range standings {
+		if st.Points > 0 && st.Placement <= len(leaderboard.PrizeTiers) {
+			prizes = append(prizes, SeasonPrize{
+				PlayerID:  st.PlayerID,
+				Placement: st.Placement,
+				Prize:     leaderboard.Prize(st.Placement, groups[st.Placement], prizePool),
+			})
+		}
+	}
+	return prizes
+}
+
+// credit adds a prize to the player's balance, locking the player first.
+func credit(ctx context.Context, tx *repository.Store, playerID uint, amount float64) error {
+	if amount == 0 {
+		return nil
+	}
+	if _, err := tx.Players.GetForUpdate(ctx, playerID); err != nil {
+		return err
+	}
+	return tx.Players.AdjustBalance(ctx, playerID, amount)
+}
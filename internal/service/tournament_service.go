@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"igaming/internal/models"
+	"igaming/internal/repository"
+	"igaming/internal/tracing"
+)
+
+// TournamentService sets up tournaments.
+type TournamentService struct {
+	tournaments repository.TournamentRepository
+}
+
+func NewTournamentService(tournaments repository.TournamentRepository) *TournamentService {
+	return &TournamentService{tournaments: tournaments}
+}
+
+// Create validates the tournament and stores it. The pool mode defaults to
+// fixed. A fixed pool guarantees its prize pool; an accumulating pool starts
+// at the guaranteed amount and grows with every bet.
+func (s *TournamentService) Create(ctx context.Context, t *models.Tournament) (err error) {
+	ctx, span := tracing.Start(ctx, "TournamentService.Create")
+	defer func() { tracing.End(span, err) }()
+
+	if t.Name == "" {
+		return invalid("Name is required")
+	}
+
+	if t.PoolMode == "" {
+		t.PoolMode = models.PoolModeFixed
+	}
+
+	if msg := validatePrizePool(t); msg != "" {
+		return invalid(msg)
+	}
+
+	if t.EndDate.Before(t.StartDate) {
+		return invalid("End date must be after start date")
+	}
+
+	if msg := validateBetLimits(t); msg != "" {
+		return invalid(msg)
+	}
+
+	if msg := validateBettingWindow(t); msg != "" {
+		return invalid(msg)
+	}
+
+	switch t.PoolMode {
+	case models.PoolModeFixed:
+		t.GuaranteedPrizePool = t.PrizePool
+	case models.PoolModeAccumulating:
+		t.PrizePool = t.GuaranteedPrizePool
+	}
+
+	return s.tournaments.Create(ctx, t)
+}
+
+// List returns every tournament.
+func (s *TournamentService) List(ctx context.Context) (_ []models.Tournament, err error) {
+	ctx, span := tracing.Start(ctx, "TournamentService.List")
+	defer func() { tracing.End(span, err) }()
+
+	return s.tournaments.GetAllTournaments(ctx)
+}
+
+// validatePrizePool returns a message describing why the prize pool
+// configuration is invalid, or an empty string when it is consistent.
+func validatePrizePool(t *models.Tournament) string {
+	switch t.PoolMode {
+	case models.PoolModeFixed:
+		if t.PrizePool <= 0 {
+			return "Prize pool must be positive"
+		}
+		if t.RakePercentage != 0 {
+			return "Rake is only supported for accumulating prize pools"
+		}
+	case models.PoolModeAccumulating:
+		if t.GuaranteedPrizePool < 0 {
+			return "Guaranteed prize pool cannot be negative"
+		}
+		if t.RakePercentage < 0 || t.RakePercentage > 100 {
+			return "Rake percentage must be between 0 and 100"
+		}
+	default:
+		return "Pool mode must be either fixed or accumulating"
+	}
+
+	return ""
+}
+
+// validateBetLimits returns a message describing the first invalid bet
+// limit, or an empty string when the limits are consistent.
+func validateBetLimits(t *models.Tournament) string {
+	amounts := []struct {
+		name  string
+		value *float64
+	}{
+		{"Minimum bet", t.MinBet},
+		{"Maximum bet", t.MaxBet},
+		{"Maximum stake per player", t.MaxStakePerPlayer},
+		{"Entry fee", t.EntryFee},
+	}
+	for _, a := range amounts {
+		if a.value != nil && *a.value <= 0 {
+			return a.name + " must be positive"
+		}
+	}
+
+	if t.MaxParticipants != nil && *t.MaxParticipants <= 0 {
+		return "Maximum participants must be positive"
+	}
+
+	if t.MinBet != nil && t.MaxBet != nil && *t.MinBet > *t.MaxBet {
+		return "Minimum bet cannot exceed maximum bet"
+	}
+
+	if t.EntryFee != nil && (t.MinBet != nil || t.MaxBet != nil || t.MaxStakePerPlayer != nil) {
+		return "Entry fee cannot be combined with bet or stake limits"
+	}
+
+	return ""
+}
+
+// validateBettingWindow returns a message describing why the betting window
+// is invalid, or an empty string when it is consistent.
+func validateBettingWindow(t *models.Tournament) string {
+	if t.BettingOpensAt != nil && t.BettingClosesAt != nil && !t.BettingOpensAt.Before(*t.BettingClosesAt) {
+		return "Betting must open before it closes"
+	}
+
+	if t.BettingOpensAt != nil && t.BettingClosesAt == nil && !t.BettingOpensAt.Before(t.EndDate) {
+		return "Betting must open before the end date"
+	}
+
+	if t.LateRegistrationMinutes != nil && *t.LateRegistrationMinutes < 0 {
+		return "Late registration period cannot be negative"
+	}
+
+	return ""
+}
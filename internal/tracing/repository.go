@@ -0,0 +1,348 @@
+package tracing
+
+import (
+	"context"
+	"igaming/internal/models"
+	"igaming/internal/repository"
+	"time"
+)
+
+// Store returns s with every repository method run in a span named after
+// the interface and method, as in "TournamentRepository.GetForUpdate". The
+// SQL spans of a method are its children. The repositories handed to a unit
+// of work are traced the same way.
+func Store(s *repository.Store) *repository.Store {
+	return &repository.Store{
+		Players:     players{s.Players},
+		Tournaments: tournaments{s.Tournaments},
+		Bets:        bets{s.Bets},
+		Seasons:     seasons{s.Seasons},
+		Ratings:     ratings{s.Ratings},
+		Limits:      limits{s.Limits},
+		Exclusions:  exclusions{s.Exclusions},
+		Snapshots:   snapshots{s.Snapshots},
+		UnitOfWork:  unitOfWork{s.UnitOfWork},
+	}
+}
+
+type players struct {
+	next repository.PlayerRepository
+}
+
+func (r players) Create(ctx context.Context, player *models.Player) (err error) {
+	ctx, span := Start(ctx, "PlayerRepository.Create")
+	defer func() { End(span, err) }()
+	return r.next.Create(ctx, player)
+}
+
+func (r players) GetAllPlayers(ctx context.Context) (_ []models.Player, err error) {
+	ctx, span := Start(ctx, "PlayerRepository.GetAllPlayers")
+	defer func() { End(span, err) }()
+	return r.next.GetAllPlayers(ctx)
+}
+
+func (r players) GetPlayerByID(ctx context.Context, id uint) (_ *models.Player, err error) {
+	ctx, span := Start(ctx, "PlayerRepository.GetPlayerByID")
+	defer func() { End(span, err) }()
+	return r.next.GetPlayerByID(ctx, id)
+}
+
+func (r players) GetForUpdate(ctx context.Context, id uint) (_ *models.Player, err error) {
+	ctx, span := Start(ctx, "PlayerRepository.GetForUpdate")
+	defer func() { End(span, err) }()
+	return r.next.GetForUpdate(ctx, id)
+}
+
+func (r players) AdjustBalance(ctx context.Context, id uint, delta float64) (err error) {
+	ctx, span := Start(ctx, "PlayerRepository.AdjustBalance")
+	defer func() { End(span, err) }()
+	return r.next.AdjustBalance(ctx, id, delta)
+}
+
+func (r players) TotalBalance(ctx context.Context) (_ float64, err error) {
+	ctx, span := Start(ctx, "PlayerRepository.TotalBalance")
+	defer func() { End(span, err) }()
+	return r.next.TotalBalance(ctx)
+}
+
+func (r players) GetRankings(ctx context.Context) (_ []models.PlayerRanking, err error) {
+	ctx, span := Start(ctx, "PlayerRepository.GetRankings")
+	defer func() { End(span, err) }()
+	return r.next.GetRankings(ctx)
+}
+
+type tournaments struct {
+	next repository.TournamentRepository
+}
+
+func (r tournaments) Create(ctx context.Context, tournament *models.Tournament) (err error) {
+	ctx, span := Start(ctx, "TournamentRepository.Create")
+	defer func() { End(span, err) }()
+	return r.next.Create(ctx, tournament)
+}
+
+func (r tournaments) GetAllTournaments(ctx context.Context) (_ []models.Tournament, err error) {
+	ctx, span := Start(ctx, "TournamentRepository.GetAllTournaments")
+	defer func() { End(span, err) }()
+	return r.next.GetAllTournaments(ctx)
+}
+
+func (r tournaments) GetTournamentByID(ctx context.Context, id uint) (_ *models.Tournament, err error) {
+	ctx, span := Start(ctx, "TournamentRepository.GetTournamentByID")
+	defer func() { End(span, err) }()
+	return r.next.GetTournamentByID(ctx, id)
+}
+
+func (r tournaments) GetForUpdate(ctx context.Context, id uint) (_ *models.Tournament, err error) {
+	ctx, span := Start(ctx, "TournamentRepository.GetForUpdate")
+	defer func() { End(span, err) }()
+	return r.next.GetForUpdate(ctx, id)
+}
+
+func (r tournaments) Exists(ctx context.Context, id uint) (_ bool, err error) {
+	ctx, span := Start(ctx, "TournamentRepository.Exists")
+	defer func() { End(span, err) }()
+	return r.next.Exists(ctx, id)
+}
+
+func (r tournaments) AddToPrizePool(ctx context.Context, id uint, amount, rake float64) (err error) {
+	ctx, span := Start(ctx, "TournamentRepository.AddToPrizePool")
+	defer func() { End(span, err) }()
+	return r.next.AddToPrizePool(ctx, id, amount, rake)
+}
+
+func (r tournaments) CreateResults(ctx context.Context, results []models.TournamentResult) (err error) {
+	ctx, span := Start(ctx, "TournamentRepository.CreateResults")
+	defer func() { End(span, err) }()
+	return r.next.CreateResults(ctx, results)
+}
+
+func (r tournaments) MarkPrizesDistributed(ctx context.Context, id uint) (err error) {
+	ctx, span := Start(ctx, "TournamentRepository.MarkPrizesDistributed")
+	defer func() { End(span, err) }()
+	return r.next.MarkPrizesDistributed(ctx, id)
+}
+
+func (r tournaments) GetStandings(ctx context.Context, tournamentID uint) (_ []models.TournamentStanding, err error) {
+	ctx, span := Start(ctx, "TournamentRepository.GetStandings")
+	defer func() { End(span, err) }()
+	return r.next.GetStandings(ctx, tournamentID)
+}
+
+func (r tournaments) GetAllStandings(ctx context.Context) (_ map[uint][]models.TournamentStanding, err error) {
+	ctx, span := Start(ctx, "TournamentRepository.GetAllStandings")
+	defer func() { End(span, err) }()
+	return r.next.GetAllStandings(ctx)
+}
+
+func (r tournaments) GetResults(ctx context.Context, tournamentID uint) (_ []models.TournamentResult, err error) {
+	ctx, span := Start(ctx, "TournamentRepository.GetResults")
+	defer func() { End(span, err) }()
+	return r.next.GetResults(ctx, tournamentID)
+}
+
+type bets struct {
+	next repository.TournamentBetRepository
+}
+
+func (r bets) Create(ctx context.Context, bet *models.TournamentBet) (err error) {
+	ctx, span := Start(ctx, "TournamentBetRepository.Create")
+	defer func() { End(span, err) }()
+	return r.next.Create(ctx, bet)
+}
+
+func (r bets) GetAll(ctx context.Context) (_ []models.TournamentBet, err error) {
+	ctx, span := Start(ctx, "TournamentBetRepository.GetAll")
+	defer func() { End(span, err) }()
+	return r.next.GetAll(ctx)
+}
+
+func (r bets) GetPlayerStake(ctx context.Context, tournamentID, playerID uint) (count int, stake float64, err error) {
+	ctx, span := Start(ctx, "TournamentBetRepository.GetPlayerStake")
+	defer func() { End(span, err) }()
+	return r.next.GetPlayerStake(ctx, tournamentID, playerID)
+}
+
+func (r bets) CountParticipants(ctx context.Context, tournamentID uint) (_ int, err error) {
+	ctx, span := Start(ctx, "TournamentBetRepository.CountParticipants")
+	defer func() { End(span, err) }()
+	return r.next.CountParticipants(ctx, tournamentID)
+}
+
+type seasons struct {
+	next repository.SeasonRepository
+}
+
+func (r seasons) Create(ctx context.Context, season *models.Season) (err error) {
+	ctx, span := Start(ctx, "SeasonRepository.Create")
+	defer func() { End(span, err) }()
+	return r.next.Create(ctx, season)
+}
+
+func (r seasons) GetAll(ctx context.Context) (_ []models.Season, err error) {
+	ctx, span := Start(ctx, "SeasonRepository.GetAll")
+	defer func() { End(span, err) }()
+	return r.next.GetAll(ctx)
+}
+
+func (r seasons) GetByID(ctx context.Context, id uint) (_ *models.Season, err error) {
+	ctx, span := Start(ctx, "SeasonRepository.GetByID")
+	defer func() { End(span, err) }()
+	return r.next.GetByID(ctx, id)
+}
+
+func (r seasons) GetForUpdate(ctx context.Context, id uint) (_ *models.Season, err error) {
+	ctx, span := Start(ctx, "SeasonRepository.GetForUpdate")
+	defer func() { End(span, err) }()
+	return r.next.GetForUpdate(ctx, id)
+}
+
+func (r seasons) SetPoints(ctx context.Context, seasonID uint, points []models.SeasonPoints) (err error) {
+	ctx, span := Start(ctx, "SeasonRepository.SetPoints")
+	defer func() { End(span, err) }()
+	return r.next.SetPoints(ctx, seasonID, points)
+}
+
+func (r seasons) GetStandings(ctx context.Context, seasonID uint) (_ []models.SeasonStanding, err error) {
+	ctx, span := Start(ctx, "SeasonRepository.GetStandings")
+	defer func() { End(span, err) }()
+	return r.next.GetStandings(ctx, seasonID)
+}
+
+func (r seasons) CreateResults(ctx context.Context, results []models.SeasonResult) (err error) {
+	ctx, span := Start(ctx, "SeasonRepository.CreateResults")
+	defer func() { End(span, err) }()
+	return r.next.CreateResults(ctx, results)
+}
+
+func (r seasons) MarkPrizesDistributed(ctx context.Context, id uint) (err error) {
+	ctx, span := Start(ctx, "SeasonRepository.MarkPrizesDistributed")
+	defer func() { End(span, err) }()
+	return r.next.MarkPrizesDistributed(ctx, id)
+}
+
+func (r seasons) GetResults(ctx context.Context, seasonID uint) (_ []models.SeasonResult, err error) {
+	ctx, span := Start(ctx, "SeasonRepository.GetResults")
+	defer func() { End(span, err) }()
+	return r.next.GetResults(ctx, seasonID)
+}
+
+type ratings struct {
+	next repository.RatingRepository
+}
+
+func (r ratings) ApplyPending(ctx context.Context) (_ int, err error) {
+	ctx, span := Start(ctx, "RatingRepository.ApplyPending")
+	defer func() { End(span, err) }()
+	return r.next.ApplyPending(ctx)
+}
+
+func (r ratings) Recompute(ctx context.Context) (tournaments, players int, err error) {
+	ctx, span := Start(ctx, "RatingRepository.Recompute")
+	defer func() { End(span, err) }()
+	return r.next.Recompute(ctx)
+}
+
+func (r ratings) GetRating(ctx context.Context, playerID uint) (_ *models.PlayerRating, err error) {
+	ctx, span := Start(ctx, "RatingRepository.GetRating")
+	defer func() { End(span, err) }()
+	return r.next.GetRating(ctx, playerID)
+}
+
+func (r ratings) GetHistory(ctx context.Context, playerID uint, limit int) (_ []models.RatingChange, err error) {
+	ctx, span := Start(ctx, "RatingRepository.GetHistory")
+	defer func() { End(span, err) }()
+	return r.next.GetHistory(ctx, playerID, limit)
+}
+
+func (r ratings) GetLeaderboard(ctx context.Context, offset, limit int) (_ []models.PlayerRating, _ int, err error) {
+	ctx, span := Start(ctx, "RatingRepository.GetLeaderboard")
+	defer func() { End(span, err) }()
+	return r.next.GetLeaderboard(ctx, offset, limit)
+}
+
+type limits struct {
+	next repository.PlayerLimitRepository
+}
+
+func (r limits) GetLimits(ctx context.Context, playerID uint) (_ []models.PlayerLimit, err error) {
+	ctx, span := Start(ctx, "PlayerLimitRepository.GetLimits")
+	defer func() { End(span, err) }()
+	return r.next.GetLimits(ctx, playerID)
+}
+
+func (r limits) SetLimits(ctx context.Context, playerID uint, changes []models.PlayerLimit) (err error) {
+	ctx, span := Start(ctx, "PlayerLimitRepository.SetLimits")
+	defer func() { End(span, err) }()
+	return r.next.SetLimits(ctx, playerID, changes)
+}
+
+type exclusions struct {
+	next repository.PlayerExclusionRepository
+}
+
+func (r exclusions) Create(ctx context.Context, exclusion *models.PlayerExclusion) (err error) {
+	ctx, span := Start(ctx, "PlayerExclusionRepository.Create")
+	defer func() { End(span, err) }()
+	return r.next.Create(ctx, exclusion)
+}
+
+func (r exclusions) GetByPlayer(ctx context.Context, playerID uint) (_ []models.PlayerExclusion, err error) {
+	ctx, span := Start(ctx, "PlayerExclusionRepository.GetByPlayer")
+	defer func() { End(span, err) }()
+	return r.next.GetByPlayer(ctx, playerID)
+}
+
+func (r exclusions) GetActive(ctx context.Context) (_ []models.PlayerExclusion, err error) {
+	ctx, span := Start(ctx, "PlayerExclusionRepository.GetActive")
+	defer func() { End(span, err) }()
+	return r.next.GetActive(ctx)
+}
+
+func (r exclusions) CheckNotExcluded(ctx context.Context, playerID uint) (err error) {
+	ctx, span := Start(ctx, "PlayerExclusionRepository.CheckNotExcluded")
+	defer func() { End(span, err) }()
+	return r.next.CheckNotExcluded(ctx, playerID)
+}
+
+type snapshots struct {
+	next repository.RankingSnapshotRepository
+}
+
+func (r snapshots) Take(ctx context.Context) (err error) {
+	ctx, span := Start(ctx, "RankingSnapshotRepository.Take")
+	defer func() { End(span, err) }()
+	return r.next.Take(ctx)
+}
+
+func (r snapshots) RanksAt(ctx context.Context, at time.Time) (_ map[uint]int, err error) {
+	ctx, span := Start(ctx, "RankingSnapshotRepository.RanksAt")
+	defer func() { End(span, err) }()
+	return r.next.RanksAt(ctx, at)
+}
+
+func (r snapshots) GetHistory(ctx context.Context, playerID uint, from, to time.Time) (_ []models.RankingSnapshot, err error) {
+	ctx, span := Start(ctx, "RankingSnapshotRepository.GetHistory")
+	defer func() { End(span, err) }()
+	return r.next.GetHistory(ctx, playerID, from, to)
+}
+
+func (r snapshots) Prune(ctx context.Context, hourlyBefore, before time.Time) (err error) {
+	ctx, span := Start(ctx, "RankingSnapshotRepository.Prune")
+	defer func() { End(span, err) }()
+	return r.next.Prune(ctx, hourlyBefore, before)
+}
+
+type unitOfWork struct {
+	next repository.UnitOfWork
+}
+
+// Do runs the unit of work in a "UnitOfWork.Do" span, with the repositories
+// passed to fn traced under it.
+func (u unitOfWork) Do(ctx context.Context, fn func(tx *repository.Store) error) (err error) {
+	ctx, span := Start(ctx, "UnitOfWork.Do")
+	defer func() { End(span, err) }()
+	return u.next.Do(ctx, func(tx *repository.Store) error {
+		return fn(Store(tx))
+	})
+}
@@ -0,0 +1,132 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"igaming/internal/dialect"
+	"strings"
+	"unicode"
+
+	"github.com/XSAM/otelsql"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// OpenDB opens a database like sql.Open, with a span for each statement,
+// transaction begin, commit and rollback run within a trace.
+func OpenDB(driverName, dsn string, d dialect.Dialect) (*sql.DB, error) {
+	return otelsql.Open(driverName, dsn, sqlOptions(d)...)
+}
+
+// OpenConnector is OpenDB for a driver connector, like sql.OpenDB.
+func OpenConnector(c driver.Connector, d dialect.Dialect) *sql.DB {
+	return otelsql.OpenDB(c, sqlOptions(d)...)
+}
+
+// sqlOptions names each span after the statement's operation and table, as
+// in "SELECT players FOR UPDATE". The statement text is left out; the values
+// it was run with are never recorded.
+func sqlOptions(d dialect.Dialect) []otelsql.Option {
+	system := map[dialect.Dialect]attribute.KeyValue{
+		dialect.MySQL:    semconv.DBSystemNameMySQL,
+		dialect.Postgres: semconv.DBSystemNamePostgreSQL,
+		dialect.SQLite:   semconv.DBSystemNameSQLite,
+	}[d]
+
+	return []otelsql.Option{
+		otelsql.WithAttributes(system),
+		otelsql.WithSpanNameFormatter(spanName),
+		otelsql.WithAttributesGetter(statementAttributes),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{
+			DisableQuery:         true,
+			DisableErrSkip:       true,
+			OmitConnResetSession: true,
+			OmitConnPrepare:      true,
+			OmitRows:             true,
+			OmitConnectorConnect: true,
+			// Statements outside a request or job, such as migrations, would
+			// each start a trace of their own.
+			SpanFilter: func(ctx context.Context, _ otelsql.Method, _ string, _ []driver.NamedValue) bool {
+				return trace.SpanContextFromContext(ctx).IsValid()
+			},
+		}),
+	}
+}
+
+func spanName(_ context.Context, method otelsql.Method, query string) string {
+	st := parseStatement(query)
+	if st.operation == "" {
+		return string(method)
+	}
+
+	name := st.operation
+	if st.table != "" {
+		name += " " + st.table
+	}
+	if st.locking {
+		name += " FOR UPDATE"
+	}
+	return name
+}
+
+func statementAttributes(_ context.Context, _ otelsql.Method, query string, _ []driver.NamedValue) []attribute.KeyValue {
+	st := parseStatement(query)
+	if st.operation == "" {
+		return nil
+	}
+
+	attrs := []attribute.KeyValue{semconv.DBOperationName(st.operation)}
+	if st.table != "" {
+		attrs = append(attrs, semconv.DBCollectionName(st.table))
+	}
+	return attrs
+}
+
+// statement is what a span tells about a SQL statement.
+type statement struct {
+	// SELECT, INSERT, SAVEPOINT, ...
+	operation string
+	// The table read from, inserted into, updated or deleted from
+	table string
+	// SELECT ... FOR UPDATE
+	locking bool
+}
+
+// parseStatement reads the operation and table of query well enough to name
+// a span. Statements it does not understand get just their first keyword.
+func parseStatement(query string) statement {
+	words := strings.FieldsFunc(query, func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune("(),;", r)
+	})
+	if len(words) == 0 {
+		return statement{}
+	}
+
+	st := statement{operation: strings.ToUpper(words[0])}
+	var tableAfter string
+	switch st.operation {
+	case "SELECT", "DELETE":
+		tableAfter = "FROM"
+	case "INSERT", "REPLACE":
+		tableAfter = "INTO"
+	case "UPDATE":
+		tableAfter = "UPDATE"
+	}
+
+	for i := 0; tableAfter != "" && i+1 < len(words); i++ {
+		if strings.EqualFold(words[i], tableAfter) {
+			// FROM (SELECT ...) reads from a subquery, not a table.
+			if table := strings.Trim(words[i+1], "`\""); !strings.EqualFold(table, "SELECT") {
+				st.table = table
+			}
+			break
+		}
+	}
+
+	n := len(words)
+	st.locking = st.operation == "SELECT" && n >= 2 &&
+		strings.EqualFold(words[n-2], "FOR") && strings.EqualFold(words[n-1], "UPDATE")
+	return st
+}
@@ -1,5 +1,5 @@
 #!/bin/sh
-until nc -z -v -w30 db 3306
+until nc -z -v -w30 "${DB_HOST:-db}" "${DB_PORT:-5432}"
 do
   echo "Waiting for database connection..."
   sleep 5